@@ -0,0 +1,165 @@
+// Package backup exports the restaurant collections into an archive and
+// restores them from it.
+//
+// An archive is a gzip compressed tar holding one NDJSON file per collection,
+// data/<collection>.ndjson, with a document per line in canonical MongoDB
+// Extended JSON so that ObjectIDs, dates and number types survive the round
+// trip. manifest.json comes last and records the format version, the number
+// of documents and the SHA-256 of every file.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"io"
+	"os"
+	"time"
+)
+
+// FormatVersion is the version of the archives written by Backup. Restore
+// reads archives up to this version.
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Collections are the collections saved by Backup, in the order they are
+// restored.
+var Collections = []string{"user", "menu", "food", "table", "order", "orderItem", "invoice", "webhook"}
+
+// Database is where the collections are exported from and imported into.
+type Database interface {
+	// Export calls fn with every document of collection.
+	Export(ctx context.Context, collection string, fn func(doc bson.Raw) error) error
+	// Import inserts docs into collection. Documents whose _id already exists
+	// are replaced when replace is set, skipped otherwise.
+	Import(ctx context.Context, collection string, docs []bson.Raw, replace bool) (ImportResult, error)
+	// Existing counts the documents of collection having one of ids.
+	Existing(ctx context.Context, collection string, ids []interface{}) (int64, error)
+	// Clear deletes every document of collection.
+	Clear(ctx context.Context, collection string) error
+}
+
+type ImportResult struct {
+	Inserted int64
+	Replaced int64
+	Skipped  int64
+}
+
+type Manifest struct {
+	FormatVersion int              `json:"format_version"`
+	CreatedAt     time.Time        `json:"created_at"`
+	Collections   []CollectionFile `json:"collections"`
+}
+
+type CollectionFile struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	Documents int64  `json:"documents"`
+	SHA256    string `json:"sha256"`
+}
+
+func dataFile(collection string) string {
+	return "data/" + collection + ".ndjson"
+}
+
+// Backup writes every collection of db into w and returns the manifest of
+// the archive.
+func Backup(ctx context.Context, db Database, w io.Writer) (*Manifest, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	for _, collection := range Collections {
+		file, err := exportCollection(ctx, db, tw, collection)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", collection, err)
+		}
+		manifest.Collections = append(manifest.Collections, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, manifestName, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// exportCollection spools the collection to a temporary file first, tar
+// needs the size of a file before its content.
+func exportCollection(ctx context.Context, db Database, tw *tar.Writer, collection string) (CollectionFile, error) {
+	file := CollectionFile{Name: collection, File: dataFile(collection)}
+
+	tmp, err := os.CreateTemp("", "rmctl-"+collection+"-*.ndjson")
+	if err != nil {
+		return file, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(tmp, hash))
+
+	err = db.Export(ctx, collection, func(doc bson.Raw) error {
+		line, err := bson.MarshalExtJSON(doc, true, false)
+		if err != nil {
+			return err
+		}
+		file.Documents++
+		if _, err := buffered.Write(line); err != nil {
+			return err
+		}
+		return buffered.WriteByte('\n')
+	})
+	if err != nil {
+		return file, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return file, err
+	}
+	file.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return file, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return file, err
+	}
+	return file, writeFile(tw, file.File, size, tmp)
+}
+
+func writeFile(tw *tar.Writer, name string, size int64, content io.Reader) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, content)
+	return err
+}
@@ -0,0 +1,356 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDatabase keeps the documents of every collection in memory, in the
+// order they were inserted.
+type fakeDatabase struct {
+	collections map[string][]bson.Raw
+}
+
+func newFakeDatabase() *fakeDatabase {
+	return &fakeDatabase{collections: map[string][]bson.Raw{}}
+}
+
+func (f *fakeDatabase) insert(t *testing.T, collection string, docs ...bson.D) {
+	t.Helper()
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.collections[collection] = append(f.collections[collection], raw)
+	}
+}
+
+func (f *fakeDatabase) find(collection string, id bson.RawValue) int {
+	for i, doc := range f.collections[collection] {
+		if doc.Lookup("_id").Equal(id) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeDatabase) Export(ctx context.Context, collection string, fn func(doc bson.Raw) error) error {
+	for _, doc := range f.collections[collection] {
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeDatabase) Import(ctx context.Context, collection string, docs []bson.Raw, replace bool) (ImportResult, error) {
+	var result ImportResult
+	for _, doc := range docs {
+		i := f.find(collection, doc.Lookup("_id"))
+		switch {
+		case i < 0:
+			f.collections[collection] = append(f.collections[collection], doc)
+			result.Inserted++
+		case replace:
+			f.collections[collection][i] = doc
+			result.Replaced++
+		default:
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeDatabase) Existing(ctx context.Context, collection string, ids []interface{}) (int64, error) {
+	var existing int64
+	for _, id := range ids {
+		if f.find(collection, id.(bson.RawValue)) >= 0 {
+			existing++
+		}
+	}
+	return existing, nil
+}
+
+func (f *fakeDatabase) Clear(ctx context.Context, collection string) error {
+	delete(f.collections, collection)
+	return nil
+}
+
+var (
+	menuId  = primitive.NewObjectID()
+	foodIds = []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+)
+
+// restaurant returns a database holding a menu and its foods, with the types
+// the archive has to keep.
+func restaurant(t *testing.T) *fakeDatabase {
+	t.Helper()
+	createdAt := time.Date(2026, time.October, 19, 12, 30, 0, 0, time.UTC)
+	price, _ := primitive.ParseDecimal128("4.50")
+
+	db := newFakeDatabase()
+	db.insert(t, "menu", bson.D{{"_id", menuId}, {"name", "Lunch"}, {"created_at", createdAt}})
+	db.insert(t, "food",
+		bson.D{{"_id", foodIds[0]}, {"name", "Soup"}, {"price", 4.5}, {"daily_limit", int32(10)}, {"menu_id", menuId.Hex()}},
+		bson.D{{"_id", foodIds[1]}, {"name", "Cake"}, {"price", price}, {"portions_sold", int64(3)}, {"tags", bson.A{"vegan"}}},
+	)
+	return db
+}
+
+func archive(t *testing.T, db Database) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := Backup(context.Background(), db, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// rewrite returns data with its files changed by edit, in the same order.
+func rewrite(t *testing.T, data []byte, edit func(files map[string][]byte)) []byte {
+	t.Helper()
+	var names []string
+	files := map[string][]byte{}
+	err := walk(bytes.NewReader(data), func(name string, content io.Reader) error {
+		b, err := io.ReadAll(content)
+		names = append(names, name)
+		files[name] = b
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit(files)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name := range files {
+		if !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if content, ok := files[name]; ok {
+			if err := writeFile(tw, name, int64(len(content)), bytes.NewReader(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// editManifest changes the manifest of files with edit.
+func editManifest(t *testing.T, files map[string][]byte, edit func(m *Manifest)) {
+	t.Helper()
+	var manifest Manifest
+	if err := json.Unmarshal(files[manifestName], &manifest); err != nil {
+		t.Fatal(err)
+	}
+	edit(&manifest)
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files[manifestName] = data
+}
+
+func TestRoundTrip(t *testing.T) {
+	source := restaurant(t)
+	data := archive(t, source)
+
+	manifest, err := Verify(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.FormatVersion != FormatVersion || len(manifest.Collections) != len(Collections) {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	for _, file := range manifest.Collections {
+		if expected := int64(len(source.collections[file.Name])); file.Documents != expected {
+			t.Errorf("%s: expected %d documents in the manifest, got %d", file.Name, expected, file.Documents)
+		}
+	}
+
+	target := newFakeDatabase()
+	report, err := Restore(context.Background(), target, bytes.NewReader(data), RestoreOptions{Policy: Abort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Collections) != len(Collections) {
+		t.Fatalf("expected a report of every collection, got %+v", report.Collections)
+	}
+	for _, collection := range Collections {
+		expected, got := source.collections[collection], target.collections[collection]
+		if len(got) != len(expected) {
+			t.Fatalf("%s: expected %d documents, got %d", collection, len(expected), len(got))
+		}
+		// the documents come back byte for byte, types included
+		for i := range expected {
+			if !bytes.Equal(got[i], expected[i]) {
+				t.Errorf("%s: expected %s, got %s", collection, expected[i], got[i])
+			}
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := archive(t, restaurant(t))
+
+	for _, test := range []struct {
+		name  string
+		edit  func(files map[string][]byte)
+		error string
+	}{
+		{"tampered document", func(files map[string][]byte) {
+			files["data/food.ndjson"] = bytes.Replace(files["data/food.ndjson"], []byte("Soup"), []byte("Stew"), 1)
+		}, "data/food.ndjson is corrupted"},
+		{"invalid document", func(files map[string][]byte) {
+			files["data/menu.ndjson"] = append(files["data/menu.ndjson"], "{not json\n"...)
+		}, "data/menu.ndjson: line 2"},
+		{"document without _id", func(files map[string][]byte) {
+			files["data/menu.ndjson"] = append(files["data/menu.ndjson"], "{\"name\": \"Dinner\"}\n"...)
+		}, "document has no _id"},
+		{"missing file", func(files map[string][]byte) {
+			delete(files, "data/food.ndjson")
+		}, "data/food.ndjson is listed in the manifest but missing"},
+		{"file not in the manifest", func(files map[string][]byte) {
+			editManifest(t, files, func(m *Manifest) { m.Collections = m.Collections[:len(m.Collections)-1] })
+		}, "data/webhook.ndjson is not listed in the manifest"},
+		{"document count", func(files map[string][]byte) {
+			editManifest(t, files, func(m *Manifest) { m.Collections[2].Documents = 3 })
+		}, "data/food.ndjson holds 2 documents, the manifest says 3"},
+		{"unknown collection", func(files map[string][]byte) {
+			editManifest(t, files, func(m *Manifest) { m.Collections[0].Name = "secrets" })
+		}, `unknown collection "secrets"`},
+		{"unexpected file", func(files map[string][]byte) {
+			files["notes.txt"] = []byte("hello")
+		}, `unexpected file "notes.txt"`},
+		{"newer format", func(files map[string][]byte) {
+			editManifest(t, files, func(m *Manifest) { m.FormatVersion = FormatVersion + 1 })
+		}, "is not supported"},
+		{"no manifest", func(files map[string][]byte) {
+			delete(files, manifestName)
+		}, "archive has no manifest"},
+	} {
+		_, err := Verify(bytes.NewReader(rewrite(t, data, test.edit)))
+		if err == nil || !strings.Contains(err.Error(), test.error) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.error, err)
+		}
+	}
+
+	if _, err := Verify(strings.NewReader("not an archive")); err == nil || !strings.Contains(err.Error(), "not gzip compressed") {
+		t.Errorf("expected a plain file to be rejected, got %v", err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	data := archive(t, restaurant(t))
+
+	// the target already has a food of the archive, renamed, and one of its own
+	target := func() *fakeDatabase {
+		db := newFakeDatabase()
+		db.insert(t, "food",
+			bson.D{{"_id", foodIds[0]}, {"name", "Renamed soup"}},
+			bson.D{{"_id", primitive.NewObjectID()}, {"name", "Bread"}},
+		)
+		return db
+	}
+	names := func(db *fakeDatabase) []string {
+		var names []string
+		for _, doc := range db.collections["food"] {
+			names = append(names, doc.Lookup("name").StringValue())
+		}
+		return names
+	}
+
+	for _, test := range []struct {
+		name   string
+		opts   RestoreOptions
+		error  string
+		foods  []string
+		report ImportResult
+		menus  int
+	}{
+		{"abort", RestoreOptions{Policy: Abort}, "1 documents of food already exist", []string{"Renamed soup", "Bread"}, ImportResult{}, 0},
+		{"skip", RestoreOptions{Policy: Skip}, "", []string{"Renamed soup", "Bread", "Cake"}, ImportResult{Inserted: 1, Skipped: 1}, 1},
+		{"replace", RestoreOptions{Policy: Replace}, "", []string{"Soup", "Bread", "Cake"}, ImportResult{Inserted: 1, Replaced: 1}, 1},
+		{"abort after clearing", RestoreOptions{Policy: Abort, Clear: true}, "", []string{"Soup", "Cake"}, ImportResult{Inserted: 2}, 1},
+		{"dry run", RestoreOptions{Policy: Replace, DryRun: true}, "", []string{"Renamed soup", "Bread"}, ImportResult{}, 0},
+	} {
+		db := target()
+		report, err := Restore(context.Background(), db, bytes.NewReader(data), test.opts)
+		if test.error != "" {
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("%s: expected %q, got %v", test.name, test.error, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else {
+			var food ImportResult
+			for _, collection := range report.Collections {
+				if collection.Name == "food" {
+					food = collection.ImportResult
+				}
+			}
+			if food != test.report {
+				t.Errorf("%s: expected the foods to be imported as %+v, got %+v", test.name, test.report, food)
+			}
+		}
+
+		if foods := names(db); strings.Join(foods, ",") != strings.Join(test.foods, ",") {
+			t.Errorf("%s: expected the foods %v, got %v", test.name, test.foods, foods)
+		}
+		if menus := len(db.collections["menu"]); menus != test.menus {
+			t.Errorf("%s: expected %d menus, got %d", test.name, test.menus, menus)
+		}
+	}
+
+	// a damaged archive writes nothing, whatever the policy
+	damaged := rewrite(t, data, func(files map[string][]byte) {
+		files["data/menu.ndjson"] = bytes.Replace(files["data/menu.ndjson"], []byte("Lunch"), []byte("Brunch"), 1)
+	})
+	db := target()
+	if _, err := Restore(context.Background(), db, bytes.NewReader(damaged), RestoreOptions{Policy: Replace, Clear: true}); err == nil {
+		t.Fatal("expected the damaged archive to be rejected")
+	}
+	if foods := names(db); len(foods) != 2 || len(db.collections["menu"]) != 0 {
+		t.Fatalf("expected nothing to be written, got the foods %v", foods)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, value := range []string{"abort", "skip", "replace"} {
+		if policy, err := ParsePolicy(value); err != nil || string(policy) != value {
+			t.Errorf("expected %q to be parsed, got %q, %v", value, policy, err)
+		}
+	}
+	if _, err := ParsePolicy("merge"); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDatabase struct {
+	db *mongo.Database
+}
+
+// NewMongoDatabase exports and imports the collections of db.
+func NewMongoDatabase(db *mongo.Database) Database {
+	return mongoDatabase{db: db}
+}
+
+func (m mongoDatabase) Export(ctx context.Context, collection string, fn func(doc bson.Raw) error) error {
+	cursor, err := m.db.Collection(collection).Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (m mongoDatabase) Import(ctx context.Context, collection string, docs []bson.Raw, replace bool) (ImportResult, error) {
+	models := make([]mongo.WriteModel, len(docs))
+	for i, doc := range docs {
+		if replace {
+			filter := bson.D{{"_id", doc.Lookup("_id")}}
+			models[i] = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(doc).SetUpsert(true)
+		} else {
+			models[i] = mongo.NewInsertOneModel().SetDocument(doc)
+		}
+	}
+
+	// unordered so that a duplicate doesn't stop the rest of the batch
+	result, err := m.db.Collection(collection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	var imported ImportResult
+	if result != nil {
+		imported.Inserted = result.InsertedCount + result.UpsertedCount
+		imported.Replaced = result.MatchedCount
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !replace && errors.As(err, &bulkErr) && onlyDuplicates(bulkErr) {
+		imported.Skipped = int64(len(bulkErr.WriteErrors))
+		imported.Inserted = int64(len(docs)) - imported.Skipped
+		return imported, nil
+	}
+	return imported, err
+}
+
+func onlyDuplicates(err mongo.BulkWriteException) bool {
+	if err.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range err.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m mongoDatabase) Existing(ctx context.Context, collection string, ids []interface{}) (int64, error) {
+	return m.db.Collection(collection).CountDocuments(ctx, bson.D{{"_id", bson.D{{"$in", ids}}}})
+}
+
+func (m mongoDatabase) Clear(ctx context.Context, collection string) error {
+	_, err := m.db.Collection(collection).DeleteMany(ctx, bson.D{})
+	return err
+}
@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"os"
+	"testing"
+	"time"
+)
+
+// mongoURL names the MongoDB server the Mongo database is tested against,
+// e.g.
+//
+//	MONGODB_TEST_URL=mongodb://localhost:27017 go test ./backup
+var mongoURL = os.Getenv("MONGODB_TEST_URL")
+
+func TestOnlyDuplicates(t *testing.T) {
+	duplicate := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 11000, Message: "E11000 duplicate key error"}}
+	invalid := mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 121, Message: "Document failed validation"}}
+
+	for _, test := range []struct {
+		name     string
+		err      mongo.BulkWriteException
+		expected bool
+	}{
+		{"duplicates", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, duplicate}}, true},
+		{"another error", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate, invalid}}, false},
+		{"write concern", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{duplicate}, WriteConcernError: &mongo.WriteConcernError{Code: 64}}, false},
+	} {
+		if got := onlyDuplicates(test.err); got != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
+
+// newMongoDatabase returns a new database of the test server, dropped once
+// the test is over.
+func newMongoDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	if mongoURL == "" {
+		t.Skip("MONGODB_TEST_URL is not set")
+	}
+	ctx := context.Background()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := client.Database(fmt.Sprintf("backup_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestMongoDatabase(t *testing.T) {
+	ctx := context.Background()
+	source, target := NewMongoDatabase(newMongoDatabase(t)), NewMongoDatabase(newMongoDatabase(t))
+
+	for collection, docs := range restaurant(t).collections {
+		if _, err := source.Import(ctx, collection, docs, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := archive(t, source)
+
+	if _, err := Restore(ctx, target, bytes.NewReader(data), RestoreOptions{Policy: Abort}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(ctx, target, bytes.NewReader(data), RestoreOptions{Policy: Abort}); err == nil {
+		t.Fatal("expected the documents restored to conflict")
+	}
+
+	report, err := Restore(ctx, target, bytes.NewReader(data), RestoreOptions{Policy: Skip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, collection := range report.Collections {
+		if collection.Name == "food" && collection.ImportResult != (ImportResult{Skipped: 2}) {
+			t.Fatalf("expected the foods to be skipped, got %+v", collection.ImportResult)
+		}
+	}
+
+	report, err = Restore(ctx, target, bytes.NewReader(data), RestoreOptions{Policy: Replace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, collection := range report.Collections {
+		if collection.Name == "food" && collection.ImportResult != (ImportResult{Replaced: 2}) {
+			t.Fatalf("expected the foods to be replaced, got %+v", collection.ImportResult)
+		}
+	}
+
+	// the restored documents are the documents saved
+	if restored := archive(t, target); !sameDocuments(t, data, restored) {
+		t.Fatal("expected the restored database to hold the documents saved")
+	}
+	if err := target.Clear(ctx, "food"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := target.Existing(ctx, "food", []interface{}{foodIds[0], foodIds[1]}); err != nil || n != 0 {
+		t.Fatalf("expected the foods to be cleared, got %d, %v", n, err)
+	}
+}
+
+// sameDocuments compares the data files of two archives, their manifests
+// differing by their dates.
+func sameDocuments(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	files := func(data []byte) map[string][]byte {
+		files := map[string][]byte{}
+		rewrite(t, data, func(all map[string][]byte) {
+			for name, content := range all {
+				if name != manifestName {
+					files[name] = content
+				}
+			}
+		})
+		return files
+	}
+	aFiles, bFiles := files(a), files(b)
+	if len(aFiles) != len(bFiles) {
+		return false
+	}
+	for name, content := range aFiles {
+		if !bytes.Equal(content, bFiles[name]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,312 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"io"
+	"strings"
+)
+
+// Policy decides what happens to the documents of the archive whose _id
+// already exists in the database.
+type Policy string
+
+const (
+	// Abort refuses to restore anything when one document already exists.
+	Abort Policy = "abort"
+	// Skip keeps the existing documents.
+	Skip Policy = "skip"
+	// Replace overwrites the existing documents.
+	Replace Policy = "replace"
+)
+
+func ParsePolicy(value string) (Policy, error) {
+	switch policy := Policy(value); policy {
+	case Abort, Skip, Replace:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown conflict policy %q, expected abort, skip or replace", value)
+}
+
+type RestoreOptions struct {
+	Policy Policy
+	// Clear deletes the documents of the restored collections first.
+	Clear bool
+	// DryRun only validates the archive.
+	DryRun bool
+}
+
+type RestoreReport struct {
+	Manifest    *Manifest
+	Collections []CollectionReport
+}
+
+type CollectionReport struct {
+	Name string
+	ImportResult
+}
+
+// batchSize is the number of documents imported at once.
+const batchSize = 500
+
+// Verify reads the whole archive and checks it against its manifest: format
+// version, checksums, document counts and the documents themselves.
+func Verify(r io.Reader) (*Manifest, error) {
+	type fileStats struct {
+		documents int64
+		sha256    string
+	}
+	files := map[string]fileStats{}
+	var manifest *Manifest
+
+	err := walk(r, func(name string, content io.Reader) error {
+		if name == manifestName {
+			manifest = &Manifest{}
+			return json.NewDecoder(content).Decode(manifest)
+		}
+
+		hash := sha256.New()
+		var documents int64
+		err := eachDocument(io.TeeReader(content, hash), func(doc bson.Raw) error {
+			documents++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		files[name] = fileStats{documents: documents, sha256: hex.EncodeToString(hash.Sum(nil))}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, errors.New("archive has no manifest")
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("archive format version %d is not supported, this build reads up to %d", manifest.FormatVersion, FormatVersion)
+	}
+
+	known := map[string]bool{}
+	for _, collection := range Collections {
+		known[collection] = true
+	}
+
+	for _, file := range manifest.Collections {
+		if !known[file.Name] {
+			return nil, fmt.Errorf("archive holds unknown collection %q", file.Name)
+		}
+		if file.File != dataFile(file.Name) {
+			return nil, fmt.Errorf("collection %s is stored in unexpected file %q", file.Name, file.File)
+		}
+
+		stats, ok := files[file.File]
+		if !ok {
+			return nil, fmt.Errorf("%s is listed in the manifest but missing", file.File)
+		}
+		if stats.sha256 != file.SHA256 {
+			return nil, fmt.Errorf("%s is corrupted, checksum %s does not match the manifest", file.File, stats.sha256)
+		}
+		if stats.documents != file.Documents {
+			return nil, fmt.Errorf("%s holds %d documents, the manifest says %d", file.File, stats.documents, file.Documents)
+		}
+		delete(files, file.File)
+	}
+
+	for name := range files {
+		return nil, fmt.Errorf("%s is not listed in the manifest", name)
+	}
+
+	return manifest, nil
+}
+
+// Restore verifies archive then imports it into db. Nothing is written when
+// the archive is invalid, or with the Abort policy when a document already
+// exists.
+func Restore(ctx context.Context, db Database, archive io.ReadSeeker, opts RestoreOptions) (*RestoreReport, error) {
+	manifest, err := Verify(archive)
+	if err != nil {
+		return nil, err
+	}
+	report := &RestoreReport{Manifest: manifest}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if opts.Policy == Abort && !opts.Clear {
+		if err := checkConflicts(ctx, db, archive); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Clear {
+		for _, file := range manifest.Collections {
+			if err := db.Clear(ctx, file.Name); err != nil {
+				return nil, fmt.Errorf("clear %s: %w", file.Name, err)
+			}
+		}
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	err = walk(archive, func(name string, content io.Reader) error {
+		collection, ok := collectionOf(manifest, name)
+		if !ok {
+			return nil
+		}
+
+		result := CollectionReport{Name: collection}
+		err := eachBatch(content, func(docs []bson.Raw) error {
+			imported, err := db.Import(ctx, collection, docs, opts.Policy == Replace)
+			result.Inserted += imported.Inserted
+			result.Replaced += imported.Replaced
+			result.Skipped += imported.Skipped
+			return err
+		})
+		report.Collections = append(report.Collections, result)
+		if err != nil {
+			return fmt.Errorf("import %s: %w", collection, err)
+		}
+		return nil
+	})
+	return report, err
+}
+
+func checkConflicts(ctx context.Context, db Database, archive io.ReadSeeker) error {
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return walk(archive, func(name string, content io.Reader) error {
+		if name == manifestName {
+			return nil
+		}
+		collection := collectionName(name)
+
+		var conflicts int64
+		err := eachBatch(content, func(docs []bson.Raw) error {
+			ids := make([]interface{}, len(docs))
+			for i, doc := range docs {
+				ids[i] = doc.Lookup("_id")
+			}
+
+			existing, err := db.Existing(ctx, collection, ids)
+			conflicts += existing
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return fmt.Errorf("%d documents of %s already exist, restore with another conflict policy or clear the database", conflicts, collection)
+		}
+		return nil
+	})
+}
+
+func collectionOf(manifest *Manifest, name string) (string, bool) {
+	for _, file := range manifest.Collections {
+		if file.File == name {
+			return file.Name, true
+		}
+	}
+	return "", false
+}
+
+func collectionName(file string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(file, "data/"), ".ndjson")
+}
+
+// walk calls fn with every file of a gzip compressed tar.
+func walk(r io.Reader, fn func(name string, content io.Reader) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("archive is not gzip compressed: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("archive is not a valid tar: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if header.Name != manifestName && !isDataFile(header.Name) {
+			return fmt.Errorf("unexpected file %q in archive", header.Name)
+		}
+		if err := fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func isDataFile(name string) bool {
+	return strings.HasPrefix(name, "data/") && strings.HasSuffix(name, ".ndjson") && collectionName(name) != ""
+}
+
+// eachDocument decodes every line of an NDJSON file.
+func eachDocument(r io.Reader, fn func(doc bson.Raw) error) error {
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			return nil
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var doc bson.Raw
+		if err := bson.UnmarshalExtJSON(line, true, &doc); err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if _, err := doc.LookupErr("_id"); err != nil {
+			return fmt.Errorf("line %d: document has no _id", lineNumber)
+		}
+
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+}
+
+func eachBatch(r io.Reader, fn func(docs []bson.Raw) error) error {
+	var batch []bson.Raw
+	err := eachDocument(r, func(doc bson.Raw) error {
+		batch = append(batch, doc)
+		if len(batch) < batchSize {
+			return nil
+		}
+		err := fn(batch)
+		batch = nil
+		return err
+	})
+	if err != nil || len(batch) == 0 {
+		return err
+	}
+	return fn(batch)
+}
@@ -0,0 +1,22 @@
+// Package cache keeps serialized values for a while so that repeated reads
+// don't reach the database.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key has no value.
+var ErrMiss = errors.New("cache miss")
+
+// Cache is implemented in process by LRU. A shared backend lets several
+// instances of the API share the entries and their invalidation.
+type Cache interface {
+	// Get returns the value of key, ErrMiss when there is none or it expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key for ttl, forever when ttl is 0.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,302 @@
+// Package client is a typed Go client for the restaurant management API.
+//
+// A client created with WithCredentials logs in on its first call and logs in
+// again whenever its access token is about to expire or is rejected as
+// expired, so long running services never have to handle the tokens
+// themselves.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// version prefixes the paths of the API version the client speaks.
+const version = "/v2"
+
+// refreshBefore is how long before its expiry an access token is replaced.
+const refreshBefore = time.Minute
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu        sync.Mutex
+	email     string
+	password  string
+	token     string
+	expiresAt time.Time
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with httpClient instead of
+// http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCredentials lets the client log in, and log in again when its token
+// expires.
+func WithCredentials(email, password string) Option {
+	return func(c *Client) {
+		c.email = email
+		c.password = password
+	}
+}
+
+// WithToken authenticates the requests with an access token obtained
+// elsewhere. Without credentials it is used until the API rejects it.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.setToken(token)
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g.
+// "http://localhost:8080", through the routes of /v2.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the access token the client currently sends, if any.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+func (c *Client) setToken(token string) {
+	c.token = token
+	c.expiresAt = tokenExpiry(token)
+}
+
+// accessToken returns a token valid for at least refreshBefore, logging in
+// when needed and possible.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fresh := c.expiresAt.IsZero() || c.now().Add(refreshBefore).Before(c.expiresAt)
+	if c.token != "" && (fresh || c.email == "") {
+		return c.token, nil
+	}
+
+	if c.email == "" {
+		return "", ErrNoCredentials
+	}
+
+	if _, err := c.logIn(ctx, c.email, c.password); err != nil {
+		return "", err
+	}
+	return c.token, nil
+}
+
+func (c *Client) hasCredentials() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.email != ""
+}
+
+// expire forgets token so that the next call logs in again, unless another
+// call already replaced it.
+func (c *Client) expire(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == token {
+		c.token = ""
+		c.expiresAt = time.Time{}
+	}
+}
+
+// logIn must be called with mu held.
+func (c *Client) logIn(ctx context.Context, email, password string) (*User, error) {
+	var user User
+	input := UserLogin{Email: email, Password: password}
+	if err := c.send(ctx, http.MethodPost, "/users/login", nil, input, &user, ""); err != nil {
+		return nil, err
+	}
+
+	c.setToken(user.Token)
+	return &user, nil
+}
+
+// do sends an authenticated request. A request rejected because its token
+// expired is sent again once with a new token.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.send(ctx, method, path, query, in, out, token)
+	if !errors.Is(err, ErrTokenExpired) || !c.hasCredentials() {
+		return err
+	}
+
+	c.expire(token)
+	if token, err = c.accessToken(ctx); err != nil {
+		return err
+	}
+	return c.send(ctx, method, path, query, in, out, token)
+}
+
+// form is a multipart form sent as it is.
+type form struct {
+	contentType string
+	data        []byte
+}
+
+// imageForm reads r into a multipart form holding it as its image field.
+func imageForm(r io.Reader) (form, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("image", "image")
+	if err != nil {
+		return form{}, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return form{}, err
+	}
+	if err := writer.Close(); err != nil {
+		return form{}, err
+	}
+	return form{contentType: writer.FormDataContentType(), data: buf.Bytes()}, nil
+}
+
+// send encodes in as the JSON body of the request, unless it is a form, and
+// decodes the response into out.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, in, out interface{}, token string) error {
+	var body io.Reader
+	contentType := "application/json"
+	if f, ok := in.(form); ok {
+		body, contentType = bytes.NewReader(f.data), f.contentType
+	} else if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	target := c.baseURL + version + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("token", token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// tokenExpiry reads the expiry of a JWT without checking its signature, the
+// API does. It returns the zero time when the token can't be read.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.ExpiresAt, 0)
+}
+
+func get[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	var doc T
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func list[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var docs []T
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (c *Client) create(ctx context.Context, path string, in interface{}) (*InsertOneResult, error) {
+	var result InsertOneResult
+	if err := c.do(ctx, http.MethodPost, path, nil, in, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) update(ctx context.Context, path string, in interface{}) (*UpdateResult, error) {
+	var result UpdateResult
+	if err := c.do(ctx, http.MethodPatch, path, nil, in, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) delete(ctx context.Context, path string) (*DeleteResult, error) {
+	var result DeleteResult
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
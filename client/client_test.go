@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/controllers"
+	"restaurant_management/routes"
+	"restaurant_management/store"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testEmail    = "kitchen@example.com"
+	testPassword = "secret-password"
+)
+
+// newTestServer serves the API over an in-memory store with a registered
+// user. logins counts the login requests.
+func newTestServer(t *testing.T) (server *httptest.Server, logins *int64) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	controllers.UseStore(store.NewMemoryStore())
+
+	router := routes.NewRouter(routes.RouterOptions{})
+
+	logins = new(int64)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/users/login" {
+			atomic.AddInt64(logins, 1)
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := New(server.URL).SignUp(context.Background(), SignUp{
+		First_name: "Kitchen",
+		Last_name:  "Display",
+		Email:      testEmail,
+		Password:   testPassword,
+		Phone:      "0102030405",
+	})
+	if err != nil {
+		t.Fatalf("sign up: %v", err)
+	}
+
+	return server, logins
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	server, logins := newTestServer(t)
+	ctx := context.Background()
+	c := New(server.URL, WithCredentials(testEmail, testPassword))
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	menu, err := c.CreateMenu(ctx, MenuInput{Name: "Lunch", Category: "main", Start_date: start, End_date: start.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+	if atomic.LoadInt64(logins) != 1 {
+		t.Fatalf("expected the first call to log in once, got %d logins", atomic.LoadInt64(logins))
+	}
+
+	food, err := c.CreateFood(ctx, FoodInput{Name: "Soup", Price: 4.5, Food_image: "soup.png", Menu_id: menu.InsertedID})
+	if err != nil {
+		t.Fatalf("create food: %v", err)
+	}
+
+	table, err := c.CreateTable(ctx, TableInput{Number_of_guests: 2, Table_number: 7})
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	items, err := c.CreateOrderItems(ctx, OrderItemPack{
+		Table_id: table.InsertedID,
+		Order_items: []OrderItemInput{
+			{Food_id: food.InsertedID, Quantity: QuantitySmall},
+			{Food_id: food.InsertedID, Quantity: QuantityLarge},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create order items: %v", err)
+	}
+
+	item, err := c.GetOrderItem(ctx, items.InsertedIDs[0])
+	if err != nil {
+		t.Fatalf("get order item: %v", err)
+	}
+
+	summaries, err := c.OrderItemsByOrder(ctx, item.Order_id)
+	if err != nil {
+		t.Fatalf("order items by order: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Payment_due != 9 || summaries[0].Table_number != 7 || len(summaries[0].Order_items) != 2 {
+		t.Fatalf("unexpected order summary %+v", summaries)
+	}
+
+	gotTable, err := c.GetTable(ctx, table.InsertedID)
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	if gotTable.Table_status != TableOccupied {
+		t.Fatalf("expected the table to be occupied, got %q", gotTable.Table_status)
+	}
+
+	invoice, err := c.CreateInvoice(ctx, InvoiceInput{Order_id: item.Order_id, Payment_method: PaymentCard})
+	if err != nil {
+		t.Fatalf("create invoice: %v", err)
+	}
+
+	paid := PaymentPaid
+	if _, err := c.UpdateInvoice(ctx, invoice.InsertedID, InvoiceUpdate{Payment_status: &paid}); err != nil {
+		t.Fatalf("update invoice: %v", err)
+	}
+
+	view, err := c.GetInvoice(ctx, invoice.InsertedID)
+	if err != nil {
+		t.Fatalf("get invoice: %v", err)
+	}
+	if view.Payment_status != PaymentPaid || view.Payment_due != 9 || len(view.Order_details) != 2 {
+		t.Fatalf("unexpected invoice %+v", view)
+	}
+
+	order, err := c.GetOrder(ctx, item.Order_id)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if order.Order_status != OrderPaid {
+		t.Fatalf("expected the order to be paid, got %q", order.Order_status)
+	}
+
+	gotMenu, err := c.GetMenu(ctx, menu.InsertedID)
+	if err != nil {
+		t.Fatalf("get menu: %v", err)
+	}
+	if gotMenu.Updated_at.IsZero() || !gotMenu.Start_date.Equal(start) {
+		t.Fatalf("unexpected menu %+v", gotMenu)
+	}
+
+	deleted, err := c.DeleteInvoice(ctx, invoice.InsertedID)
+	if err != nil || deleted.DeletedCount != 1 {
+		t.Fatalf("delete invoice: %+v, %v", deleted, err)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	server, _ := newTestServer(t)
+	ctx := context.Background()
+	c := New(server.URL)
+
+	if _, err := c.LogIn(ctx, testEmail, testPassword); err != nil {
+		t.Fatalf("log in: %v", err)
+	}
+
+	start := time.Now().Add(time.Hour)
+	menu, err := c.CreateMenu(ctx, MenuInput{Name: "Dinner", Category: "main", Start_date: start, End_date: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		input := FoodInput{Name: fmt.Sprintf("Dish %d", i), Price: 10, Food_image: "dish.png", Menu_id: menu.InsertedID}
+		if _, err := c.CreateFood(ctx, input); err != nil {
+			t.Fatalf("create food: %v", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	it := c.Foods(2)
+	for it.Next(ctx) {
+		seen[it.Item().Food_id] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+	if len(seen) != 5 {
+		t.Fatalf("expected 5 foods, got %d", len(seen))
+	}
+
+	empty := c.Orders(10)
+	if empty.Next(ctx) || empty.Err() != nil {
+		t.Fatalf("expected no orders, got error %v", empty.Err())
+	}
+}
+
+func TestRefreshesExpiringToken(t *testing.T) {
+	server, logins := newTestServer(t)
+	ctx := context.Background()
+	c := New(server.URL, WithCredentials(testEmail, testPassword))
+
+	if _, err := c.ListTables(ctx); err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+
+	// the API issues tokens valid for a day
+	c.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+
+	if _, err := c.ListTables(ctx); err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if atomic.LoadInt64(logins) != 2 {
+		t.Fatalf("expected the client to log in again, got %d logins", atomic.LoadInt64(logins))
+	}
+}
+
+func TestRetriesExpiredToken(t *testing.T) {
+	var calls, logins int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/users/login":
+			fmt.Fprintf(w, `{"token": "token-%d"}`, atomic.AddInt64(&logins, 1))
+		case r.Header.Get("token") == "token-1":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error": "token is expired by 1s"}`)
+		default:
+			atomic.AddInt64(&calls, 1)
+			fmt.Fprint(w, `[{"table_id": "t1", "table_status": "FREE"}]`)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithCredentials(testEmail, testPassword))
+	tables, err := c.ListTables(context.Background())
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	if len(tables) != 1 || atomic.LoadInt64(&logins) != 2 || atomic.LoadInt64(&calls) != 1 || c.Token() != "token-2" {
+		t.Fatalf("expected one retry with a new token, got %d logins, %d calls, token %q", atomic.LoadInt64(&logins), atomic.LoadInt64(&calls), c.Token())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	server, _ := newTestServer(t)
+	ctx := context.Background()
+
+	if _, err := New(server.URL).ListMenus(ctx); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("expected ErrNoCredentials, got %v", err)
+	}
+
+	if _, err := New(server.URL).LogIn(ctx, testEmail, "wrong-password"); err == nil {
+		t.Fatal("expected a wrong password to fail")
+	}
+
+	c := New(server.URL, WithCredentials(testEmail, testPassword))
+
+	_, err := c.GetOrder(ctx, "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a not found APIError, got %v", err)
+	}
+
+	_, err = c.CreateTable(ctx, TableInput{Number_of_guests: 2, Table_number: 1, Table_status: "BROKEN"})
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "12")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error": "too many requests, retry later"}`)
+	}))
+	defer limited.Close()
+
+	_, err = New(limited.URL, WithToken("token")).ListTables(ctx)
+	if !errors.Is(err, ErrRateLimited) || !errors.As(err, &apiErr) || apiErr.RetryAfter != 12*time.Second {
+		t.Fatalf("expected a rate limit error with Retry-After, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoCredentials is returned by calls that need a token when the client
+	// has neither a token nor credentials to get one.
+	ErrNoCredentials = errors.New("client: no token and no credentials to log in")
+
+	// The errors below are matched by errors.Is against an *APIError.
+	ErrNotFound     = errors.New("client: not found")
+	ErrTokenExpired = errors.New("client: token expired")
+	ErrRateLimited  = errors.New("client: rate limited")
+	ErrInvalid      = errors.New("client: invalid request")
+)
+
+// APIError is an error response of the API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+	// RetryAfter is set on rate limited responses.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is classifies the error. The API doesn't always answer with the matching
+// status code, e.g. missing documents are often a 500, so the message is
+// looked at too.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || strings.HasSuffix(e.Message, "not found")
+	case ErrTokenExpired:
+		return strings.HasPrefix(e.Message, "token is expired")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalid:
+		return e.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	// errors are {"error": "..."}, a few handlers answer with a bare string
+	var object struct {
+		Error string `json:"error"`
+	}
+	var message string
+	switch {
+	case json.Unmarshal(body, &object) == nil && object.Error != "":
+		apiErr.Message = object.Error
+	case json.Unmarshal(body, &message) == nil:
+		apiErr.Message = message
+	default:
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	return apiErr
+}
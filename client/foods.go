@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) ListFoods(ctx context.Context, page, perPage int) (*Page[Food], error) {
+	return c.FilterFoods(ctx, FoodFilter{}, page, perPage)
+}
+
+// FilterFoods lists the foods filter selects.
+func (c *Client) FilterFoods(ctx context.Context, filter FoodFilter, page, perPage int) (*Page[Food], error) {
+	query := url.Values{}
+	if filter.Category != "" {
+		query.Set("category", filter.Category)
+	}
+	query["tag"] = filter.Tags
+	query["allergen_free"] = filter.AllergenFree
+	if filter.MaxSpiceLevel != nil {
+		query.Set("max_spice_level", strconv.Itoa(*filter.MaxSpiceLevel))
+	}
+
+	var foods Page[Food]
+	total, err := c.page(ctx, "/foods", "food_items", query, page, perPage, &foods.Items)
+	if err != nil {
+		return nil, err
+	}
+	foods.Total = total
+	return &foods, nil
+}
+
+// Foods iterates over all the foods, perPage at a time.
+func (c *Client) Foods(perPage int) *Iterator[Food] {
+	return newIterator(func(ctx context.Context, page int) (Page[Food], error) {
+		foods, err := c.ListFoods(ctx, page, perPage)
+		if err != nil {
+			return Page[Food]{}, err
+		}
+		return *foods, nil
+	})
+}
+
+// SoldOutFoods returns the 86 list: the foods sold out first, then those
+// with a daily limit.
+func (c *Client) SoldOutFoods(ctx context.Context) ([]SoldOutFood, error) {
+	var foods []SoldOutFood
+	if err := c.do(ctx, http.MethodGet, "/foods/sold-out", nil, nil, &foods); err != nil {
+		return nil, err
+	}
+	return foods, nil
+}
+
+func (c *Client) GetFood(ctx context.Context, foodId string) (*Food, error) {
+	return get[Food](ctx, c, "/foods/"+url.PathEscape(foodId))
+}
+
+func (c *Client) CreateFood(ctx context.Context, input FoodInput) (*InsertOneResult, error) {
+	return c.create(ctx, "/foods", input)
+}
+
+func (c *Client) UpdateFood(ctx context.Context, foodId string, input FoodUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/foods/"+url.PathEscape(foodId), input)
+}
+
+func (c *Client) DeleteFood(ctx context.Context, foodId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/foods/"+url.PathEscape(foodId))
+}
+
+// UploadFoodImage uploads the JPEG, PNG or GIF image read from r as the image
+// of the food, a thumbnail being made from it.
+func (c *Client) UploadFoodImage(ctx context.Context, foodId string, r io.Reader) (*Image, error) {
+	body, err := imageForm(r)
+	if err != nil {
+		return nil, err
+	}
+	var image Image
+	if err := c.do(ctx, http.MethodPut, "/foods/"+url.PathEscape(foodId)+"/image", nil, body, &image); err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+func (c *Client) ListInvoices(ctx context.Context) ([]Invoice, error) {
+	return list[Invoice](ctx, c, "/invoices")
+}
+
+// GetInvoice returns an invoice with the amount due and the items of its
+// order.
+func (c *Client) GetInvoice(ctx context.Context, invoiceId string) (*InvoiceView, error) {
+	return get[InvoiceView](ctx, c, "/invoices/"+url.PathEscape(invoiceId))
+}
+
+// CreateInvoice creates an invoice. An invoice created as PAID settles its
+// order and frees the table.
+func (c *Client) CreateInvoice(ctx context.Context, input InvoiceInput) (*InsertOneResult, error) {
+	return c.create(ctx, "/invoices", input)
+}
+
+// UpdateInvoice updates an invoice. Paying it settles its order and frees the
+// table.
+func (c *Client) UpdateInvoice(ctx context.Context, invoiceId string, input InvoiceUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/invoices/"+url.PathEscape(invoiceId), input)
+}
+
+func (c *Client) DeleteInvoice(ctx context.Context, invoiceId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/invoices/"+url.PathEscape(invoiceId))
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Page is one page of a paginated list.
+type Page[T any] struct {
+	Items []T
+	// Total is the number of items across all the pages.
+	Total int
+}
+
+// pageResponse is the shape of the paginated lists: a single element array
+// holding the total count and the items under key, or null when the list is
+// empty.
+type pageResponse []map[string]json.RawMessage
+
+// page reads a page of the list at path, filtered by query when it isn't
+// nil.
+func (c *Client) page(ctx context.Context, path, key string, query url.Values, page, perPage int, items interface{}) (int, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("recordPerPage", strconv.Itoa(perPage))
+
+	var resp pageResponse
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &resp); err != nil {
+		return 0, err
+	}
+	if len(resp) == 0 {
+		return 0, nil
+	}
+
+	var total int
+	if err := json.Unmarshal(resp[0]["total_count"], &total); err != nil {
+		return 0, err
+	}
+	if raw, ok := resp[0][key]; ok {
+		if err := json.Unmarshal(raw, items); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// Iterator walks a paginated list, fetching the pages as it goes:
+//
+//	it := c.Orders(50)
+//	for it.Next(ctx) {
+//		order := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	fetch   func(ctx context.Context, page int) (Page[T], error)
+	page    int
+	items   []T
+	current T
+	seen    int
+	done    bool
+	err     error
+}
+
+func newIterator[T any](fetch func(ctx context.Context, page int) (Page[T], error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// Next moves to the next item, fetching the next page when needed. It returns
+// false at the end of the list or on error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+
+		it.page++
+		page, err := it.fetch(ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = page.Items
+		it.seen += len(page.Items)
+		it.done = len(page.Items) == 0 || it.seen >= page.Total
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the item Next moved to.
+func (it *Iterator[T]) Item() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
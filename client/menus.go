@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (c *Client) ListMenus(ctx context.Context) ([]Menu, error) {
+	return list[Menu](ctx, c, "/menus")
+}
+
+// ListActiveMenus returns the menus that can be ordered from at at, now when
+// it is zero.
+func (c *Client) ListActiveMenus(ctx context.Context, at time.Time) ([]Menu, error) {
+	query := url.Values{}
+	if !at.IsZero() {
+		query.Set("at", at.Format(time.RFC3339))
+	}
+
+	var menus []Menu
+	if err := c.do(ctx, http.MethodGet, "/menus/active", query, nil, &menus); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+func (c *Client) GetMenu(ctx context.Context, menuId string) (*Menu, error) {
+	return get[Menu](ctx, c, "/menus/"+url.PathEscape(menuId))
+}
+
+// MenuAllergens returns the allergen matrix of the foods of a menu.
+func (c *Client) MenuAllergens(ctx context.Context, menuId string) (*AllergenMatrix, error) {
+	return get[AllergenMatrix](ctx, c, "/menus/"+url.PathEscape(menuId)+"/allergens")
+}
+
+func (c *Client) CreateMenu(ctx context.Context, input MenuInput) (*InsertOneResult, error) {
+	return c.create(ctx, "/menus", input)
+}
+
+func (c *Client) UpdateMenu(ctx context.Context, menuId string, input MenuUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/menus/"+url.PathEscape(menuId), input)
+}
+
+func (c *Client) DeleteMenu(ctx context.Context, menuId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/menus/"+url.PathEscape(menuId))
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) ListOrderItems(ctx context.Context) ([]OrderItem, error) {
+	return list[OrderItem](ctx, c, "/order-items")
+}
+
+// OrderItemsByOrder returns the items of an order with the amount due. The
+// list is empty for an order without items.
+func (c *Client) OrderItemsByOrder(ctx context.Context, orderId string) ([]OrderSummary, error) {
+	return list[OrderSummary](ctx, c, "/orders/"+url.PathEscape(orderId)+"/items")
+}
+
+func (c *Client) GetOrderItem(ctx context.Context, orderItemId string) (*OrderItem, error) {
+	return get[OrderItem](ctx, c, "/order-items/"+url.PathEscape(orderItemId))
+}
+
+// CreateOrderItems opens an order for a table with its items. The ID of the
+// order is the Order_id of the created items.
+func (c *Client) CreateOrderItems(ctx context.Context, pack OrderItemPack) (*InsertManyResult, error) {
+	var result InsertManyResult
+	if err := c.do(ctx, http.MethodPost, "/order-items", nil, pack, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) UpdateOrderItem(ctx context.Context, orderItemId string, input OrderItemUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/order-items/"+url.PathEscape(orderItemId), input)
+}
+
+func (c *Client) DeleteOrderItem(ctx context.Context, orderItemId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/order-items/"+url.PathEscape(orderItemId))
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+func (c *Client) ListOrders(ctx context.Context, page, perPage int) (*Page[Order], error) {
+	var orders Page[Order]
+	total, err := c.page(ctx, "/orders", "order_items", nil, page, perPage, &orders.Items)
+	if err != nil {
+		return nil, err
+	}
+	orders.Total = total
+	return &orders, nil
+}
+
+// Orders iterates over all the orders, perPage at a time.
+func (c *Client) Orders(perPage int) *Iterator[Order] {
+	return newIterator(func(ctx context.Context, page int) (Page[Order], error) {
+		orders, err := c.ListOrders(ctx, page, perPage)
+		if err != nil {
+			return Page[Order]{}, err
+		}
+		return *orders, nil
+	})
+}
+
+func (c *Client) GetOrder(ctx context.Context, orderId string) (*Order, error) {
+	return get[Order](ctx, c, "/orders/"+url.PathEscape(orderId))
+}
+
+func (c *Client) CreateOrder(ctx context.Context, input OrderInput) (*InsertOneResult, error) {
+	return c.create(ctx, "/orders", input)
+}
+
+func (c *Client) UpdateOrder(ctx context.Context, orderId string, input OrderUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/orders/"+url.PathEscape(orderId), input)
+}
+
+func (c *Client) DeleteOrder(ctx context.Context, orderId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/orders/"+url.PathEscape(orderId))
+}
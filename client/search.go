@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Search returns up to limit foods and menus matching query, the best
+// matches first, only those of kind, SearchFood or SearchMenu, when it isn't
+// empty. A zero limit lets the API choose it.
+func (c *Client) Search(ctx context.Context, query, kind string, limit int) ([]SearchResult, error) {
+	values := url.Values{"q": {query}}
+	if kind != "" {
+		values.Set("type", kind)
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var results []SearchResult
+	if err := c.do(ctx, http.MethodGet, "/search", values, nil, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+func (c *Client) ListTables(ctx context.Context) ([]Table, error) {
+	return list[Table](ctx, c, "/tables")
+}
+
+func (c *Client) GetTable(ctx context.Context, tableId string) (*Table, error) {
+	return get[Table](ctx, c, "/tables/"+url.PathEscape(tableId))
+}
+
+func (c *Client) CreateTable(ctx context.Context, input TableInput) (*InsertOneResult, error) {
+	return c.create(ctx, "/tables", input)
+}
+
+func (c *Client) UpdateTable(ctx context.Context, tableId string, input TableUpdate) (*UpdateResult, error) {
+	return c.update(ctx, "/tables/"+url.PathEscape(tableId), input)
+}
+
+func (c *Client) DeleteTable(ctx context.Context, tableId string) (*DeleteResult, error) {
+	return c.delete(ctx, "/tables/"+url.PathEscape(tableId))
+}
@@ -0,0 +1,474 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type User struct {
+	User_id    string `json:"user_id"`
+	First_name string `json:"first_name"`
+	Last_name  string `json:"last_name"`
+	Email      string `json:"email"`
+	Avatar     string `json:"avatar"`
+	// Avatar_thumbnail is set with Avatar when it is uploaded.
+	Avatar_thumbnail string    `json:"avatar_thumbnail"`
+	Phone            string    `json:"phone"`
+	Token            string    `json:"token"`
+	Refresh_token    string    `json:"refresh_token"`
+	Permissions      []string  `json:"permissions"`
+	Created_at       time.Time `json:"created_at"`
+	Updated_at       time.Time `json:"updated_at"`
+}
+
+// PermissionPriceOverride allows a user to set the unit price of order
+// items.
+const PermissionPriceOverride = "price_override"
+
+// PermissionManageWebhooks allows a user to manage the webhooks.
+const PermissionManageWebhooks = "manage_webhooks"
+
+type SignUp struct {
+	First_name string `json:"first_name"`
+	Last_name  string `json:"last_name"`
+	Password   string `json:"password"`
+	Email      string `json:"email"`
+	Avatar     string `json:"avatar,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+}
+
+type UserLogin struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type Menu struct {
+	Menu_id      string        `json:"menu_id"`
+	Name         string        `json:"name"`
+	Category     string        `json:"category"`
+	Start_date   *time.Time    `json:"start_date"`
+	End_date     *time.Time    `json:"end_date"`
+	Availability *Availability `json:"availability"`
+	Created_at   time.Time     `json:"created_at"`
+	Updated_at   time.Time     `json:"updated_at"`
+}
+
+// Availability is the recurring schedule of a menu, see the API docs.
+type Availability struct {
+	Time_zone  string                  `json:"time_zone,omitempty"`
+	Rules      []AvailabilityRule      `json:"rules,omitempty"`
+	Exceptions []AvailabilityException `json:"exceptions,omitempty"`
+}
+
+// AvailabilityRule opens a menu from Start to End, "HH:MM", on Days, "MON"
+// to "SUN".
+type AvailabilityRule struct {
+	Days  []string `json:"days"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// AvailabilityException replaces the rules on Date, "YYYY-MM-DD", closing
+// the menu unless Start and End are set.
+type AvailabilityException struct {
+	Date  string `json:"date"`
+	Name  string `json:"name,omitempty"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// UnmarshalJSON reads the update time of single menus too, the API names it
+// update_at there.
+func (m *Menu) UnmarshalJSON(data []byte) error {
+	type menu Menu
+	var v struct {
+		menu
+		Update_at time.Time `json:"update_at"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*m = Menu(v.menu)
+	if m.Updated_at.IsZero() {
+		m.Updated_at = v.Update_at
+	}
+	return nil
+}
+
+type MenuInput struct {
+	Name         string        `json:"name"`
+	Category     string        `json:"category"`
+	Start_date   time.Time     `json:"start_date"`
+	End_date     time.Time     `json:"end_date"`
+	Availability *Availability `json:"availability,omitempty"`
+}
+
+type MenuUpdate struct {
+	Name         *string       `json:"name,omitempty"`
+	Category     *string       `json:"category,omitempty"`
+	Start_date   *time.Time    `json:"start_date,omitempty"`
+	End_date     *time.Time    `json:"end_date,omitempty"`
+	Availability *Availability `json:"availability,omitempty"`
+}
+
+type Food struct {
+	Food_id     string  `json:"food_id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Category    *string `json:"category"`
+	Price       float64 `json:"price"`
+	// Size_prices are the prices of the sizes that don't cost Price.
+	Size_prices map[string]float64 `json:"size_prices"`
+	Food_image  string             `json:"food_image"`
+	// Food_thumbnail is set with Food_image when it is uploaded.
+	Food_thumbnail string   `json:"food_thumbnail"`
+	Tags           []string `json:"tags"`
+	Allergens      []string `json:"allergens"`
+	Spice_level    *int     `json:"spice_level"`
+	// Modifier_groups are the choices made when ordering the food.
+	Modifier_groups []ModifierGroup `json:"modifier_groups"`
+	Sold_out        *bool           `json:"sold_out"`
+	// Sold_out_until is when the food goes back on sale, nil when it is sold
+	// out until further notice.
+	Sold_out_until *time.Time `json:"sold_out_until"`
+	// Daily_limit are the portions that can be ordered a day, nil without a
+	// limit.
+	Daily_limit   *int      `json:"daily_limit"`
+	Portions_sold int       `json:"portions_sold"`
+	Portions_date string    `json:"portions_date"`
+	Menu_id       string    `json:"menu_id"`
+	Created_at    time.Time `json:"created_at"`
+	Updated_at    time.Time `json:"updated_at"`
+}
+
+// FoodInput creates a food. Allergens declares the allergens the food
+// contains, a nil slice leaving them undeclared.
+type FoodInput struct {
+	Name            string             `json:"name"`
+	Description     *string            `json:"description,omitempty"`
+	Category        *string            `json:"category,omitempty"`
+	Price           float64            `json:"price"`
+	Size_prices     map[string]float64 `json:"size_prices,omitempty"`
+	Food_image      string             `json:"food_image,omitempty"`
+	Tags            []string           `json:"tags,omitempty"`
+	Allergens       []string           `json:"allergens"`
+	Spice_level     *int               `json:"spice_level,omitempty"`
+	Modifier_groups []ModifierGroup    `json:"modifier_groups,omitempty"`
+	Sold_out        *bool              `json:"sold_out,omitempty"`
+	Sold_out_until  *time.Time         `json:"sold_out_until,omitempty"`
+	Daily_limit     *int               `json:"daily_limit,omitempty"`
+	Menu_id         string             `json:"menu_id"`
+}
+
+type FoodUpdate struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Category    *string  `json:"category,omitempty"`
+	Price       *float64 `json:"price,omitempty"`
+	// Size_prices replace the prices of the sizes of the food.
+	Size_prices map[string]float64 `json:"size_prices,omitempty"`
+	Food_image  *string            `json:"food_image,omitempty"`
+	Tags        *[]string          `json:"tags,omitempty"`
+	Allergens   *[]string          `json:"allergens,omitempty"`
+	Spice_level *int               `json:"spice_level,omitempty"`
+	// Modifier_groups replace the modifier groups of the food.
+	Modifier_groups *[]ModifierGroup `json:"modifier_groups,omitempty"`
+	// Sold_out false puts the food back on sale, ending its sold out period.
+	Sold_out       *bool      `json:"sold_out,omitempty"`
+	Sold_out_until *time.Time `json:"sold_out_until,omitempty"`
+	// Daily_limit 0 removes the limit.
+	Daily_limit *int    `json:"daily_limit,omitempty"`
+	Menu_id     *string `json:"menu_id,omitempty"`
+}
+
+// Image holds the URLs of an image uploaded and of its thumbnail, relative
+// to the API.
+type Image struct {
+	Image     string `json:"image"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// SoldOutFood is an entry of the 86 list. Sold_out_until is when the food
+// goes back on sale, nil when it is sold out until further notice, and
+// Portions_left the portions it has left today, nil without a daily limit.
+type SoldOutFood struct {
+	Food_id        string     `json:"food_id"`
+	Name           string     `json:"name"`
+	Menu_id        string     `json:"menu_id"`
+	Sold_out       bool       `json:"sold_out"`
+	Sold_out_until *time.Time `json:"sold_out_until"`
+	Daily_limit    *int       `json:"daily_limit"`
+	Portions_left  *int       `json:"portions_left"`
+}
+
+// ModifierGroup is a choice made when ordering a food: between
+// Min_selections and Max_selections of its Options, no limit being set by a
+// zero Max_selections. A Required group needs at least one option. The API
+// gives IDs to the groups and the options that have none.
+type ModifierGroup struct {
+	Group_id       string           `json:"group_id,omitempty"`
+	Name           string           `json:"name"`
+	Required       bool             `json:"required"`
+	Min_selections int              `json:"min_selections"`
+	Max_selections int              `json:"max_selections"`
+	Options        []ModifierOption `json:"options"`
+}
+
+type ModifierOption struct {
+	Option_id   string  `json:"option_id,omitempty"`
+	Name        string  `json:"name"`
+	Price_delta float64 `json:"price_delta"`
+}
+
+// The types of SearchResult.
+const (
+	SearchFood = "food"
+	SearchMenu = "menu"
+)
+
+// SearchResult is a food or a menu matching a search, the higher its Score
+// the better the match. Menu_id and Menu_name are the menu of a food.
+type SearchResult struct {
+	Type      string  `json:"type"`
+	Id        string  `json:"id"`
+	Name      string  `json:"name"`
+	Menu_id   string  `json:"menu_id"`
+	Menu_name string  `json:"menu_name"`
+	Score     float64 `json:"score"`
+}
+
+// FoodFilter selects foods, its zero value selects them all. The foods that
+// didn't declare their allergens or spice level are left out when they are
+// filtered on.
+type FoodFilter struct {
+	Category      string
+	Tags          []string
+	AllergenFree  []string
+	MaxSpiceLevel *int
+}
+
+// Allergens of the EU 14.
+const (
+	Gluten     = "gluten"
+	Crustacean = "crustacean"
+	Egg        = "egg"
+	Fish       = "fish"
+	Peanut     = "peanut"
+	Soy        = "soy"
+	Milk       = "milk"
+	TreeNut    = "tree_nut"
+	Celery     = "celery"
+	Mustard    = "mustard"
+	Sesame     = "sesame"
+	Sulphite   = "sulphite"
+	Lupin      = "lupin"
+	Mollusc    = "mollusc"
+)
+
+// AllergenMatrix tells which allergens the foods of a menu contain.
+type AllergenMatrix struct {
+	Menu_id   string        `json:"menu_id"`
+	Name      string        `json:"name"`
+	Allergens []string      `json:"allergens"`
+	Foods     []AllergenRow `json:"foods"`
+}
+
+// AllergenRow is a food of an AllergenMatrix, Contains being nil when it
+// didn't declare its allergens.
+type AllergenRow struct {
+	Food_id  string          `json:"food_id"`
+	Name     string          `json:"name"`
+	Declared bool            `json:"declared"`
+	Contains map[string]bool `json:"contains"`
+}
+
+// Table statuses.
+const (
+	TableFree     = "FREE"
+	TableOccupied = "OCCUPIED"
+)
+
+type Table struct {
+	Table_id         string    `json:"table_id"`
+	Number_of_guests int       `json:"number_of_guests"`
+	Table_number     int       `json:"table_number"`
+	Table_status     string    `json:"table_status"`
+	Created_at       time.Time `json:"created_at"`
+	Updated_at       time.Time `json:"updated_at"`
+}
+
+type TableInput struct {
+	Number_of_guests int    `json:"number_of_guests"`
+	Table_number     int    `json:"table_number"`
+	Table_status     string `json:"table_status,omitempty"`
+}
+
+type TableUpdate struct {
+	Number_of_guests *int    `json:"number_of_guests,omitempty"`
+	Table_number     *int    `json:"table_number,omitempty"`
+	Table_status     *string `json:"table_status,omitempty"`
+}
+
+// Order statuses.
+const (
+	OrderOpen = "OPEN"
+	OrderPaid = "PAID"
+)
+
+type Order struct {
+	Order_id     string    `json:"order_id"`
+	Order_date   time.Time `json:"order_date"`
+	Order_status string    `json:"order_status"`
+	Table_id     string    `json:"table_id"`
+	Created_at   time.Time `json:"created_at"`
+	Updated_at   time.Time `json:"updated_at"`
+}
+
+type OrderInput struct {
+	Order_date time.Time `json:"order_date"`
+	Table_id   string    `json:"table_id"`
+}
+
+type OrderUpdate struct {
+	Order_date time.Time `json:"order_date"`
+	Table_id   *string   `json:"table_id,omitempty"`
+}
+
+// Order item quantities.
+const (
+	QuantitySmall  = "S"
+	QuantityMedium = "M"
+	QuantityLarge  = "L"
+)
+
+type OrderItem struct {
+	Order_item_id string             `json:"order_item_id"`
+	Order_id      string             `json:"order_id"`
+	Food_id       string             `json:"food_id"`
+	Quantity      string             `json:"quantity"`
+	Unit_price    float64            `json:"unit_price"`
+	Modifiers     []SelectedModifier `json:"modifiers"`
+	Created_at    time.Time          `json:"created_at"`
+	Updated_at    time.Time          `json:"updated_at"`
+}
+
+// OrderItemInput orders a food. The API computes the unit price from the
+// size and the modifiers, setting Unit_price requires the price_override
+// permission.
+type OrderItemInput struct {
+	Food_id    string             `json:"food_id"`
+	Quantity   string             `json:"quantity"`
+	Unit_price *float64           `json:"unit_price,omitempty"`
+	Modifiers  []SelectedModifier `json:"modifiers,omitempty"`
+}
+
+// SelectedModifier is an option of a modifier group of the food of an order
+// item. Only the IDs are sent, the API fills the names and the price delta.
+type SelectedModifier struct {
+	Group_id    string  `json:"group_id"`
+	Option_id   string  `json:"option_id"`
+	Group_name  string  `json:"group_name,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Price_delta float64 `json:"price_delta,omitempty"`
+}
+
+// OrderItemPack opens an order for a table with its items.
+type OrderItemPack struct {
+	Table_id    string           `json:"table_id"`
+	Order_items []OrderItemInput `json:"order_items"`
+}
+
+// OrderItemUpdate changes an order item, pricing it again when the size, the
+// food or the modifiers change. Setting Unit_price requires the
+// price_override permission.
+type OrderItemUpdate struct {
+	Food_id    *string  `json:"food_id,omitempty"`
+	Quantity   *string  `json:"quantity,omitempty"`
+	Unit_price *float64 `json:"unit_price,omitempty"`
+	// Modifiers replace the modifiers of the item when not nil.
+	Modifiers *[]SelectedModifier `json:"modifiers,omitempty"`
+}
+
+// OrderSummary is the amount due on an order with its items.
+type OrderSummary struct {
+	Payment_due  float64            `json:"payment_due"`
+	Total_count  int                `json:"total_count"`
+	Table_number int                `json:"table_number"`
+	Order_items  []OrderSummaryItem `json:"order_items"`
+}
+
+// OrderSummaryItem is an order item with the details of its food.
+type OrderSummaryItem struct {
+	Order_item_id string  `json:"order_item_id"`
+	Order_id      string  `json:"order_id"`
+	Food_id       string  `json:"food_id"`
+	Food_name     string  `json:"food_name"`
+	Food_image    string  `json:"food_image"`
+	Table_id      string  `json:"table_id"`
+	Table_number  int     `json:"table_number"`
+	Price         float64 `json:"price"`
+	// Amount is the price of the food and of the modifiers selected.
+	Amount    float64            `json:"amount"`
+	Quantity  int                `json:"quantity"`
+	Modifiers []SelectedModifier `json:"modifiers"`
+}
+
+// Payment methods and statuses.
+const (
+	PaymentCard    = "CARD"
+	PaymentCash    = "CASH"
+	PaymentPending = "PENDING"
+	PaymentPaid    = "PAID"
+)
+
+type Invoice struct {
+	Invoice_id       string    `json:"invoice_id"`
+	Order_id         string    `json:"order_id"`
+	Payment_method   string    `json:"payment_method"`
+	Payment_status   string    `json:"payment_status"`
+	Payment_due_date time.Time `json:"payment_due_date"`
+	Created_at       time.Time `json:"created_at"`
+	Updated_at       time.Time `json:"updated_at"`
+}
+
+type InvoiceInput struct {
+	Order_id       string `json:"order_id"`
+	Payment_method string `json:"payment_method,omitempty"`
+	Payment_status string `json:"payment_status,omitempty"`
+}
+
+type InvoiceUpdate struct {
+	Payment_method *string `json:"payment_method,omitempty"`
+	Payment_status *string `json:"payment_status,omitempty"`
+}
+
+// InvoiceView is an invoice with the amount due and the items of its order.
+type InvoiceView struct {
+	Invoice_id       string             `json:"Invoice_id"`
+	Payment_method   string             `json:"Payment_method"`
+	Order_id         string             `json:"Order_id"`
+	Payment_status   string             `json:"Payment_status"`
+	Payment_due      float64            `json:"Payment_due"`
+	Table_number     int                `json:"Table_number"`
+	Payment_due_date time.Time          `json:"Payment_due_date"`
+	Order_details    []OrderSummaryItem `json:"Order_details"`
+}
+
+type InsertOneResult struct {
+	InsertedID string
+}
+
+type InsertManyResult struct {
+	InsertedIDs []string
+}
+
+type UpdateResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedCount int64
+}
+
+type DeleteResult struct {
+	DeletedCount int64
+}
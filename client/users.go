@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SignUp registers a user.
+func (c *Client) SignUp(ctx context.Context, input SignUp) (*InsertOneResult, error) {
+	var result InsertOneResult
+	if err := c.send(ctx, http.MethodPost, "/users/signup", nil, input, &result, ""); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LogIn logs in and authenticates the following calls with the new token.
+// The credentials are kept to log in again when the token expires.
+func (c *Client) LogIn(ctx context.Context, email, password string) (*User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.logIn(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	c.email = email
+	c.password = password
+	return user, nil
+}
+
+func (c *Client) ListUsers(ctx context.Context, page, perPage int) (*Page[User], error) {
+	var users Page[User]
+	total, err := c.page(ctx, "/users", "user_items", nil, page, perPage, &users.Items)
+	if err != nil {
+		return nil, err
+	}
+	users.Total = total
+	return &users, nil
+}
+
+// Users iterates over all the users, perPage at a time.
+func (c *Client) Users(perPage int) *Iterator[User] {
+	return newIterator(func(ctx context.Context, page int) (Page[User], error) {
+		users, err := c.ListUsers(ctx, page, perPage)
+		if err != nil {
+			return Page[User]{}, err
+		}
+		return *users, nil
+	})
+}
+
+func (c *Client) GetUser(ctx context.Context, userId string) (*User, error) {
+	return get[User](ctx, c, "/users/"+url.PathEscape(userId))
+}
+
+// UploadAvatar uploads the JPEG, PNG or GIF image read from r as the avatar
+// of the user logged in, userId.
+func (c *Client) UploadAvatar(ctx context.Context, userId string, r io.Reader) (*Image, error) {
+	body, err := imageForm(r)
+	if err != nil {
+		return nil, err
+	}
+	var image Image
+	if err := c.do(ctx, http.MethodPut, "/users/"+url.PathEscape(userId)+"/avatar", nil, body, &image); err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
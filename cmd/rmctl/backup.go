@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"restaurant_management/backup"
+	"restaurant_management/database"
+	"time"
+)
+
+func runBackup(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := flags.String("o", "", "archive to write, restaurant-<timestamp>.tar.gz by default")
+	flags.Parse(args)
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("restaurant-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	db := backup.NewMongoDatabase(database.Client.Database(database.Name))
+	manifest, err := backup.Backup(ctx, db, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	for _, collection := range manifest.Collections {
+		fmt.Printf("%-10s %8d documents\n", collection.Name, collection.Documents)
+	}
+	fmt.Printf("wrote %s\n", path)
+	return nil
+}
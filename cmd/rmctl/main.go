@@ -0,0 +1,70 @@
+// Command rmctl administers the restaurant database.
+//
+//	rmctl backup [-o archive]
+//	rmctl restore [-policy abort|skip|replace] [-clear] [-dry-run] archive
+//	rmctl seed [-seed n] [-weeks n] [-orders-per-day n] [-tables n] [-staff n]
+//	rmctl migrate [-url postgres-url]
+//
+// The database is read from MONGODB_URI, mongodb://localhost:27017 by
+// default. Seed writes to the database selected by DATABASE_DRIVER, the
+// PostgreSQL one being read from POSTGRES_URL, and keeps what a seeded
+// database already holds; backup and restore only handle MongoDB.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"backup":  {"export every collection into a compressed archive", runBackup},
+	"restore": {"validate an archive and import it", runRestore},
+	"seed":    {"generate demo menus, foods, tables, staff and order history", runSeed},
+	"migrate": {"bring the PostgreSQL schema up to date", runMigrate},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "rmctl: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "rmctl %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: rmctl <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
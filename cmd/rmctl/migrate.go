@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"restaurant_management/config"
+	"restaurant_management/database"
+	"restaurant_management/store"
+)
+
+func runMigrate(ctx context.Context, args []string) error {
+	cfg := config.Load().Database
+
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	flags.StringVar(&cfg.PostgresURL, "url", cfg.PostgresURL, "PostgreSQL database to migrate")
+	flags.Parse(args)
+
+	pool, err := database.ConnectPostgres(ctx, cfg.PostgresURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	if err := store.MigratePostgres(ctx, pool); err != nil {
+		return err
+	}
+	fmt.Println("postgres schema up to date")
+	return nil
+}
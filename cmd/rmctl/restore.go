@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"restaurant_management/backup"
+	"restaurant_management/database"
+)
+
+func runRestore(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	policy := flags.String("policy", string(backup.Abort), "what to do with documents that already exist: abort, skip or replace")
+	clearFirst := flags.Bool("clear", false, "delete the documents of the restored collections first")
+	dryRun := flags.Bool("dry-run", false, "only validate the archive")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("expected the path of the archive")
+	}
+
+	opts := backup.RestoreOptions{Clear: *clearFirst, DryRun: *dryRun}
+	var err error
+	if opts.Policy, err = backup.ParsePolicy(*policy); err != nil {
+		return err
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	db := backup.NewMongoDatabase(database.Client.Database(database.Name))
+	report, err := backup.Restore(ctx, db, file, opts)
+	if report != nil {
+		printReport(report, opts.DryRun)
+	}
+	return err
+}
+
+func printReport(report *backup.RestoreReport, dryRun bool) {
+	if dryRun {
+		fmt.Printf("archive format %d created at %s is valid\n", report.Manifest.FormatVersion, report.Manifest.CreatedAt)
+		for _, collection := range report.Manifest.Collections {
+			fmt.Printf("%-10s %8d documents\n", collection.Name, collection.Documents)
+		}
+		return
+	}
+
+	for _, collection := range report.Collections {
+		fmt.Printf("%-10s %8d inserted %8d replaced %8d skipped\n", collection.Name, collection.Inserted, collection.Replaced, collection.Skipped)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"restaurant_management/config"
+	"restaurant_management/seed"
+	"restaurant_management/store"
+)
+
+func runSeed(ctx context.Context, args []string) error {
+	opts := seed.DefaultOptions()
+
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	flags.Int64Var(&opts.Seed, "seed", opts.Seed, "random seed, reuse it to generate the same data again")
+	flags.IntVar(&opts.Weeks, "weeks", opts.Weeks, "weeks of order history")
+	flags.IntVar(&opts.OrdersPerDay, "orders-per-day", opts.OrdersPerDay, "average orders of a weekday")
+	flags.IntVar(&opts.Tables, "tables", opts.Tables, "number of tables")
+	flags.IntVar(&opts.Staff, "staff", opts.Staff, "number of staff users")
+	flags.IntVar(&opts.OpenOrders, "open-orders", opts.OpenOrders, "unpaid orders left on occupied tables")
+	flags.StringVar(&opts.StaffPassword, "password", opts.StaffPassword, "password of the staff users")
+	flags.Parse(args)
+
+	fmt.Printf("seeding with -seed %d\n", opts.Seed)
+
+	s, err := store.Open(ctx, config.Load().Database)
+	if err != nil {
+		return err
+	}
+
+	summary, err := seed.Run(ctx, s, opts)
+	if summary != nil {
+		fmt.Printf("%d users, %d menus, %d foods, %d tables, %d orders, %d order items, %d invoices\n",
+			summary.Users, summary.Menus, summary.Foods, summary.Tables, summary.Orders, summary.OrderItems, summary.Invoices)
+	}
+	return err
+}
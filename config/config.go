@@ -0,0 +1,259 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings read from the environment at startup.
+type Config struct {
+	Port     string
+	GRPCPort string
+	// MetricsPort serves /metrics, apart from the API since the metrics
+	// count the revenue: it isn't meant to be exposed publicly.
+	MetricsPort string
+	LogLevel    string
+	LogFormat   string
+	// OpenAPIValidation checks requests and responses against the OpenAPI
+	// document, on by default in gin's debug mode.
+	OpenAPIValidation bool
+	Database          Database
+	RateLimit         RateLimit
+	Tracing           Tracing
+	Cache             Cache
+	GraphQL           GraphQL
+	Foods             Foods
+	Images            Images
+	Events            Events
+	Webhooks          Webhooks
+	Stream            Stream
+	Versions          Versions
+}
+
+// Database selects the store: "mongo", reached through MONGODB_URI, or
+// "postgres", reached through PostgresURL. Migrate brings the PostgreSQL
+// schema up to date at startup. The Mongo store needs a replica set or a
+// sharded cluster for its transactions, a standalone server is refused
+// unless AllowStandalone is set.
+type Database struct {
+	Driver          string
+	PostgresURL     string
+	Migrate         bool
+	AllowStandalone bool
+}
+
+// RateLimit limits are written as "<requests>/<duration>", e.g. "10/1m".
+type RateLimit struct {
+	Enabled bool
+	Default string
+	Auth    string
+	Lists   string
+	// APIKeys are the keys of the clients limited by their X-API-Key header
+	// rather than by their IP.
+	APIKeys []string
+}
+
+// Cache keeps up to Size menu and food reads in process for TTL.
+type Cache struct {
+	Enabled bool
+	Size    int
+	TTL     time.Duration
+}
+
+// GraphQL queries deeper than MaxDepth or more complex than MaxComplexity are
+// rejected, 0 lifts the limit.
+type GraphQL struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Foods sold out until a time, or that sold their daily limit on an earlier
+// day, are put back on sale every RestoreInterval.
+type Foods struct {
+	RestoreInterval time.Duration
+}
+
+// Images uploaded are stored under Dir, up to MaxBytes and MaxPixels, their
+// thumbnails fitting in a square of ThumbnailSize pixels.
+type Images struct {
+	Dir           string
+	MaxBytes      int
+	MaxPixels     int
+	ThumbnailSize int
+}
+
+// Events are read from the outbox every PollInterval. A failed publication
+// is retried after Backoff, doubled after every attempt up to MaxBackoff,
+// until MaxAttempts were made.
+type Events struct {
+	MaxAttempts  int
+	Backoff      time.Duration
+	MaxBackoff   time.Duration
+	PollInterval time.Duration
+}
+
+// Webhooks retry a failed delivery after Backoff, doubled after every attempt
+// up to MaxBackoff, until MaxAttempts were made. Receivers on private
+// networks are rejected unless AllowPrivateNetworks.
+type Webhooks struct {
+	Enabled              bool
+	MaxAttempts          int
+	Backoff              time.Duration
+	MaxBackoff           time.Duration
+	Timeout              time.Duration
+	PollInterval         time.Duration
+	AllowPrivateNetworks bool
+}
+
+// Stream serves /stream when Enabled, keeping the connections alive every
+// Heartbeat.
+type Stream struct {
+	Enabled   bool
+	Heartbeat time.Duration
+}
+
+// Versions dates the deprecation of the unversioned routes and of the /v1
+// routes replaced in /v2, which are removed at Sunset. Dates are written as
+// "2006-01-02" or in RFC 3339.
+type Versions struct {
+	DeprecatedAt time.Time
+	Sunset       time.Time
+}
+
+// Tracing exporter is one of "none", "stdout" or "otlp".
+type Tracing struct {
+	Exporter    string
+	SampleRatio float64
+}
+
+func Load() Config {
+	return Config{
+		Port:              getEnv("PORT", "8080"),
+		GRPCPort:          getEnv("GRPC_PORT", "9090"),
+		MetricsPort:       getEnv("METRICS_PORT", "9100"),
+		LogLevel:          getEnv("LOG_LEVEL", "info"),
+		LogFormat:         getEnv("LOG_FORMAT", "json"),
+		OpenAPIValidation: getBool("OPENAPI_VALIDATION", getEnv("GIN_MODE", "debug") == "debug"),
+		Database: Database{
+			Driver:          getEnv("DATABASE_DRIVER", "mongo"),
+			PostgresURL:     getEnv("POSTGRES_URL", "postgres://localhost:5432/restaurant_management"),
+			Migrate:         getBool("POSTGRES_MIGRATE", true),
+			AllowStandalone: getBool("MONGODB_ALLOW_STANDALONE", false),
+		},
+		RateLimit: RateLimit{
+			Enabled: getBool("RATE_LIMIT_ENABLED", true),
+			Default: getEnv("RATE_LIMIT_DEFAULT", "300/1m"),
+			Auth:    getEnv("RATE_LIMIT_AUTH", "10/1m"),
+			Lists:   getEnv("RATE_LIMIT_LISTS", "60/1m"),
+			APIKeys: getList("RATE_LIMIT_API_KEYS"),
+		},
+		Tracing: Tracing{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			SampleRatio: getFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Cache: Cache{
+			Enabled: getBool("CACHE_ENABLED", true),
+			Size:    getInt("CACHE_SIZE", 1000),
+			TTL:     getDuration("CACHE_TTL", 5*time.Minute),
+		},
+		GraphQL: GraphQL{
+			MaxDepth:      getInt("GRAPHQL_MAX_DEPTH", 6),
+			MaxComplexity: getInt("GRAPHQL_MAX_COMPLEXITY", 2000),
+		},
+		Foods: Foods{
+			RestoreInterval: getDuration("FOODS_RESTORE_INTERVAL", time.Minute),
+		},
+		Images: Images{
+			Dir:           getEnv("IMAGES_DIR", "uploads"),
+			MaxBytes:      getInt("IMAGES_MAX_BYTES", 5<<20),
+			MaxPixels:     getInt("IMAGES_MAX_PIXELS", 40_000_000),
+			ThumbnailSize: getInt("IMAGES_THUMBNAIL_SIZE", 256),
+		},
+		Events: Events{
+			MaxAttempts:  getInt("EVENTS_MAX_ATTEMPTS", 10),
+			Backoff:      getDuration("EVENTS_BACKOFF", time.Second),
+			MaxBackoff:   getDuration("EVENTS_MAX_BACKOFF", 5*time.Minute),
+			PollInterval: getDuration("EVENTS_POLL_INTERVAL", time.Second),
+		},
+		Webhooks: Webhooks{
+			Enabled:              getBool("WEBHOOKS_ENABLED", true),
+			MaxAttempts:          getInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			Backoff:              getDuration("WEBHOOK_BACKOFF", 30*time.Second),
+			MaxBackoff:           getDuration("WEBHOOK_MAX_BACKOFF", time.Hour),
+			Timeout:              getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			PollInterval:         getDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			AllowPrivateNetworks: getBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Stream: Stream{
+			Enabled:   getBool("STREAM_ENABLED", true),
+			Heartbeat: getDuration("STREAM_HEARTBEAT", 15*time.Second),
+		},
+		Versions: Versions{
+			DeprecatedAt: getTime("API_DEPRECATED_AT", time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)),
+			Sunset:       getTime("API_SUNSET", time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getList reads a comma separated list, empty when key isn't set.
+func getList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getTime(key string, fallback time.Time) time.Time {
+	value := os.Getenv(key)
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	return fallback
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"restaurant_management/service"
+	"strings"
+)
+
+// UploadFoodImage stores the image of the image field of a multipart form as
+// the image of the food.
+func UploadFoodImage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uploadImage(c, func(file io.Reader) (service.Image, error) {
+			return service.SetFoodImage(c, c.Param("id"), file)
+		})
+	}
+}
+
+// UploadAvatar stores the image of the image field of a multipart form as the
+// avatar of the user logged in.
+func UploadAvatar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uploadImage(c, func(file io.Reader) (service.Image, error) {
+			return service.SetAvatar(c, c.Param("id"), c.GetString("uid"), file)
+		})
+	}
+}
+
+// GetImage serves a stored image. Their keys never being reused, they are
+// cached for good.
+func GetImage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, contentType, err := service.GetImage(c, strings.TrimPrefix(c.Param("key"), "/"))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		c.Data(http.StatusOK, contentType, data)
+	}
+}
+
+// uploadImage opens the image field of the multipart form and responds with
+// what store makes of it. The body is bounded before the form is parsed.
+func uploadImage(c *gin.Context, store func(file io.Reader) (service.Image, error)) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxUploadBytes())
+	header, err := c.FormFile("image")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "image is too large"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "an image file is required in the image field"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	uploaded, err := store(file)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, uploaded)
+}
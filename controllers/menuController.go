@@ -1,51 +1,68 @@
 package controllers
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"log"
 	"net/http"
-	"restaurant_management/database"
 	"restaurant_management/models"
+	"restaurant_management/service"
 	"time"
 )
 
-var menuCollection *mongo.Collection = database.OpenCollection(database.Client, "menu")
-
 func GetMenus() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		cursor, err := menuCollection.Find(c, bson.M{})
+		allMenus, err := service.ListMenus(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			respondError(c, err)
 			return
 		}
+		respondCacheable(c, allMenus)
+	}
+}
+
+// GetActiveMenus lists the menus that can be ordered from now, or at the
+// RFC 3339 time of the at query parameter.
+func GetActiveMenus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		at := time.Now()
+		if value := c.Query("at"); value != "" {
+			var err error
+			if at, err = time.Parse(time.RFC3339, value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 time"})
+				return
+			}
+		}
 
-		var allMenus []bson.M
-		if err := cursor.All(c, &allMenus); err != nil {
-			log.Fatal(err)
+		menus, err := service.ActiveMenus(c, at)
+		if err != nil {
+			respondError(c, err)
+			return
 		}
-		c.JSON(http.StatusOK, allMenus)
+		respondCacheable(c, menus)
 	}
 }
 
 func GetMenu() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		menuId := c.Param("id")
-		filter := bson.D{{"menu_id", menuId}}
+		menu, err := service.GetMenu(c, c.Param("id"))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
 
-		var menu models.Menu
+		respondCacheable(c, menu)
+	}
+}
 
-		err := menuCollection.FindOne(c, filter).Decode(&menu)
+// GetMenuAllergens reports the allergens of the foods of the menu.
+func GetMenuAllergens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matrix, err := service.MenuAllergens(c, c.Param("id"))
 		if err != nil {
-			msg := fmt.Sprint("Menu item not found")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+			respondError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, menu)
+		respondCacheable(c, matrix)
 	}
 }
 
@@ -58,26 +75,9 @@ func CreateMenu() gin.HandlerFunc {
 			return
 		}
 
-		if validateErr := validate.Struct(menu); validateErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": validateErr.Error()})
-			return
-		}
-
-		if !inTimeSpan(*menu.Start_date, *menu.End_date) {
-			msg := fmt.Sprint("kindly retype the time")
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-			return
-		}
-
-		menu.ID = primitive.NewObjectID()
-		menu.Menu_id = menu.ID.Hex()
-		menu.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
-		menu.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
-
-		result, insertErr := menuCollection.InsertOne(c, menu)
-		if insertErr != nil {
-			msg := fmt.Sprint("Menu item was not created")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		result, err := service.CreateMenu(c, menu)
+		if err != nil {
+			respondError(c, err)
 			return
 		}
 
@@ -85,51 +85,17 @@ func CreateMenu() gin.HandlerFunc {
 	}
 }
 
-func inTimeSpan(start, end time.Time) bool {
-	return start.After(time.Now()) && end.After(start)
-}
-
 func UpdateMenu() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		menuId := c.Param("id")
-		filter := bson.D{{"menu_id", menuId}}
-
 		var menu models.Menu
 		if err := c.ShouldBind(&menu); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-		}
-
-		var menuObj primitive.D
-		if menu.Start_date != nil && menu.End_date != nil {
-			if !inTimeSpan(*menu.Start_date, *menu.End_date) {
-				msg := fmt.Sprint("kindly retype the time")
-				c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-				return
-			}
-			menuObj = append(menuObj, bson.E{"start_date", menu.Start_date})
-			menuObj = append(menuObj, bson.E{"end_date", menu.End_date})
-		}
-
-		if menu.Name != "" {
-			menuObj = append(menuObj, bson.E{"name", menu.Name})
-		}
-
-		if menu.Category != "" {
-			menuObj = append(menuObj, bson.E{"category", menu.Category})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 
-		menu.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
-		menuObj = append(menuObj, bson.E{"updated_at", menu.Updated_at})
-
-		result, updateErr := menuCollection.UpdateOne(c, filter, bson.D{
-			{"$set", menuObj},
-		})
-
-		if updateErr != nil {
-			msg := fmt.Sprint("menu item update failed")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		result, err := service.UpdateMenu(c, c.Param("id"), menu)
+		if err != nil {
+			respondError(c, err)
 			return
 		}
 
@@ -139,20 +105,9 @@ func UpdateMenu() gin.HandlerFunc {
 
 func DeleteMenu() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var menuId = c.Param("id")
-		filter := bson.D{{"menu_id", menuId}}
-
-		err := menuCollection.FindOne(c, filter).Err()
+		result, err := service.DeleteMenu(c, c.Param("id"))
 		if err != nil {
-			msg := fmt.Sprint("menu item not found")
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-			return
-		}
-
-		result, deleteErr := menuCollection.DeleteOne(c, filter)
-		if deleteErr != nil {
-			msg := fmt.Sprint("error occurred while delete menu item")
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			respondError(c, err)
 			return
 		}
 
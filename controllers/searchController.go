@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/service"
+	"strconv"
+)
+
+// Search finds the foods and menus matching the q query parameter, of the
+// type parameter when set, returning up to limit of them.
+func Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := service.DefaultSearchLimit
+		if value := c.Query("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+				return
+			}
+		}
+
+		results, err := service.Search(c, c.Query("q"), c.Query("type"), limit)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	}
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"net/http"
+	"restaurant_management/service"
+	"restaurant_management/store"
+)
+
+var dataStore store.Store
+
+// UseStore sets the storage backend every controller reads from and writes to.
+func UseStore(s store.Store) {
+	dataStore = s
+	service.UseStore(s)
+}
+
+// pageResult wraps a page of documents the way the paginated list endpoints
+// return them.
+func pageResult(key string, docs []bson.M, total int64) []bson.M {
+	if total == 0 {
+		return nil
+	}
+	return []bson.M{{"total_count": total, key: docs}}
+}
+
+// respondError answers the status and message of a failed service operation.
+func respondError(c *gin.Context, err error) {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(serviceErr.Status, gin.H{"error": serviceErr.Message})
+}
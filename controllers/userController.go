@@ -3,26 +3,25 @@ package controllers
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
-	"log"
 	"net/http"
-	"restaurant_management/database"
 	"restaurant_management/helpers"
+	"restaurant_management/logger"
 	"restaurant_management/models"
 	"strconv"
 	"time"
 )
 
+var validate = validator.New()
+
 type UserLogin struct {
 	Email    *string `json:"email" validate:"email,required"`
 	Password *string `json:"password" validate:"required,min=6"`
 }
 
-var userCollection *mongo.Collection = database.OpenCollection(database.Client, "user")
-
 func GetUsers() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		recordPerPage, err := strconv.Atoi(c.Query("recordPerPage"))
@@ -47,35 +46,14 @@ func GetUsers() gin.HandlerFunc {
 
 		startIndex := (page - 1) * recordPerPage
 
-		matchStage := bson.D{{"$match", bson.D{{}}}}
-		groupStage := bson.D{
-			{"$group", bson.D{
-				{"_id", bson.D{{"_id", "null"}}},
-				{"total_count", bson.D{{"$sum", 1}}},
-				{"data", bson.D{{"$push", "$$ROOT"}}},
-			}}}
-		projectStage := bson.D{
-			{"$project", bson.D{
-				{"_id", 0},
-				{"total_count", 1},
-				{"user_items", bson.D{{"$slice", []interface{}{"$data", startIndex, recordPerPage}}}},
-			}}}
-
-		result, err := userCollection.Aggregate(c, mongo.Pipeline{
-			matchStage, groupStage, projectStage,
-		})
-
+		users, total, err := dataStore.Users().Page(c, startIndex, recordPerPage)
 		if err != nil {
+			logger.FromContext(c).Error("error occurred while listing food users", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while listing food users"})
 			return
 		}
 
-		var allUsers []bson.M
-		if err = result.All(c, &allUsers); err != nil {
-			log.Fatal(err)
-		}
-
-		c.JSON(http.StatusOK, allUsers)
+		c.JSON(http.StatusOK, pageResult("user_items", users, total))
 
 	}
 }
@@ -84,9 +62,7 @@ func GetUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userId := c.Param("id")
 
-		var user models.User
-
-		err := userCollection.FindOne(c, bson.M{"user_id": userId}).Decode(&user)
+		user, err := dataStore.Users().Get(c, userId)
 
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while get user"})
@@ -111,8 +87,9 @@ func SignUp() gin.HandlerFunc {
 			return
 		}
 
-		countEmail, err := userCollection.CountDocuments(c, bson.D{{"email", user.Email}})
+		countEmail, err := dataStore.Users().CountByEmail(c, *user.Email)
 		if err != nil {
+			logger.FromContext(c).Error("error occurred while checking for the email", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while checking for the email"})
 			return
 		}
@@ -122,8 +99,14 @@ func SignUp() gin.HandlerFunc {
 			return
 		}
 
-		countPhone, err := userCollection.CountDocuments(c, bson.D{{"phone", user.Phone}})
+		var phone string
+		if user.Phone != nil {
+			phone = *user.Phone
+		}
+
+		countPhone, err := dataStore.Users().CountByPhone(c, phone)
 		if err != nil {
+			logger.FromContext(c).Error("error occurred while checking for the phone number", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while checking for the phone number"})
 			return
 		}
@@ -133,26 +116,39 @@ func SignUp() gin.HandlerFunc {
 			return
 		}
 
-		password := HashPassword(*user.Password)
+		password, err := HashPassword(*user.Password)
+		if err != nil {
+			logger.FromContext(c).Error("password hashing failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while hashing the password"})
+			return
+		}
 		user.Password = &password
+		user.Permissions = nil
 
 		user.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
 		user.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
 		user.ID = primitive.NewObjectID()
 		user.User_id = user.ID.Hex()
 
-		token, refreshToken, _ := helpers.GenerateAllTokens(
+		token, refreshToken, err := helpers.GenerateAllTokens(
 			*user.Email,
 			*user.First_name,
 			*user.Last_name,
 			user.User_id,
+			user.Permissions,
 		)
+		if err != nil {
+			logger.FromContext(c).Error("token generation failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while generating the tokens"})
+			return
+		}
 
 		user.Token = &token
 		user.Refresh_token = &refreshToken
 
-		resultInsertionNumber, insertErr := userCollection.InsertOne(c, user)
+		resultInsertionNumber, insertErr := dataStore.Users().Create(c, user)
 		if insertErr != nil {
+			logger.FromContext(c).Error("user insert failed", zap.Error(insertErr))
 			msg := fmt.Sprintf("User item was not created")
 			c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
 			return
@@ -165,14 +161,18 @@ func SignUp() gin.HandlerFunc {
 func LogIn() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var userLogin UserLogin
-		var foundUser models.User
 
 		if err := c.BindJSON(&userLogin); err != nil {
 			c.JSON(http.StatusBadRequest, err.Error())
 			return
 		}
 
-		err := userCollection.FindOne(c, bson.M{"email": userLogin.Email}).Decode(&foundUser)
+		if validationErr := validate.Struct(userLogin); validationErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error()})
+			return
+		}
+
+		foundUser, err := dataStore.Users().GetByEmail(c, *userLogin.Email)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "user not found, login seems to be incorrect"})
 			return
@@ -184,20 +184,32 @@ func LogIn() gin.HandlerFunc {
 			return
 		}
 
-		token, refreshToken, _ := helpers.GenerateAllTokens(*foundUser.Email, *foundUser.First_name, *foundUser.Last_name, foundUser.User_id)
-		helpers.UpdateAllToken(c, token, refreshToken, foundUser.User_id)
+		token, refreshToken, err := helpers.GenerateAllTokens(*foundUser.Email, *foundUser.First_name, *foundUser.Last_name, foundUser.User_id, foundUser.Permissions)
+		if err != nil {
+			logger.FromContext(c).Error("token generation failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while generating the tokens"})
+			return
+		}
+
+		if err := dataStore.Users().UpdateTokens(c, foundUser.User_id, token, refreshToken); err != nil {
+			logger.FromContext(c).Error("token update failed", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error occurred while updating the tokens"})
+			return
+		}
+		foundUser.Token = &token
+		foundUser.Refresh_token = &refreshToken
 
 		c.JSON(http.StatusOK, foundUser)
 	}
 }
 
-func HashPassword(password string) string {
+func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
 	if err != nil {
-		log.Panic(err)
+		return "", err
 	}
 
-	return string(bytes)
+	return string(bytes), nil
 }
 
 func VerifyPassword(userPassword, providedPassword string) (bool, string) {
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/models"
+	"restaurant_management/service"
+	"strconv"
+)
+
+func GetWebhooks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allWebhooks, err := service.ListWebhooks(c)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, allWebhooks)
+	}
+}
+
+func GetWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhook, err := service.GetWebhook(c, c.Param("id"))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+func CreateWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var webhook models.Webhook
+
+		if err := c.ShouldBind(&webhook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := service.CreateWebhook(c, webhook)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func UpdateWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var webhook models.Webhook
+		if err := c.ShouldBind(&webhook); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := service.UpdateWebhook(c, c.Param("id"), webhook)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func DeleteWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := service.DeleteWebhook(c, c.Param("id"))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetWebhookDeliveries lists the last deliveries of a webhook, 100 unless
+// the limit query parameter asks for fewer.
+func GetWebhookDeliveries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.Query("limit"))
+		if err != nil {
+			limit = 100
+		}
+
+		deliveries, err := service.WebhookDeliveries(c, c.Param("id"), limit)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, deliveries)
+	}
+}
+
+func RedeliverWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		delivery, err := service.RedeliverWebhook(c, c.Param("id"), c.Param("deliveryId"))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, delivery)
+	}
+}
@@ -2,18 +2,29 @@ package database
 
 import (
 	"context"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 	"log"
+	"os"
+	"restaurant_management/metrics"
 	"time"
 )
 
+// Name is the database holding the restaurant collections.
+const Name = "restaurant_management"
+
 func ConnectDB() *mongo.Client {
-	uri := "mongodb://localhost:27017"
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	monitor := chainMonitors(metrics.MongoMonitor(), otelmongo.NewMonitor())
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetMonitor(monitor))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -23,6 +34,28 @@ func ConnectDB() *mongo.Client {
 var Client *mongo.Client = ConnectDB()
 
 func OpenCollection(client *mongo.Client, collectionName string) *mongo.Collection {
-	collection := client.Database("restaurant_management").Collection(collectionName)
+	collection := client.Database(Name).Collection(collectionName)
 	return collection
 }
+
+// chainMonitors forwards the command events to every monitor, the driver
+// only accepts one.
+func chainMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				m.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				m.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				m.Failed(ctx, evt)
+			}
+		},
+	}
+}
@@ -0,0 +1,31 @@
+// Package docs holds the OpenAPI document describing the HTTP API and the
+// Swagger UI page rendering it.
+package docs
+
+import (
+	"context"
+	_ "embed"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Spec is the OpenAPI 3 document of the API. It is maintained by hand, keep
+// it in sync with routes/ and the payloads of controllers/.
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+//go:embed swagger.html
+var SwaggerUI []byte
+
+// Load parses Spec and checks that it is a valid OpenAPI document.
+func Load(ctx context.Context) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(ctx); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
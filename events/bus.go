@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Handler reacts to an event in process.
+type Handler func(ctx context.Context, event Event) error
+
+// Transport forwards every event out of the process, to webhooks or a
+// message broker.
+type Transport interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// Bus hands the events to their subscribers and to the transports.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]Handler
+	transports  []Transport
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[string][]Handler{}}
+}
+
+// Subscribe calls handler with every event of eventType.
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventType] = append(b.subscribers[eventType], handler)
+}
+
+// AddTransport sends every event through t.
+func (b *Bus) AddTransport(t Transport) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.transports = append(b.transports, t)
+}
+
+// Publish hands event to the subscribers of its type, then to every
+// transport. All of them get the event, the first failure is returned.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := b.subscribers[event.Type]
+	transports := b.transports
+	b.mu.RUnlock()
+
+	var firstErr error
+	for i, handler := range handlers {
+		if err := handler(ctx, event); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("subscriber %d of %s: %w", i, event.Type, err)
+		}
+	}
+	for _, transport := range transports {
+		if err := transport.Send(ctx, event); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("transport %s: %w", transport.Name(), err)
+		}
+	}
+	return firstErr
+}
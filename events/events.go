@@ -0,0 +1,92 @@
+// Package events publishes the domain events of the restaurant: orders
+// placed, changed and deleted, items added, changed and removed, invoices
+// created, changed and paid, tables occupied and freed, foods sold out and
+// restored.
+//
+// The service layer writes every event to the outbox in the unit of work of
+// the change it records, so an event is published if and only if its change
+// was committed. The Relay reads the outbox and hands the events to the Bus:
+// to the subscribers of their type, in process, and to every transport,
+// which forwards them out of the process. An event is published at least
+// once: when a handler fails the event is published again later, to all its
+// handlers, which have to tolerate duplicates. Nor are the events published
+// in order: a handler needing it compares the dates of the events, or reads
+// the current state of what they record.
+package events
+
+import (
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"restaurant_management/models"
+	"time"
+)
+
+// The types of the domain events.
+const (
+	OrderPlaced    = "order.placed"
+	OrderUpdated   = "order.updated"
+	OrderDeleted   = "order.deleted"
+	ItemAdded      = "order_item.added"
+	ItemUpdated    = "order_item.updated"
+	ItemDeleted    = "order_item.deleted"
+	InvoiceCreated = "invoice.created"
+	InvoiceUpdated = "invoice.updated"
+	InvoicePaid    = "invoice.paid"
+	TableOccupied  = "table.occupied"
+	TableFreed     = "table.freed"
+	FoodSoldOut    = "food.sold_out"
+	FoodRestored   = "food.restored"
+)
+
+// Types lists every type of event.
+var Types = []string{
+	OrderPlaced, OrderUpdated, OrderDeleted, ItemAdded, ItemUpdated, ItemDeleted,
+	InvoiceCreated, InvoiceUpdated, InvoicePaid, TableOccupied, TableFreed, FoodSoldOut, FoodRestored,
+}
+
+// The status of an event in the outbox.
+const (
+	Pending   = "PENDING"
+	Published = "PUBLISHED"
+	Failed    = "FAILED"
+)
+
+// Event is a domain event as handlers get it. Data is the JSON document the
+// event is about: the order, the order item, the invoice or the table.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Created_at time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// New returns the outbox record of an event of eventType about data, to be
+// written with the change of data.
+func New(eventType string, data interface{}) (models.OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	record := models.OutboxEvent{
+		ID:              primitive.NewObjectID(),
+		Type:            eventType,
+		Payload:         string(payload),
+		Status:          Pending,
+		Next_attempt_at: createdAt,
+		Created_at:      createdAt,
+		Updated_at:      createdAt,
+	}
+	record.Event_id = record.ID.Hex()
+	return record, nil
+}
+
+func fromRecord(record models.OutboxEvent) Event {
+	return Event{
+		ID:         record.Event_id,
+		Type:       record.Type,
+		Created_at: record.Created_at,
+		Data:       json.RawMessage(record.Payload),
+	}
+}
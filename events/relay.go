@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"time"
+)
+
+type Options struct {
+	// MaxAttempts is the number of publications after which an event FAILED.
+	MaxAttempts int
+	// Backoff is the delay before publishing an event again, doubled after
+	// every failure up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// PollInterval is how often the outbox is read.
+	PollInterval time.Duration
+}
+
+// Relay publishes the events of the outbox on a bus, at least once and with
+// no ordering: it reads them in the order they were written, but an event
+// failing is published again after the events written after it, and the
+// relays of several instances reading the outbox together may publish the
+// same event twice.
+type Relay struct {
+	store store.Store
+	bus   *Bus
+	opts  Options
+}
+
+func NewRelay(s store.Store, bus *Bus, opts Options) *Relay {
+	return &Relay{store: s, bus: bus, opts: opts}
+}
+
+// Run publishes the pending events until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.publishDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishDue publishes the due events by batches, until a batch isn't full.
+func (r *Relay) publishDue(ctx context.Context) {
+	const batchSize = 50
+
+	for ctx.Err() == nil {
+		due, err := r.store.Outbox().Due(ctx, time.Now(), batchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("reading the outbox failed", zap.Error(err))
+			return
+		}
+
+		for _, record := range due {
+			r.publish(ctx, record)
+		}
+		if len(due) < batchSize {
+			return
+		}
+	}
+}
+
+// publish hands an event to the bus and records the outcome, scheduling the
+// next publication when it failed and attempts are left.
+func (r *Relay) publish(ctx context.Context, record models.OutboxEvent) {
+	log := logger.FromContext(ctx).With(zap.String("event_id", record.Event_id), zap.String("type", record.Type))
+
+	err := r.bus.Publish(ctx, fromRecord(record))
+	attempts := record.Attempts + 1
+	now, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	set := bson.D{
+		{"attempts", attempts},
+		{"updated_at", now},
+	}
+	var outcome string
+	switch {
+	case err == nil:
+		outcome = "published"
+		set = append(set, bson.E{"status", Published}, bson.E{"last_error", ""})
+	case attempts >= r.opts.MaxAttempts:
+		outcome = "failed"
+		set = append(set, bson.E{"status", Failed}, bson.E{"last_error", err.Error()})
+		log.Error("publishing the event failed", zap.Int("attempts", attempts), zap.Error(err))
+	default:
+		outcome = "retried"
+		set = append(set, bson.E{"last_error", err.Error()}, bson.E{"next_attempt_at", now.Add(r.backoff(attempts))})
+		log.Warn("publishing the event failed, retrying", zap.Int("attempts", attempts), zap.Error(err))
+	}
+	metrics.EventPublished(record.Type, outcome)
+
+	if _, err := r.store.Outbox().Update(ctx, record.Event_id, set); err != nil {
+		log.Error("recording the publication of the event failed", zap.Error(err))
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.opts.Backoff
+	for i := 1; i < attempts && delay < r.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.opts.MaxBackoff {
+		delay = r.opts.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,59 @@
+// Package graph serves the restaurant over GraphQL, for the screens needing
+// nested data: menus with their foods, orders with their table and items,
+// invoices with their order. Related documents are loaded in batches, one
+// query per level of the GraphQL query rather than one per parent.
+package graph
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"net/http"
+)
+
+type request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler runs the query of the request. Queries that don't parse, aren't
+// valid against the schema or go over limits are answered 400 without running.
+func Handler(limits Limits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req request
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+
+		if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+			c.JSON(http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+			return
+		}
+
+		if err := limits.check(doc, req.OperationName, req.Variables); err != nil {
+			c.JSON(http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           doc,
+			OperationName: req.OperationName,
+			Args:          req.Variables,
+			Context:       withLoaders(c),
+		})
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+	"strings"
+)
+
+// Limits bound the queries accepted, zero ones don't limit anything.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields, the fields of the query
+	// being at depth 1.
+	MaxDepth int
+	// MaxComplexity bounds the number of fields resolved: every field costs
+	// 1 and the fields under a list cost as many times as the list is
+	// expected to hold items, see listSize.
+	MaxComplexity int
+}
+
+// listSize is how many items a list is expected to hold when nothing tells,
+// paginated lists hold recordPerPage items.
+const listSize = 10
+
+// check measures the operation of doc that will run and rejects it when it
+// goes over the limits. Introspection fields aren't measured.
+func (l Limits) check(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	m := &measure{
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		case *ast.FragmentDefinition:
+			m.fragments[definition.Name.Value] = definition
+		}
+	}
+	// the executor reports operations missing or ambiguous
+	if len(operations) != 1 {
+		return nil
+	}
+
+	depth, complexity := m.selectionSet(operations[0].SelectionSet, schema.QueryType(), 0, map[string]bool{})
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity)
+	}
+	return nil
+}
+
+type measure struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selectionSet returns the depth and complexity of the fields selected on
+// parent. pageSize is the number of items of the list of a page, spread is
+// the set of fragments being walked through.
+func (m *measure) selectionSet(set *ast.SelectionSet, parent *graphql.Object, pageSize int, spread map[string]bool) (depth, complexity int) {
+	if set == nil || parent == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = m.field(selection, parent, pageSize, spread)
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(selection.SelectionSet, parent, pageSize, spread)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := m.fragments[name]
+			if !ok || spread[name] {
+				continue
+			}
+			spread[name] = true
+			d, c = m.selectionSet(fragment.SelectionSet, parent, pageSize, spread)
+			delete(spread, name)
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (m *measure) field(field *ast.Field, parent *graphql.Object, pageSize int, spread map[string]bool) (depth, complexity int) {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+
+	definition, ok := parent.Fields()[name]
+	if !ok {
+		return 1, 1
+	}
+
+	fieldType, isList := unwrap(definition.Type)
+	multiplier := 1
+	if isList {
+		multiplier = listSize
+		if pageSize > 0 {
+			multiplier = pageSize
+		}
+	}
+
+	childPageSize := 0
+	for _, arg := range definition.Args {
+		if arg.Name() == "recordPerPage" {
+			childPageSize = m.intArgument(field, arg.Name(), defaultRecordPerPage)
+		}
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	depth, complexity = m.selectionSet(field.SelectionSet, object, childPageSize, spread)
+	return depth + 1, 1 + multiplier*complexity
+}
+
+// intArgument returns the value of the int argument name of field, fallback
+// when it is left out.
+func (m *measure) intArgument(field *ast.Field, name string, fallback int) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return n
+			}
+		case *ast.Variable:
+			switch n := m.variables[value.Name.Value].(type) {
+			case float64:
+				return int(n)
+			case int:
+				return n
+			}
+		}
+	}
+	return fallback
+}
+
+// unwrap returns the named type of t and whether t is a list.
+func unwrap(t graphql.Output) (graphql.Output, bool) {
+	isList := false
+	for {
+		switch wrapper := t.(type) {
+		case *graphql.NonNull:
+			t = wrapper.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapper.OfType
+		default:
+			return t, isList
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"context"
+	"restaurant_management/models"
+	"restaurant_management/service"
+	"sync"
+)
+
+// loader batches the documents a query needs. The executor resolves every
+// field of a level of the query before the thunks returned by load, so the
+// keys of a whole level are fetched together when the first thunk runs,
+// whatever the number of parents.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	current *batch[V]
+	batches map[string]*batch[V]
+}
+
+type batch[V any] struct {
+	keys   []string
+	once   sync.Once
+	values map[string]V
+	err    error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, batches: map[string]*batch[V]{}}
+}
+
+// load returns a thunk resolving to the value of key, nil when there is
+// none. A key is fetched once per request.
+func (l *loader[V]) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	b, ok := l.batches[key]
+	if !ok {
+		if l.current == nil {
+			l.current = &batch[V]{}
+		}
+		b = l.current
+		b.keys = append(b.keys, key)
+		l.batches[key] = b
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.once.Do(func() {
+			// keys loaded from now on go to the next batch
+			l.mu.Lock()
+			if l.current == b {
+				l.current = nil
+			}
+			l.mu.Unlock()
+
+			b.values, b.err = l.fetch(ctx, b.keys)
+		})
+		if b.err != nil {
+			return nil, failure(b.err)
+		}
+
+		value, ok := b.values[key]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	}
+}
+
+// byId fetches documents by their id.
+func byId[T any](list func(ctx context.Context, ids []string) ([]T, error), id func(T) string) func(context.Context, []string) (map[string]T, error) {
+	return func(ctx context.Context, ids []string) (map[string]T, error) {
+		docs, err := list(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]T, len(docs))
+		for _, doc := range docs {
+			values[id(doc)] = doc
+		}
+		return values, nil
+	}
+}
+
+// groupedBy fetches the documents referencing one of keys, every key getting
+// a list even when nothing references it.
+func groupedBy[T any](list func(ctx context.Context, keys []string) ([]T, error), key func(T) string) func(context.Context, []string) (map[string][]T, error) {
+	return func(ctx context.Context, keys []string) (map[string][]T, error) {
+		docs, err := list(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string][]T, len(keys))
+		for _, k := range keys {
+			values[k] = []T{}
+		}
+		for _, doc := range docs {
+			values[key(doc)] = append(values[key(doc)], doc)
+		}
+		return values, nil
+	}
+}
+
+// loaders live for a single request, documents aren't shared between
+// requests.
+type loaders struct {
+	menus      *loader[models.Menu]
+	menuFoods  *loader[[]models.Food]
+	foods      *loader[models.Food]
+	tables     *loader[models.Table]
+	orders     *loader[models.Order]
+	orderItems *loader[[]models.OrderItem]
+}
+
+func newLoaders() *loaders {
+	return &loaders{
+		menus: newLoader(byId(service.MenusByIds, func(menu models.Menu) string {
+			return menu.Menu_id
+		})),
+		menuFoods: newLoader(groupedBy(service.FoodsByMenus, func(food models.Food) string {
+			return stringOf(food.Menu_id)
+		})),
+		foods: newLoader(byId(service.FoodsByIds, func(food models.Food) string {
+			return food.Food_id
+		})),
+		tables: newLoader(byId(service.TablesByIds, func(table models.Table) string {
+			return table.Table_id
+		})),
+		orders: newLoader(byId(service.OrdersByIds, func(order models.Order) string {
+			return order.Order_id
+		})),
+		orderItems: newLoader(groupedBy(service.OrderItemsByOrders, func(orderItem models.OrderItem) string {
+			return orderItem.Order_id
+		})),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders())
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func stringOf(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
@@ -0,0 +1,422 @@
+package graph
+
+import (
+	"errors"
+	"github.com/graphql-go/graphql"
+	"go.mongodb.org/mongo-driver/bson"
+	"restaurant_management/models"
+	"restaurant_management/service"
+	"restaurant_management/store"
+)
+
+// schema only has queries, writes go through the REST and gRPC APIs.
+var schema graphql.Schema
+
+// The object types are built by init, they refer to each other. Fields are
+// named after the JSON of the REST API: the default resolver finds the model
+// field of the same name, only the relations need resolvers.
+var menuType, foodType, tableType, orderType, orderItemType, invoiceType *graphql.Object
+
+func init() {
+	menuType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Menu",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"menu_id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":       &graphql.Field{Type: graphql.String},
+				"category":   &graphql.Field{Type: graphql.String},
+				"start_date": &graphql.Field{Type: graphql.DateTime},
+				"end_date":   &graphql.Field{Type: graphql.DateTime},
+				"created_at": &graphql.Field{Type: graphql.DateTime},
+				"updated_at": &graphql.Field{Type: graphql.DateTime},
+				"foods": &graphql.Field{
+					Type: listOf(foodType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						menu := p.Source.(models.Menu)
+						return loadersFrom(p.Context).menuFoods.load(p.Context, menu.Menu_id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	foodType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Food",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"food_id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":            &graphql.Field{Type: graphql.String},
+				"price":           &graphql.Field{Type: graphql.Float},
+				"food_image":      &graphql.Field{Type: graphql.String},
+				"food_thumbnail":  &graphql.Field{Type: graphql.String},
+				"description":     &graphql.Field{Type: graphql.String},
+				"category":        &graphql.Field{Type: graphql.String},
+				"tags":            &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"allergens":       &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"spice_level":     &graphql.Field{Type: graphql.Int},
+				"modifier_groups": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(modifierGroupType))},
+				"sold_out":        &graphql.Field{Type: graphql.Boolean},
+				"sold_out_until":  &graphql.Field{Type: graphql.DateTime},
+				"daily_limit":     &graphql.Field{Type: graphql.Int},
+				"menu_id":         &graphql.Field{Type: graphql.ID},
+				"created_at":      &graphql.Field{Type: graphql.DateTime},
+				"updated_at":      &graphql.Field{Type: graphql.DateTime},
+				"size_prices": &graphql.Field{
+					Type: sizePricesType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if prices := p.Source.(models.Food).Size_prices; prices != nil {
+							return prices, nil
+						}
+						return nil, nil
+					},
+				},
+				"menu": &graphql.Field{
+					Type: menuType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						food := p.Source.(models.Food)
+						if food.Menu_id == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).menus.load(p.Context, *food.Menu_id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	tableType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Table",
+		Fields: graphql.Fields{
+			"table_id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"number_of_guests": &graphql.Field{Type: graphql.Int},
+			"table_number":     &graphql.Field{Type: graphql.Int},
+			"table_status":     &graphql.Field{Type: graphql.String},
+			"created_at":       &graphql.Field{Type: graphql.DateTime},
+			"updated_at":       &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	orderType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"order_id":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"order_date":   &graphql.Field{Type: graphql.DateTime},
+				"order_status": &graphql.Field{Type: graphql.String},
+				"table_id":     &graphql.Field{Type: graphql.ID},
+				"created_at":   &graphql.Field{Type: graphql.DateTime},
+				"updated_at":   &graphql.Field{Type: graphql.DateTime},
+				"table": &graphql.Field{
+					Type: tableType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						order := p.Source.(models.Order)
+						if order.Table_id == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).tables.load(p.Context, *order.Table_id), nil
+					},
+				},
+				"items": &graphql.Field{
+					Type: listOf(orderItemType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						order := p.Source.(models.Order)
+						return loadersFrom(p.Context).orderItems.load(p.Context, order.Order_id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	orderItemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "OrderItem",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"order_item_id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"quantity":      &graphql.Field{Type: graphql.String},
+				"unit_price":    &graphql.Field{Type: graphql.Float},
+				"modifiers":     &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(selectedModifierType))},
+				"food_id":       &graphql.Field{Type: graphql.ID},
+				"order_id":      &graphql.Field{Type: graphql.ID},
+				"created_at":    &graphql.Field{Type: graphql.DateTime},
+				"updated_at":    &graphql.Field{Type: graphql.DateTime},
+				"food": &graphql.Field{
+					Type: foodType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						orderItem := p.Source.(models.OrderItem)
+						if orderItem.Food_id == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).foods.load(p.Context, *orderItem.Food_id), nil
+					},
+				},
+				"order": &graphql.Field{
+					Type: orderType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						orderItem := p.Source.(models.OrderItem)
+						return loadersFrom(p.Context).orders.load(p.Context, orderItem.Order_id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	invoiceType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Invoice",
+		Fields: graphql.Fields{
+			"invoice_id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"order_id":         &graphql.Field{Type: graphql.ID},
+			"payment_method":   &graphql.Field{Type: graphql.String},
+			"payment_status":   &graphql.Field{Type: graphql.String},
+			"payment_due_date": &graphql.Field{Type: graphql.DateTime},
+			"created_at":       &graphql.Field{Type: graphql.DateTime},
+			"updated_at":       &graphql.Field{Type: graphql.DateTime},
+			"order": &graphql.Field{
+				Type: orderType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					invoice := p.Source.(models.Invoice)
+					return loadersFrom(p.Context).orders.load(p.Context, invoice.Order_id), nil
+				},
+			},
+		},
+	})
+
+	foodPageType := pageOf("FoodPage", foodType)
+	orderPageType := pageOf("OrderPage", orderType)
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"menus": &graphql.Field{
+				Type: listOf(menuType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list[models.Menu](service.ListMenus(p.Context))
+				},
+			},
+			"menu": &graphql.Field{
+				Type: menuType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.GetMenu(p.Context, p.Args["id"].(string)))
+				},
+			},
+			"foods": &graphql.Field{
+				Type: graphql.NewNonNull(foodPageType),
+				Args: foodsArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter := store.FoodFilter{
+						Tags:         stringList(p.Args["tags"]),
+						AllergenFree: stringList(p.Args["allergen_free"]),
+					}
+					if category, ok := p.Args["category"].(string); ok {
+						filter.Category = category
+					}
+					if level, ok := p.Args["max_spice_level"].(int); ok {
+						filter.MaxSpiceLevel = &level
+					}
+					return paginated[models.Food](service.ListFoods(p.Context, filter, p.Args["page"].(int), p.Args["recordPerPage"].(int)))
+				},
+			},
+			"food": &graphql.Field{
+				Type: foodType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.GetFood(p.Context, p.Args["id"].(string)))
+				},
+			},
+			"tables": &graphql.Field{
+				Type: listOf(tableType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list[models.Table](service.ListTables(p.Context))
+				},
+			},
+			"table": &graphql.Field{
+				Type: tableType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.GetTable(p.Context, p.Args["id"].(string)))
+				},
+			},
+			"orders": &graphql.Field{
+				Type: graphql.NewNonNull(orderPageType),
+				Args: pageArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return paginated[models.Order](service.ListOrders(p.Context, p.Args["page"].(int), p.Args["recordPerPage"].(int)))
+				},
+			},
+			"order": &graphql.Field{
+				Type: orderType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.GetOrder(p.Context, p.Args["id"].(string)))
+				},
+			},
+			"orderItems": &graphql.Field{
+				Type: listOf(orderItemType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list[models.OrderItem](service.ListOrderItems(p.Context))
+				},
+			},
+			"orderItem": &graphql.Field{
+				Type: orderItemType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.GetOrderItem(p.Context, p.Args["id"].(string)))
+				},
+			},
+			"invoices": &graphql.Field{
+				Type: listOf(invoiceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list[models.Invoice](service.ListInvoices(p.Context))
+				},
+			},
+			"invoice": &graphql.Field{
+				Type: invoiceType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(service.FindInvoice(p.Context, p.Args["id"].(string)))
+				},
+			},
+		},
+	})
+
+	var err error
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// page is what the paginated lists resolve to.
+type page[T any] struct {
+	Total_count int64
+	Items       []T
+}
+
+func pageOf(name string, itemType *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"total_count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"items":       &graphql.Field{Type: listOf(itemType)},
+		},
+	})
+}
+
+func listOf(t graphql.Type) *graphql.NonNull {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+var idArgs = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+}
+
+var modifierGroupType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ModifierGroup",
+	Fields: graphql.Fields{
+		"group_id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name":           &graphql.Field{Type: graphql.String},
+		"required":       &graphql.Field{Type: graphql.Boolean},
+		"min_selections": &graphql.Field{Type: graphql.Int},
+		"max_selections": &graphql.Field{Type: graphql.Int},
+		"options": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+			Name: "ModifierOption",
+			Fields: graphql.Fields{
+				"option_id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":        &graphql.Field{Type: graphql.String},
+				"price_delta": &graphql.Field{Type: graphql.Float},
+			},
+		})))},
+	},
+})
+
+var selectedModifierType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SelectedModifier",
+	Fields: graphql.Fields{
+		"group_id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"option_id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"group_name":  &graphql.Field{Type: graphql.String},
+		"name":        &graphql.Field{Type: graphql.String},
+		"price_delta": &graphql.Field{Type: graphql.Float},
+	},
+})
+
+// sizePricesType holds the prices of the portion sizes of a food, null for
+// the sizes costing the price of the food.
+var sizePricesType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SizePrices",
+	Fields: graphql.Fields{
+		"S": &graphql.Field{Type: graphql.Float, Resolve: sizePrice("S")},
+		"M": &graphql.Field{Type: graphql.Float, Resolve: sizePrice("M")},
+		"L": &graphql.Field{Type: graphql.Float, Resolve: sizePrice("L")},
+	},
+})
+
+func sizePrice(size string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if price, ok := p.Source.(map[string]float64)[size]; ok {
+			return price, nil
+		}
+		return nil, nil
+	}
+}
+
+var pageArgs = graphql.FieldConfigArgument{
+	"page":          &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+	"recordPerPage": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultRecordPerPage},
+}
+
+const defaultRecordPerPage = 10
+
+// foodsArgs page and filter the foods, see store.FoodFilter.
+var foodsArgs = graphql.FieldConfigArgument{
+	"page":            pageArgs["page"],
+	"recordPerPage":   pageArgs["recordPerPage"],
+	"category":        &graphql.ArgumentConfig{Type: graphql.String},
+	"tags":            &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	"allergen_free":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	"max_spice_level": &graphql.ArgumentConfig{Type: graphql.Int},
+}
+
+func stringList(arg interface{}) []string {
+	values, _ := arg.([]interface{})
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		list = append(list, value.(string))
+	}
+	return list
+}
+
+func one[T any](value T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, failure(err)
+	}
+	return value, nil
+}
+
+func list[T any](docs []bson.M, err error) (interface{}, error) {
+	if err != nil {
+		return nil, failure(err)
+	}
+	return store.Decode[T](docs)
+}
+
+func paginated[T any](docs []bson.M, total int64, err error) (interface{}, error) {
+	if err != nil {
+		return nil, failure(err)
+	}
+	items, err := store.Decode[T](docs)
+	if err != nil {
+		return nil, err
+	}
+	return page[T]{Total_count: total, Items: items}, nil
+}
+
+// failure hides the error of the store behind the message the REST API
+// answers.
+func failure(err error) error {
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		return errors.New(serviceErr.Message)
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+	"restaurant_management/store"
+	"time"
+)
+
+// toStatus turns the error of an operation into a gRPC status carrying the
+// message the REST API answers.
+func toStatus(err error) error {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	switch serviceErr.Kind {
+	case service.Invalid:
+		return status.Error(codes.InvalidArgument, serviceErr.Message)
+	case service.NotFound:
+		return status.Error(codes.NotFound, serviceErr.Message)
+	case service.Forbidden:
+		return status.Error(codes.PermissionDenied, serviceErr.Message)
+	}
+	return status.Error(codes.Internal, serviceErr.Message)
+}
+
+// decode converts the documents returned by the list operations into T.
+func decode[T any](docs []bson.M) ([]T, error) {
+	values, err := store.Decode[T](docs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return values, nil
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timestampOf(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// timeOf returns nil when the request left ts out.
+func timeOf(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func value[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// intOf converts the optional int32 of a request into the int of a model.
+func intOf(p *int32) *int {
+	if p == nil {
+		return nil
+	}
+	i := int(*p)
+	return &i
+}
+
+// int32Of converts the optional int of a model into the int32 of a message.
+func int32Of(p *int) *int32 {
+	if p == nil {
+		return nil
+	}
+	i := int32(*p)
+	return &i
+}
+
+// stringsOf returns nil when the request left list out, and an empty slice
+// when it set none.
+func stringsOf(list *pb.StringList) []string {
+	if list == nil {
+		return nil
+	}
+	return append([]string{}, list.Values...)
+}
+
+// stringList leaves out nil values.
+func stringList(values []string) *pb.StringList {
+	if values == nil {
+		return nil
+	}
+	return &pb.StringList{Values: values}
+}
+
+func createResponse(result *mongo.InsertOneResult) *pb.CreateResponse {
+	return &pb.CreateResponse{Id: hex(result.InsertedID)}
+}
+
+func updateResponse(result *mongo.UpdateResult) *pb.UpdateResponse {
+	return &pb.UpdateResponse{MatchedCount: result.MatchedCount, ModifiedCount: result.ModifiedCount}
+}
+
+func deleteResponse(result *mongo.DeleteResult) *pb.DeleteResponse {
+	return &pb.DeleteResponse{DeletedCount: result.DeletedCount}
+}
+
+func hex(id interface{}) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return oid.Hex()
+	}
+	s, _ := id.(string)
+	return s
+}
@@ -0,0 +1,206 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+	"restaurant_management/store"
+	"time"
+)
+
+type foodServer struct {
+	pb.UnimplementedFoodServiceServer
+}
+
+func (foodServer) ListFoods(ctx context.Context, req *pb.PageRequest) (*pb.ListFoodsResponse, error) {
+	docs, total, err := service.ListFoods(ctx, store.FoodFilter{}, int(req.Page), int(req.RecordPerPage))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	foods, err := decode[models.Food](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListFoodsResponse{TotalCount: total}
+	for _, food := range foods {
+		resp.Foods = append(resp.Foods, foodMessage(food))
+	}
+	return resp, nil
+}
+
+func (foodServer) GetFood(ctx context.Context, req *pb.IdRequest) (*pb.Food, error) {
+	food, err := service.GetFood(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return foodMessage(food), nil
+}
+
+func (foodServer) CreateFood(ctx context.Context, req *pb.CreateFoodRequest) (*pb.CreateResponse, error) {
+	food := models.Food{
+		Name:            &req.Name,
+		Price:           &req.Price,
+		Food_image:      &req.FoodImage,
+		Menu_id:         &req.MenuId,
+		Tags:            req.Tags,
+		Allergens:       stringsOf(req.Allergens),
+		Spice_level:     intOf(req.SpiceLevel),
+		Size_prices:     req.SizePrices,
+		Modifier_groups: modifierGroupsOf(req.ModifierGroups),
+		Sold_out_until:  timeOf(req.SoldOutUntil),
+		Daily_limit:     intOf(req.DailyLimit),
+	}
+	if req.SoldOut {
+		food.Sold_out = &req.SoldOut
+	}
+	if req.Description != "" {
+		food.Description = &req.Description
+	}
+	if req.Category != "" {
+		food.Category = &req.Category
+	}
+
+	result, err := service.CreateFood(ctx, food)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return createResponse(result), nil
+}
+
+func (foodServer) UpdateFood(ctx context.Context, req *pb.UpdateFoodRequest) (*pb.UpdateResponse, error) {
+	food := models.Food{
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    req.Category,
+		Price:       req.Price,
+		Food_image:  req.FoodImage,
+		Menu_id:     req.MenuId,
+		Tags:        stringsOf(req.Tags),
+		Allergens:   stringsOf(req.Allergens),
+		Spice_level: intOf(req.SpiceLevel),
+		Sold_out:    req.SoldOut,
+		Daily_limit: intOf(req.DailyLimit),
+	}
+	if req.SoldOut != nil {
+		food.Sold_out_until = timeOf(req.SoldOutUntil)
+	}
+	if req.SizePrices != nil {
+		// an empty map removes the prices
+		food.Size_prices = map[string]float64{}
+		for size, price := range req.SizePrices.Prices {
+			food.Size_prices[size] = price
+		}
+	}
+	if req.ModifierGroups != nil {
+		// an empty list removes the groups
+		food.Modifier_groups = append([]models.ModifierGroup{}, modifierGroupsOf(req.ModifierGroups.Groups)...)
+	}
+
+	result, err := service.UpdateFood(ctx, req.FoodId, food)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (foodServer) DeleteFood(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteFood(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+func (foodServer) ListSoldOutFoods(ctx context.Context, _ *emptypb.Empty) (*pb.ListSoldOutFoodsResponse, error) {
+	foods, err := service.SoldOutFoods(ctx, time.Now())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListSoldOutFoodsResponse{}
+	for _, food := range foods {
+		resp.Foods = append(resp.Foods, &pb.SoldOutFood{
+			FoodId:       food.Food_id,
+			Name:         food.Name,
+			MenuId:       food.Menu_id,
+			SoldOut:      food.Sold_out,
+			SoldOutUntil: timestampOf(food.Sold_out_until),
+			DailyLimit:   int32Of(food.Daily_limit),
+			PortionsLeft: int32Of(food.Portions_left),
+		})
+	}
+	return resp, nil
+}
+
+func foodMessage(food models.Food) *pb.Food {
+	return &pb.Food{
+		FoodId:         food.Food_id,
+		Name:           value(food.Name),
+		Price:          value(food.Price),
+		FoodImage:      value(food.Food_image),
+		FoodThumbnail:  value(food.Food_thumbnail),
+		MenuId:         value(food.Menu_id),
+		CreatedAt:      timestamp(food.Created_at),
+		UpdatedAt:      timestamp(food.Updated_at),
+		ModifierGroups: modifierGroupMessages(food.Modifier_groups),
+		Description:    value(food.Description),
+		Category:       value(food.Category),
+		Tags:           food.Tags,
+		Allergens:      stringList(food.Allergens),
+		SpiceLevel:     int32Of(food.Spice_level),
+		SizePrices:     food.Size_prices,
+		SoldOut:        value(food.Sold_out),
+		SoldOutUntil:   timestampOf(food.Sold_out_until),
+		DailyLimit:     int32Of(food.Daily_limit),
+	}
+}
+
+func modifierGroupMessages(groups []models.ModifierGroup) []*pb.ModifierGroup {
+	var messages []*pb.ModifierGroup
+	for _, group := range groups {
+		message := &pb.ModifierGroup{
+			GroupId:       group.Group_id,
+			Name:          group.Name,
+			Required:      group.Required,
+			MinSelections: int32(group.Min_selections),
+			MaxSelections: int32(group.Max_selections),
+		}
+		for _, option := range group.Options {
+			message.Options = append(message.Options, &pb.ModifierOption{
+				OptionId:   option.Option_id,
+				Name:       option.Name,
+				PriceDelta: option.Price_delta,
+			})
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// modifierGroupsOf converts the modifier groups of a request, nil when there
+// are none.
+func modifierGroupsOf(messages []*pb.ModifierGroup) []models.ModifierGroup {
+	var groups []models.ModifierGroup
+	for _, message := range messages {
+		group := models.ModifierGroup{
+			Group_id:       message.GroupId,
+			Name:           message.Name,
+			Required:       message.Required,
+			Min_selections: int(message.MinSelections),
+			Max_selections: int(message.MaxSelections),
+		}
+		for _, option := range message.Options {
+			group.Options = append(group.Options, models.ModifierOption{
+				Option_id:   option.OptionId,
+				Name:        option.Name,
+				Price_delta: option.PriceDelta,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
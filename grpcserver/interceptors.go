@@ -0,0 +1,157 @@
+package grpcserver
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"restaurant_management/helpers"
+	"restaurant_management/logger"
+	"restaurant_management/middleware"
+	"strings"
+	"time"
+)
+
+// publicMethods are served without a token, the reflection service lets
+// tools like grpcurl list the API.
+var publicMethods = []string{
+	"/grpc.reflection.v1alpha.ServerReflection/",
+	"/grpc.reflection.v1.ServerReflection/",
+}
+
+// authenticate checks the JWT sent in the token metadata, like the REST API
+// reads the token header, and adds the uid to the call logger.
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get("token")
+	if len(tokens) == 0 || tokens[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "No Authorization header provided")
+	}
+
+	claims, msg := helpers.ValidateToken(tokens[0])
+	if msg != "" {
+		return nil, status.Error(codes.Unauthenticated, msg)
+	}
+
+	if c, ok := ctx.Value(callKey{}).(*call); ok {
+		c.uid = claims.Uid
+	}
+	ctx = helpers.WithPermissions(ctx, claims.Permissions)
+	return logger.WithContext(ctx, logger.FromContext(ctx).With(zap.String("uid", claims.Uid))), nil
+}
+
+func unaryAuthentication(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuthentication(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ss, ctx})
+}
+
+type callKey struct{}
+
+// call is what the logger learns about a call while it is served.
+type call struct {
+	uid string
+}
+
+// callLogger gives the call a logger carrying its request ID, taken from the
+// x-request-id metadata or generated, and method.
+func callLogger(ctx context.Context, method string) (context.Context, *call) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := ""
+	if ids := md.Get(strings.ToLower(middleware.RequestIDHeader)); len(ids) > 0 && len(ids[0]) <= 128 {
+		requestID = ids[0]
+	}
+	if requestID == "" {
+		requestID = middleware.NewRequestID()
+	}
+
+	c := &call{}
+	ctx = context.WithValue(ctx, callKey{}, c)
+	return logger.WithContext(ctx, logger.L().With(zap.String("request_id", requestID), zap.String("method", method))), c
+}
+
+func logCall(ctx context.Context, c *call, err error, start time.Time) {
+	code := status.Code(err)
+	fields := []zap.Field{zap.String("code", code.String()), zap.Duration("latency", time.Since(start))}
+
+	// authentication sets the uid, when it succeeds
+	l := logger.FromContext(ctx)
+	if c.uid != "" {
+		l = l.With(zap.String("uid", c.uid))
+	}
+	switch code {
+	case codes.OK:
+		l.Info("call served", fields...)
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+		l.Error("call served", append(fields, zap.Error(err))...)
+	default:
+		l.Warn("call served", append(fields, zap.Error(err))...)
+	}
+}
+
+func unaryLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, c := callLogger(ctx, info.FullMethod)
+
+	resp, err := handler(ctx, req)
+	logCall(ctx, c, err, start)
+	return resp, err
+}
+
+func streamLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, c := callLogger(ss.Context(), info.FullMethod)
+
+	err := handler(srv, &contextStream{ss, ctx})
+	logCall(ctx, c, err, start)
+	return err
+}
+
+func unaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx).Error("call panicked", zap.String("method", info.FullMethod), zap.Any("panic", r), zap.Stack("stack"))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func streamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ss.Context()).Error("call panicked", zap.String("method", info.FullMethod), zap.Any("panic", r), zap.Stack("stack"))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,115 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+)
+
+type invoiceServer struct {
+	pb.UnimplementedInvoiceServiceServer
+}
+
+func (invoiceServer) ListInvoices(ctx context.Context, _ *emptypb.Empty) (*pb.ListInvoicesResponse, error) {
+	docs, err := service.ListInvoices(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	invoices, err := decode[models.Invoice](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListInvoicesResponse{}
+	for _, invoice := range invoices {
+		resp.Invoices = append(resp.Invoices, &pb.Invoice{
+			InvoiceId:      invoice.Invoice_id,
+			OrderId:        invoice.Order_id,
+			PaymentMethod:  invoice.Payment_method,
+			PaymentStatus:  value(invoice.Payment_status),
+			PaymentDueDate: timestamp(invoice.Payment_due_date),
+			CreatedAt:      timestamp(invoice.Created_at),
+			UpdatedAt:      timestamp(invoice.Updated_at),
+		})
+	}
+	return resp, nil
+}
+
+func (invoiceServer) GetInvoice(ctx context.Context, req *pb.IdRequest) (*pb.InvoiceView, error) {
+	view, err := service.GetInvoice(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	items, err := decodeSummaryItems(view.Order_details)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.InvoiceView{
+		InvoiceId:      view.Invoice_id,
+		OrderId:        view.Order_id,
+		PaymentMethod:  view.Payment_method,
+		PaymentStatus:  value(view.Payment_status),
+		PaymentDueDate: timestamp(view.Payment_due_date),
+		OrderDetails:   summaryItemMessages(items),
+	}
+	resp.PaymentDue, _ = toFloat(view.Payment_due)
+	if tableNumber, ok := toFloat(view.Table_number); ok {
+		n := int32(tableNumber)
+		resp.TableNumber = &n
+	}
+	return resp, nil
+}
+
+func (invoiceServer) CreateInvoice(ctx context.Context, req *pb.CreateInvoiceRequest) (*pb.CreateResponse, error) {
+	result, err := service.CreateInvoice(ctx, models.Invoice{
+		Order_id:       req.OrderId,
+		Payment_method: req.PaymentMethod,
+		Payment_status: req.PaymentStatus,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return createResponse(result), nil
+}
+
+func (invoiceServer) UpdateInvoice(ctx context.Context, req *pb.UpdateInvoiceRequest) (*pb.UpdateResponse, error) {
+	result, err := service.UpdateInvoice(ctx, req.InvoiceId, models.Invoice{
+		Payment_method: req.PaymentMethod,
+		Payment_status: req.PaymentStatus,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (invoiceServer) DeleteInvoice(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteInvoice(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+// toFloat reads the numbers of the invoice view, whose type depends on how
+// they were stored.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
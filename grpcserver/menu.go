@@ -0,0 +1,149 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+	"time"
+)
+
+type menuServer struct {
+	pb.UnimplementedMenuServiceServer
+}
+
+func (menuServer) ListMenus(ctx context.Context, _ *emptypb.Empty) (*pb.ListMenusResponse, error) {
+	docs, err := service.ListMenus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	menus, err := decode[models.Menu](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListMenusResponse{}
+	for _, menu := range menus {
+		resp.Menus = append(resp.Menus, menuMessage(menu))
+	}
+	return resp, nil
+}
+
+func (menuServer) ListActiveMenus(ctx context.Context, req *pb.ActiveMenusRequest) (*pb.ListMenusResponse, error) {
+	at := time.Now()
+	if req.At != nil {
+		at = req.At.AsTime()
+	}
+
+	menus, err := service.ActiveMenus(ctx, at)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListMenusResponse{}
+	for _, menu := range menus {
+		resp.Menus = append(resp.Menus, menuMessage(menu))
+	}
+	return resp, nil
+}
+
+func (menuServer) GetMenu(ctx context.Context, req *pb.IdRequest) (*pb.Menu, error) {
+	menu, err := service.GetMenu(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return menuMessage(menu), nil
+}
+
+func (menuServer) CreateMenu(ctx context.Context, req *pb.CreateMenuRequest) (*pb.CreateResponse, error) {
+	result, err := service.CreateMenu(ctx, models.Menu{
+		Name:         req.Name,
+		Category:     req.Category,
+		Start_date:   timeOf(req.StartDate),
+		End_date:     timeOf(req.EndDate),
+		Availability: availabilityOf(req.Availability),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return createResponse(result), nil
+}
+
+func (menuServer) UpdateMenu(ctx context.Context, req *pb.UpdateMenuRequest) (*pb.UpdateResponse, error) {
+	result, err := service.UpdateMenu(ctx, req.MenuId, models.Menu{
+		Name:         req.Name,
+		Category:     req.Category,
+		Start_date:   timeOf(req.StartDate),
+		End_date:     timeOf(req.EndDate),
+		Availability: availabilityOf(req.Availability),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (menuServer) DeleteMenu(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteMenu(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+func menuMessage(menu models.Menu) *pb.Menu {
+	return &pb.Menu{
+		MenuId:       menu.Menu_id,
+		Name:         menu.Name,
+		Category:     menu.Category,
+		StartDate:    timestampOf(menu.Start_date),
+		EndDate:      timestampOf(menu.End_date),
+		CreatedAt:    timestamp(menu.Created_at),
+		UpdatedAt:    timestamp(menu.Updated_at),
+		Availability: availabilityMessage(menu.Availability),
+	}
+}
+
+func availabilityMessage(availability *models.Availability) *pb.Availability {
+	if availability == nil {
+		return nil
+	}
+
+	message := &pb.Availability{TimeZone: availability.Time_zone}
+	for _, rule := range availability.Rules {
+		message.Rules = append(message.Rules, &pb.AvailabilityRule{Days: rule.Days, Start: rule.Start, End: rule.End})
+	}
+	for _, exception := range availability.Exceptions {
+		message.Exceptions = append(message.Exceptions, &pb.AvailabilityException{
+			Date:  exception.Date,
+			Name:  exception.Name,
+			Start: exception.Start,
+			End:   exception.End,
+		})
+	}
+	return message
+}
+
+// availabilityOf converts the availability of a request, nil when it left it
+// out.
+func availabilityOf(message *pb.Availability) *models.Availability {
+	if message == nil {
+		return nil
+	}
+
+	availability := &models.Availability{Time_zone: message.TimeZone}
+	for _, rule := range message.Rules {
+		availability.Rules = append(availability.Rules, models.AvailabilityRule{Days: rule.Days, Start: rule.Start, End: rule.End})
+	}
+	for _, exception := range message.Exceptions {
+		availability.Exceptions = append(availability.Exceptions, models.AvailabilityException{
+			Date:  exception.Date,
+			Name:  exception.Name,
+			Start: exception.Start,
+			End:   exception.End,
+		})
+	}
+	return availability
+}
@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"context"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+)
+
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+}
+
+func (orderServer) ListOrders(ctx context.Context, req *pb.PageRequest) (*pb.ListOrdersResponse, error) {
+	docs, total, err := service.ListOrders(ctx, int(req.Page), int(req.RecordPerPage))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	orders, err := decode[models.Order](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListOrdersResponse{TotalCount: total}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, orderMessage(order))
+	}
+	return resp, nil
+}
+
+func (orderServer) GetOrder(ctx context.Context, req *pb.IdRequest) (*pb.Order, error) {
+	order, err := service.GetOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return orderMessage(order), nil
+}
+
+func (orderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateResponse, error) {
+	result, err := service.CreateOrder(ctx, models.Order{
+		Order_date: value(timeOf(req.OrderDate)),
+		Table_id:   &req.TableId,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return createResponse(result), nil
+}
+
+func (orderServer) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*pb.UpdateResponse, error) {
+	result, err := service.UpdateOrder(ctx, req.OrderId, models.Order{
+		Order_date: value(timeOf(req.OrderDate)),
+		Table_id:   req.TableId,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (orderServer) DeleteOrder(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+func orderMessage(order models.Order) *pb.Order {
+	return &pb.Order{
+		OrderId:     order.Order_id,
+		OrderDate:   timestamp(order.Order_date),
+		OrderStatus: value(order.Order_status),
+		TableId:     value(order.Table_id),
+		CreatedAt:   timestamp(order.Created_at),
+		UpdatedAt:   timestamp(order.Updated_at),
+	}
+}
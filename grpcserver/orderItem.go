@@ -0,0 +1,200 @@
+package grpcserver
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+)
+
+type orderItemServer struct {
+	pb.UnimplementedOrderItemServiceServer
+}
+
+func (orderItemServer) ListOrderItems(ctx context.Context, _ *emptypb.Empty) (*pb.ListOrderItemsResponse, error) {
+	docs, err := service.ListOrderItems(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	orderItems, err := decode[models.OrderItem](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListOrderItemsResponse{}
+	for _, orderItem := range orderItems {
+		resp.OrderItems = append(resp.OrderItems, orderItemMessage(orderItem))
+	}
+	return resp, nil
+}
+
+func (orderItemServer) OrderItemsByOrder(ctx context.Context, req *pb.IdRequest) (*pb.OrderSummary, error) {
+	docs, err := service.OrderItemsByOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	summaries, err := decode[orderSummary](docs)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return &pb.OrderSummary{}, nil
+	}
+
+	summary := summaries[0]
+	return &pb.OrderSummary{
+		PaymentDue:  summary.Payment_due,
+		TotalCount:  summary.Total_count,
+		TableNumber: summary.Table_number,
+		OrderItems:  summaryItemMessages(summary.Order_items),
+	}, nil
+}
+
+func (orderItemServer) GetOrderItem(ctx context.Context, req *pb.IdRequest) (*pb.OrderItem, error) {
+	orderItem, err := service.GetOrderItem(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return orderItemMessage(orderItem), nil
+}
+
+func (orderItemServer) CreateOrderItems(ctx context.Context, req *pb.CreateOrderItemsRequest) (*pb.CreateOrderItemsResponse, error) {
+	pack := service.OrderItemPack{Table_id: &req.TableId}
+	for _, item := range req.OrderItems {
+		item := item
+		pack.Order_items = append(pack.Order_items, models.OrderItem{
+			Food_id:    &item.FoodId,
+			Quantity:   &item.Quantity,
+			Unit_price: item.UnitPrice,
+			Modifiers:  selectedModifiersOf(item.Modifiers),
+		})
+	}
+
+	result, err := service.CreateOrderItems(ctx, pack)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.CreateOrderItemsResponse{}
+	for _, id := range result.InsertedIDs {
+		resp.Ids = append(resp.Ids, hex(id))
+	}
+	return resp, nil
+}
+
+func (orderItemServer) UpdateOrderItem(ctx context.Context, req *pb.UpdateOrderItemRequest) (*pb.UpdateResponse, error) {
+	result, err := service.UpdateOrderItem(ctx, req.OrderItemId, models.OrderItem{
+		Quantity:   req.Quantity,
+		Unit_price: req.UnitPrice,
+		Food_id:    req.FoodId,
+		Modifiers:  selectedModifiersOf(req.Modifiers),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (orderItemServer) DeleteOrderItem(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteOrderItem(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+func orderItemMessage(orderItem models.OrderItem) *pb.OrderItem {
+	return &pb.OrderItem{
+		OrderItemId: orderItem.Order_item_id,
+		Quantity:    value(orderItem.Quantity),
+		UnitPrice:   value(orderItem.Unit_price),
+		FoodId:      value(orderItem.Food_id),
+		OrderId:     orderItem.Order_id,
+		CreatedAt:   timestamp(orderItem.Created_at),
+		UpdatedAt:   timestamp(orderItem.Updated_at),
+		Modifiers:   selectedModifierMessages(orderItem.Modifiers),
+	}
+}
+
+func selectedModifierMessages(modifiers []models.SelectedModifier) []*pb.SelectedModifier {
+	var messages []*pb.SelectedModifier
+	for _, modifier := range modifiers {
+		messages = append(messages, &pb.SelectedModifier{
+			GroupId:    modifier.Group_id,
+			OptionId:   modifier.Option_id,
+			GroupName:  modifier.Group_name,
+			Name:       modifier.Name,
+			PriceDelta: modifier.Price_delta,
+		})
+	}
+	return messages
+}
+
+// selectedModifiersOf converts the modifiers selected in a request, only the
+// options are read, nil when there are none.
+func selectedModifiersOf(messages []*pb.SelectedModifier) []models.SelectedModifier {
+	var modifiers []models.SelectedModifier
+	for _, message := range messages {
+		modifiers = append(modifiers, models.SelectedModifier{Group_id: message.GroupId, Option_id: message.OptionId})
+	}
+	return modifiers
+}
+
+// orderSummary is a document returned by ItemsByOrder.
+type orderSummary struct {
+	Payment_due  float64            `bson:"payment_due"`
+	Total_count  int64              `bson:"total_count"`
+	Table_number *int32             `bson:"table_number"`
+	Order_items  []orderSummaryItem `bson:"order_items"`
+}
+
+type orderSummaryItem struct {
+	Order_item_id string  `bson:"order_item_id"`
+	Food_id       string  `bson:"food_id"`
+	Food_name     string  `bson:"food_name"`
+	Food_image    string  `bson:"food_image"`
+	Unit_price    float64 `bson:"unit_price"`
+	Price         float64 `bson:"price"`
+	Amount        float64 `bson:"amount"`
+	Quantity      int64   `bson:"quantity"`
+	Order_id      string  `bson:"order_id"`
+	Table_id      string  `bson:"table_id"`
+	Table_number  int32   `bson:"table_number"`
+}
+
+func summaryItemMessages(items []orderSummaryItem) []*pb.OrderSummaryItem {
+	var messages []*pb.OrderSummaryItem
+	for _, item := range items {
+		messages = append(messages, &pb.OrderSummaryItem{
+			OrderItemId: item.Order_item_id,
+			FoodId:      item.Food_id,
+			FoodName:    item.Food_name,
+			FoodImage:   item.Food_image,
+			UnitPrice:   item.Unit_price,
+			Price:       item.Price,
+			Amount:      item.Amount,
+			Quantity:    item.Quantity,
+			OrderId:     item.Order_id,
+			TableId:     item.Table_id,
+			TableNumber: item.Table_number,
+		})
+	}
+	return messages
+}
+
+// decodeSummaryItems converts the order details of an invoice, the items of
+// an ItemsByOrder document.
+func decodeSummaryItems(details interface{}) ([]orderSummaryItem, error) {
+	data, err := bson.Marshal(bson.M{"order_items": details})
+	if err != nil {
+		return nil, err
+	}
+
+	var summary orderSummary
+	err = bson.Unmarshal(data, &summary)
+	return summary.Order_items, err
+}
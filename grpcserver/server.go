@@ -0,0 +1,29 @@
+// Package grpcserver exposes the food, menu, table, order, order item and
+// invoice operations of the service package over gRPC, behind the same JWT
+// as the REST API.
+package grpcserver
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"restaurant_management/pb"
+)
+
+// NewServer returns a server with every service registered, logging the
+// calls and authenticating them.
+func NewServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecovery, unaryLogger, unaryAuthentication),
+		grpc.ChainStreamInterceptor(streamRecovery, streamLogger, streamAuthentication),
+	)
+
+	pb.RegisterFoodServiceServer(server, foodServer{})
+	pb.RegisterMenuServiceServer(server, menuServer{})
+	pb.RegisterTableServiceServer(server, tableServer{})
+	pb.RegisterOrderServiceServer(server, orderServer{})
+	pb.RegisterOrderItemServiceServer(server, orderItemServer{})
+	pb.RegisterInvoiceServiceServer(server, invoiceServer{})
+	reflection.Register(server)
+
+	return server
+}
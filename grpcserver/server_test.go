@@ -0,0 +1,617 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"image"
+	"image/png"
+	"net"
+	"restaurant_management/helpers"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+	"restaurant_management/storage"
+	"restaurant_management/store"
+	"testing"
+	"time"
+)
+
+// dial serves the API over an in-memory store and connection.
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	service.UseStore(store.NewMemoryStore())
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// authenticated calls as a waiter holding permissions.
+func authenticated(t *testing.T, permissions ...string) context.Context {
+	t.Helper()
+
+	token, _, err := helpers.GenerateAllTokens("waiter@example.com", "Test", "Waiter", "uid", permissions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "token", token)
+}
+
+func TestAuthentication(t *testing.T) {
+	menus := pb.NewMenuServiceClient(dial(t))
+
+	_, err := menus.GetMenu(context.Background(), &pb.IdRequest{Id: "missing"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected a call without token to be rejected, got %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "not-a-token")
+	_, err = menus.GetMenu(ctx, &pb.IdRequest{Id: "missing"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected an invalid token to be rejected, got %v", err)
+	}
+
+	_, err = menus.GetMenu(authenticated(t), &pb.IdRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound || status.Convert(err).Message() != "Menu item not found" {
+		t.Fatalf("expected the menu not to be found, got %v", err)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	start := time.Now().Add(-time.Hour)
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:      "Lunch",
+		Category:  "main",
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foods := pb.NewFoodServiceClient(conn)
+	_, err = foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Ghost", Price: 1, FoodImage: "x", MenuId: "missing"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected a food of a missing menu to be rejected, got %v", err)
+	}
+
+	food, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Soup", Price: 4.567, FoodImage: "soup.png", MenuId: menu.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Soup" || got.Price != 4.57 || got.MenuId != menu.Id {
+		t.Fatalf("unexpected food %v", got)
+	}
+
+	tables := pb.NewTableServiceClient(conn)
+	table, err := tables.CreateTable(ctx, &pb.CreateTableRequest{NumberOfGuests: 4, TableNumber: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderItems := pb.NewOrderItemServiceClient(conn)
+	created, err := orderItems.CreateOrderItems(ctx, &pb.CreateOrderItemsRequest{
+		TableId: table.Id,
+		OrderItems: []*pb.NewOrderItem{
+			{FoodId: food.Id, Quantity: "M"},
+			{FoodId: food.Id, Quantity: "L"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Ids) != 2 {
+		t.Fatalf("expected 2 order items, got %v", created.Ids)
+	}
+
+	item, err := orderItems.GetOrderItem(ctx, &pb.IdRequest{Id: created.Ids[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := orderItems.OrderItemsByOrder(ctx, &pb.IdRequest{Id: item.OrderId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.PaymentDue != 9.14 || summary.TotalCount != 2 || summary.GetTableNumber() != 7 || len(summary.OrderItems) != 2 {
+		t.Fatalf("unexpected summary %v", summary)
+	}
+
+	if got, _ := tables.GetTable(ctx, &pb.IdRequest{Id: table.Id}); got.GetTableStatus() != "OCCUPIED" {
+		t.Fatalf("expected the table to be occupied, got %v", got)
+	}
+
+	paid, card := "PAID", "CARD"
+	invoices := pb.NewInvoiceServiceClient(conn)
+	invoice, err := invoices.CreateInvoice(ctx, &pb.CreateInvoiceRequest{OrderId: item.OrderId, PaymentMethod: &card, PaymentStatus: &paid})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view, err := invoices.GetInvoice(ctx, &pb.IdRequest{Id: invoice.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.PaymentDue != 9.14 || view.PaymentStatus != "PAID" || view.GetTableNumber() != 7 || len(view.OrderDetails) != 2 {
+		t.Fatalf("unexpected invoice %v", view)
+	}
+
+	order, err := pb.NewOrderServiceClient(conn).GetOrder(ctx, &pb.IdRequest{Id: item.OrderId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.OrderStatus != "PAID" {
+		t.Fatalf("expected the order to be paid, got %v", order)
+	}
+	if got, _ := tables.GetTable(ctx, &pb.IdRequest{Id: table.Id}); got.GetTableStatus() != "FREE" {
+		t.Fatalf("expected the table to be freed, got %v", got)
+	}
+}
+
+func TestModifiers(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	start := time.Now().Add(-time.Hour)
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:      "Dinner",
+		Category:  "main",
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foods := pb.NewFoodServiceClient(conn)
+	food, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{
+		Name: "Steak", Price: 10, FoodImage: "steak.png", MenuId: menu.Id,
+		ModifierGroups: []*pb.ModifierGroup{
+			{Name: "Cooking", Required: true, MaxSelections: 1, Options: []*pb.ModifierOption{{Name: "Rare"}, {Name: "Well done", PriceDelta: 0.5}}},
+			{Name: "Sauce", Options: []*pb.ModifierOption{{Name: "Pepper", PriceDelta: 1}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.ModifierGroups) != 2 || !got.ModifierGroups[0].Required || got.ModifierGroups[0].Options[0].OptionId == "" {
+		t.Fatalf("unexpected modifier groups %v", got.ModifierGroups)
+	}
+	cooking, sauce := got.ModifierGroups[0], got.ModifierGroups[1]
+	modifier := func(group *pb.ModifierGroup, option int) *pb.SelectedModifier {
+		return &pb.SelectedModifier{GroupId: group.GroupId, OptionId: group.Options[option].OptionId}
+	}
+
+	table, err := pb.NewTableServiceClient(conn).CreateTable(ctx, &pb.CreateTableRequest{NumberOfGuests: 2, TableNumber: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderItems := pb.NewOrderItemServiceClient(conn)
+	_, err = orderItems.CreateOrderItems(ctx, &pb.CreateOrderItemsRequest{
+		TableId:    table.Id,
+		OrderItems: []*pb.NewOrderItem{{FoodId: food.Id, Quantity: "M"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the cooking to be required, got %v", err)
+	}
+	created, err := orderItems.CreateOrderItems(ctx, &pb.CreateOrderItemsRequest{
+		TableId:    table.Id,
+		OrderItems: []*pb.NewOrderItem{{FoodId: food.Id, Quantity: "M", Modifiers: []*pb.SelectedModifier{modifier(cooking, 1), modifier(sauce, 0)}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := orderItems.GetOrderItem(ctx, &pb.IdRequest{Id: created.Ids[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.UnitPrice != 11.5 || len(item.Modifiers) != 2 || item.Modifiers[0].Name != "Well done" || item.Modifiers[1].GroupName != "Sauce" {
+		t.Fatalf("unexpected order item %v", item)
+	}
+
+	// the modifiers are replaced and the item priced again
+	_, err = orderItems.UpdateOrderItem(ctx, &pb.UpdateOrderItemRequest{OrderItemId: item.OrderItemId, Modifiers: []*pb.SelectedModifier{modifier(cooking, 0)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, err = orderItems.GetOrderItem(ctx, &pb.IdRequest{Id: item.OrderItemId}); err != nil {
+		t.Fatal(err)
+	}
+	if item.UnitPrice != 10 || len(item.Modifiers) != 1 || item.Modifiers[0].Name != "Rare" {
+		t.Fatalf("expected the modifiers to be replaced, got %v", item)
+	}
+	_, err = orderItems.UpdateOrderItem(ctx, &pb.UpdateOrderItemRequest{OrderItemId: item.OrderItemId, Modifiers: []*pb.SelectedModifier{modifier(sauce, 0)}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the cooking to be required, got %v", err)
+	}
+}
+
+func TestFoodDetails(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	start := time.Now().Add(-time.Hour)
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:      "Lunch",
+		Category:  "main",
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foods := pb.NewFoodServiceClient(conn)
+	_, err = foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Soup", Price: 4, FoodImage: "soup.png", MenuId: menu.Id, Allergens: &pb.StringList{Values: []string{"nuts"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected an unknown allergen to be rejected, got %v", err)
+	}
+
+	spiceLevel := int32(2)
+	food, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{
+		Name: "Curry", Price: 9, FoodImage: "curry.png", MenuId: menu.Id,
+		Description: "Chickpeas in a coconut sauce",
+		Category:    "main",
+		Tags:        []string{"vegan"},
+		Allergens:   &pb.StringList{Values: []string{"mustard"}},
+		SpiceLevel:  &spiceLevel,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Description != "Chickpeas in a coconut sauce" || got.Category != "main" || len(got.Tags) != 1 ||
+		len(got.Allergens.GetValues()) != 1 || got.Allergens.Values[0] != "mustard" || got.GetSpiceLevel() != 2 {
+		t.Fatalf("unexpected food %v", got)
+	}
+
+	// the allergens can be declared to be none, the fields left out are kept
+	_, err = foods.UpdateFood(ctx, &pb.UpdateFoodRequest{FoodId: food.Id, Allergens: &pb.StringList{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err = foods.GetFood(ctx, &pb.IdRequest{Id: food.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Allergens == nil || len(got.Allergens.Values) != 0 || len(got.Tags) != 1 || got.GetSpiceLevel() != 2 {
+		t.Fatalf("expected no allergens to be declared, got %v", got)
+	}
+
+	// undeclared allergens are left out
+	other, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Bread", Price: 2, FoodImage: "bread.png", MenuId: menu.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := foods.GetFood(ctx, &pb.IdRequest{Id: other.Id}); got.GetAllergens() != nil || got.SpiceLevel != nil {
+		t.Fatalf("expected the allergens not to be declared, got %v", got)
+	}
+}
+
+func TestPricing(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	start := time.Now().Add(-time.Hour)
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:      "Breakfast",
+		Category:  "drinks",
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foods := pb.NewFoodServiceClient(conn)
+	food, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{
+		Name: "Coffee", Price: 3, FoodImage: "coffee.png", MenuId: menu.Id,
+		SizePrices: map[string]float64{"S": 2.5, "L": 4.254},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.SizePrices) != 2 || got.SizePrices["L"] != 4.25 {
+		t.Fatalf("unexpected size prices %v", got.SizePrices)
+	}
+	_, err = foods.UpdateFood(ctx, &pb.UpdateFoodRequest{FoodId: food.Id, SizePrices: &pb.SizePrices{Prices: map[string]float64{"XL": 5}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected an unknown size to be rejected, got %v", err)
+	}
+
+	table, err := pb.NewTableServiceClient(conn).CreateTable(ctx, &pb.CreateTableRequest{NumberOfGuests: 2, TableNumber: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderItems := pb.NewOrderItemServiceClient(conn)
+	order := func(ctx context.Context, item *pb.NewOrderItem) (*pb.OrderItem, error) {
+		created, err := orderItems.CreateOrderItems(ctx, &pb.CreateOrderItemsRequest{TableId: table.Id, OrderItems: []*pb.NewOrderItem{item}})
+		if err != nil {
+			return nil, err
+		}
+		return orderItems.GetOrderItem(ctx, &pb.IdRequest{Id: created.Ids[0]})
+	}
+
+	// the size prices the item
+	item, err := order(ctx, &pb.NewOrderItem{FoodId: food.Id, Quantity: "S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.UnitPrice != 2.5 {
+		t.Fatalf("expected the small coffee to cost 2.5, got %v", item.UnitPrice)
+	}
+
+	// a zero unit price is an override, the waiter can't set it
+	free := 0.0
+	if _, err = order(ctx, &pb.NewOrderItem{FoodId: food.Id, Quantity: "M", UnitPrice: &free}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected a price override to be denied, got %v", err)
+	}
+	item, err = order(authenticated(t, helpers.PriceOverride), &pb.NewOrderItem{FoodId: food.Id, Quantity: "M", UnitPrice: &free})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.UnitPrice != 0 {
+		t.Fatalf("expected the coffee to be offered, got %v", item.UnitPrice)
+	}
+}
+
+func TestSoldOut(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	start := time.Now().Add(-time.Hour)
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:      "Lunch",
+		Category:  "main",
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foods := pb.NewFoodServiceClient(conn)
+	limit := int32(1)
+	special, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Special", Price: 12, FoodImage: "special.png", MenuId: menu.Id, DailyLimit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	soup, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{
+		Name: "Soup", Price: 4.5, FoodImage: "soup.png", MenuId: menu.Id,
+		SoldOut: true, SoldOutUntil: timestamppb.New(until),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: soup.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.SoldOut || !got.SoldOutUntil.AsTime().Equal(until) || got.DailyLimit != nil {
+		t.Fatalf("unexpected food %+v", got)
+	}
+
+	soldOut := func() []*pb.SoldOutFood {
+		t.Helper()
+		list, err := foods.ListSoldOutFoods(ctx, &emptypb.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return list.Foods
+	}
+	list := soldOut()
+	if len(list) != 2 || list[0].FoodId != soup.Id || !list[0].SoldOut || list[0].PortionsLeft != nil {
+		t.Fatalf("expected the soup sold out first, got %+v", list)
+	}
+	if list[1].FoodId != special.Id || list[1].SoldOut || list[1].GetDailyLimit() != 1 || list[1].GetPortionsLeft() != 1 {
+		t.Fatalf("expected a portion of the special left, got %+v", list[1])
+	}
+
+	// the last portion sells the special out
+	table, err := pb.NewTableServiceClient(conn).CreateTable(ctx, &pb.CreateTableRequest{NumberOfGuests: 2, TableNumber: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderItems := pb.NewOrderItemServiceClient(conn)
+	order := &pb.CreateOrderItemsRequest{TableId: table.Id, OrderItems: []*pb.NewOrderItem{{FoodId: special.Id, Quantity: "M"}}}
+	if _, err := orderItems.CreateOrderItems(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orderItems.CreateOrderItems(ctx, order); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the special to be sold out, got %v", err)
+	}
+	if list := soldOut(); len(list) != 2 || !list[1].SoldOut || list[1].GetPortionsLeft() != 0 {
+		t.Fatalf("expected no portion of the special left, got %+v", list)
+	}
+
+	// putting the soup back on sale and removing the limit empties the list
+	onSale, noLimit := false, int32(0)
+	if _, err := foods.UpdateFood(ctx, &pb.UpdateFoodRequest{FoodId: soup.Id, SoldOut: &onSale}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := foods.UpdateFood(ctx, &pb.UpdateFoodRequest{FoodId: special.Id, DailyLimit: &noLimit}); err != nil {
+		t.Fatal(err)
+	}
+	if list := soldOut(); len(list) != 0 {
+		t.Fatalf("expected nothing sold out, got %+v", list)
+	}
+	got, err = foods.GetFood(ctx, &pb.IdRequest{Id: soup.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SoldOut || got.SoldOutUntil != nil {
+		t.Fatalf("expected the soup on sale, got %+v", got)
+	}
+
+	// a sold out period needs the food sold out
+	if _, err := foods.UpdateFood(ctx, &pb.UpdateFoodRequest{FoodId: soup.Id, SoldOut: &onSale, SoldOutUntil: timestamppb.New(until)}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the sold out period to be rejected, got %v", err)
+	}
+}
+
+func TestMenuAvailability(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+	menus := pb.NewMenuServiceClient(conn)
+
+	allWeek := []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+	lunch, err := menus.CreateMenu(ctx, &pb.CreateMenuRequest{
+		Name:     "Lunch",
+		Category: "main",
+		Availability: &pb.Availability{
+			TimeZone:   "Europe/Paris",
+			Rules:      []*pb.AvailabilityRule{{Days: allWeek, Start: "11:30", End: "14:30"}},
+			Exceptions: []*pb.AvailabilityException{{Date: "2026-12-25", Name: "Christmas"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := menus.CreateMenu(ctx, &pb.CreateMenuRequest{Name: "Drinks", Category: "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := menus.GetMenu(ctx, &pb.IdRequest{Id: lunch.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	availability := got.Availability
+	if availability.GetTimeZone() != "Europe/Paris" || len(availability.Rules) != 1 || len(availability.Rules[0].Days) != 7 ||
+		availability.Rules[0].End != "14:30" || len(availability.Exceptions) != 1 || availability.Exceptions[0].Name != "Christmas" {
+		t.Fatalf("unexpected availability %+v", availability)
+	}
+
+	active := func(at string) []string {
+		t.Helper()
+		when, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list, err := menus.ListActiveMenus(ctx, &pb.ActiveMenusRequest{At: timestamppb.New(when)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, menu := range list.Menus {
+			names = append(names, menu.Name)
+		}
+		return names
+	}
+	for _, test := range []struct {
+		at       string
+		expected int
+	}{
+		{"2026-12-24T12:00:00+01:00", 2},
+		{"2026-12-24T18:00:00+01:00", 1},
+		{"2026-12-25T12:00:00+01:00", 1},
+	} {
+		if names := active(test.at); len(names) != test.expected {
+			t.Errorf("%s: expected %d active menus, got %v", test.at, test.expected, names)
+		}
+	}
+	if list, err := menus.ListActiveMenus(ctx, &pb.ActiveMenusRequest{}); err != nil || len(list.Menus) == 0 {
+		t.Fatalf("expected the drinks to be active now, got %v, %v", list, err)
+	}
+
+	// the availability updated replaces the one of the menu
+	_, err = menus.UpdateMenu(ctx, &pb.UpdateMenuRequest{MenuId: lunch.Id, Availability: &pb.Availability{
+		Rules: []*pb.AvailabilityRule{{Days: []string{"SAT", "SUN"}, Start: "18:00", End: "23:00"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := active("2026-12-26T19:00:00Z"); len(names) != 2 {
+		t.Fatalf("expected the lunch to open on saturday evenings, got %v", names)
+	}
+	if names := active("2026-12-24T12:00:00+01:00"); len(names) != 1 {
+		t.Fatalf("expected the lunch to be closed on thursdays, got %v", names)
+	}
+
+	_, err = menus.UpdateMenu(ctx, &pb.UpdateMenuRequest{MenuId: lunch.Id, Availability: &pb.Availability{
+		Rules: []*pb.AvailabilityRule{{Days: []string{"NOON"}, Start: "11:30", End: "14:30"}},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected an unknown day to be rejected, got %v", err)
+	}
+}
+
+func TestFoodThumbnail(t *testing.T) {
+	conn := dial(t)
+	ctx := authenticated(t)
+
+	images, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.UseImages(images, service.ImageOptions{MaxBytes: 1 << 20, MaxPixels: 1 << 20, ThumbnailSize: 8})
+	t.Cleanup(func() { service.UseImages(nil, service.ImageOptions{}) })
+
+	menu, err := pb.NewMenuServiceClient(conn).CreateMenu(ctx, &pb.CreateMenuRequest{Name: "Lunch", Category: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	foods := pb.NewFoodServiceClient(conn)
+	food, err := foods.CreateFood(ctx, &pb.CreateFoodRequest{Name: "Soup", Price: 4, FoodImage: "soup.png", MenuId: menu.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id}); err != nil || got.FoodThumbnail != "" {
+		t.Fatalf("expected no thumbnail before an upload, got %v, %v", got, err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 32, 32))); err != nil {
+		t.Fatal(err)
+	}
+	uploaded, err := service.SetFoodImage(context.Background(), food.Id, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := foods.GetFood(ctx, &pb.IdRequest{Id: food.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.FoodImage != uploaded.Image || got.FoodThumbnail != uploaded.Thumbnail || got.FoodThumbnail == "" {
+		t.Fatalf("expected the image uploaded and its thumbnail, got %v", got)
+	}
+}
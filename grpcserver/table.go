@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"restaurant_management/models"
+	"restaurant_management/pb"
+	"restaurant_management/service"
+)
+
+type tableServer struct {
+	pb.UnimplementedTableServiceServer
+}
+
+func (tableServer) ListTables(ctx context.Context, _ *emptypb.Empty) (*pb.ListTablesResponse, error) {
+	docs, err := service.ListTables(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	tables, err := decode[models.Table](docs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListTablesResponse{}
+	for _, table := range tables {
+		resp.Tables = append(resp.Tables, tableMessage(table))
+	}
+	return resp, nil
+}
+
+func (tableServer) GetTable(ctx context.Context, req *pb.IdRequest) (*pb.Table, error) {
+	table, err := service.GetTable(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return tableMessage(table), nil
+}
+
+func (tableServer) CreateTable(ctx context.Context, req *pb.CreateTableRequest) (*pb.CreateResponse, error) {
+	numberOfGuests, tableNumber := int(req.NumberOfGuests), int(req.TableNumber)
+
+	result, err := service.CreateTable(ctx, models.Table{
+		Number_of_guests: &numberOfGuests,
+		Table_number:     &tableNumber,
+		Table_status:     req.TableStatus,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return createResponse(result), nil
+}
+
+func (tableServer) UpdateTable(ctx context.Context, req *pb.UpdateTableRequest) (*pb.UpdateResponse, error) {
+	result, err := service.UpdateTable(ctx, req.TableId, models.Table{
+		Number_of_guests: intOf(req.NumberOfGuests),
+		Table_number:     intOf(req.TableNumber),
+		Table_status:     req.TableStatus,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return updateResponse(result), nil
+}
+
+func (tableServer) DeleteTable(ctx context.Context, req *pb.IdRequest) (*pb.DeleteResponse, error) {
+	result, err := service.DeleteTable(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deleteResponse(result), nil
+}
+
+func tableMessage(table models.Table) *pb.Table {
+	return &pb.Table{
+		TableId:        table.Table_id,
+		NumberOfGuests: int32(value(table.Number_of_guests)),
+		TableNumber:    int32(value(table.Table_number)),
+		TableStatus:    value(table.Table_status),
+		CreatedAt:      timestamp(table.Created_at),
+		UpdatedAt:      timestamp(table.Updated_at),
+	}
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import "context"
+
+// PriceOverride allows setting the unit price of order items instead of
+// letting the API compute it from the food.
+const PriceOverride = "price_override"
+
+// ManageWebhooks allows registering the webhooks and reading their
+// deliveries. The API sends requests to their URLs and shows the answers.
+const ManageWebhooks = "manage_webhooks"
+
+type permissionsKey struct{}
+
+// WithPermissions returns a copy of ctx carrying the permissions of the
+// authenticated caller.
+func WithPermissions(ctx context.Context, permissions []string) context.Context {
+	return context.WithValue(ctx, permissionsKey{}, permissions)
+}
+
+// HasPermission reports whether the caller of ctx holds permission.
+func HasPermission(ctx context.Context, permission string) bool {
+	permissions, _ := ctx.Value(permissionsKey{}).([]string)
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+)
+
+var base = zap.NewNop()
+
+// redactedKeys are the fields whose value never reaches the logs.
+var redactedKeys = map[string]bool{
+	"token":         true,
+	"refresh_token": true,
+	"password":      true,
+	"authorization": true,
+	"secret":        true,
+}
+
+// Setup builds the process logger. Level is a zap level name, format is
+// "json" or "console".
+func Setup(level, format string) (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	if format == "console" {
+		cfg = zap.NewDevelopmentConfig()
+	}
+
+	if err := cfg.Level.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+
+	l, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return redactCore{core}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	base = l
+	return l, nil
+}
+
+// L returns the process logger, for code that runs outside of a request.
+func L() *zap.Logger {
+	return base
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the request logger carried by ctx, or the process
+// logger when there is none.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return base
+}
+
+// redactCore replaces the value of the sensitive fields before they are
+// encoded.
+type redactCore struct {
+	zapcore.Core
+}
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redact(fields))}
+}
+
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redact(fields))
+}
+
+// redact copies fields only when one of them has to be redacted.
+func redact(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		if !redactedKeys[strings.ToLower(field.Key)] {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields...)
+		}
+		redacted[i] = zap.String(field.Key, "[REDACTED]")
+	}
+
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// RedactQuery hides the values of the sensitive query parameters of a raw
+// query string.
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, found := strings.Cut(param, "=")
+		if found && redactedKeys[strings.ToLower(key)] {
+			params[i] = key + "=[REDACTED]"
+		}
+	}
+	return strings.Join(params, "&")
+}
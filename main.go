@@ -1,30 +1,220 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"restaurant_management/cache"
+	"restaurant_management/config"
+	"restaurant_management/controllers"
+	"restaurant_management/docs"
+	"restaurant_management/events"
+	"restaurant_management/graph"
+	"restaurant_management/grpcserver"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
 	"restaurant_management/middleware"
 	"restaurant_management/routes"
+	"restaurant_management/service"
+	"restaurant_management/storage"
+	"restaurant_management/store"
+	"restaurant_management/stream"
+	"restaurant_management/tracing"
+	"restaurant_management/webhook"
+	"syscall"
+	"time"
 )
 
 func main() {
-	port := os.Getenv("PORT")
+	cfg := config.Load()
 
-	if port == "" {
-		port = "8080"
+	log, err := logger.Setup(cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		panic(err)
 	}
+	defer log.Sync()
 
-	router := gin.New()
-	router.Use(gin.Logger())
-	routes.UserRoutes(router)
-	router.Use(middleware.Authentication())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	routes.FoodRoutes(router)
-	routes.MenuRoutes(router)
-	routes.TableRoutes(router)
-	routes.OrderItemRoutes(router)
-	routes.OrderRoutes(router)
-	routes.InvoiceRoutes(router)
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing.Exporter, cfg.Tracing.SampleRatio)
+	if err != nil {
+		log.Fatal("tracing setup failed", zap.Error(err))
+	}
+
+	dataStore, err := store.Open(ctx, cfg.Database)
+	if err != nil {
+		log.Fatal("store setup failed", zap.Error(err))
+	}
+	if cfg.Cache.Enabled {
+		dataStore = store.NewCachedStore(dataStore, cache.NewLRU(cfg.Cache.Size), cfg.Cache.TTL)
+	}
+	controllers.UseStore(dataStore)
+	metrics.RegisterOpenTables(service.OpenTables)
+
+	images, err := storage.NewLocal(cfg.Images.Dir)
+	if err != nil {
+		log.Fatal("image storage setup failed", zap.Error(err))
+	}
+	service.UseImages(images, service.ImageOptions{
+		MaxBytes:      int64(cfg.Images.MaxBytes),
+		MaxPixels:     cfg.Images.MaxPixels,
+		ThumbnailSize: cfg.Images.ThumbnailSize,
+	})
+
+	// the domain events are written to the outbox by the service layer, the
+	// relay publishes them to the subscribers and transports of the bus
+	bus := events.NewBus()
+	if cfg.Webhooks.Enabled {
+		dispatcher := webhook.NewDispatcher(dataStore, webhook.Options{
+			MaxAttempts:          cfg.Webhooks.MaxAttempts,
+			Backoff:              cfg.Webhooks.Backoff,
+			MaxBackoff:           cfg.Webhooks.MaxBackoff,
+			Timeout:              cfg.Webhooks.Timeout,
+			PollInterval:         cfg.Webhooks.PollInterval,
+			AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+		})
+		bus.AddTransport(dispatcher)
+		service.UseWebhooks(dispatcher)
+		go dispatcher.Run(ctx)
+	}
+	var hub *stream.Hub
+	if cfg.Stream.Enabled {
+		hub = stream.NewHub()
+		bus.AddTransport(hub)
+	}
+	relay := events.NewRelay(dataStore, bus, events.Options{
+		MaxAttempts:  cfg.Events.MaxAttempts,
+		Backoff:      cfg.Events.Backoff,
+		MaxBackoff:   cfg.Events.MaxBackoff,
+		PollInterval: cfg.Events.PollInterval,
+	})
+	go relay.Run(ctx)
+	go service.RunFoodRestorer(ctx, cfg.Foods.RestoreInterval)
+
+	opts := routes.RouterOptions{
+		GraphQLLimits: graph.Limits{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+		},
+		Stream:          hub,
+		StreamHeartbeat: cfg.Stream.Heartbeat,
+		Deprecation: middleware.Deprecation{
+			At:     cfg.Versions.DeprecatedAt,
+			Sunset: cfg.Versions.Sunset,
+		},
+	}
+	if cfg.RateLimit.Enabled {
+		opts.RateLimiter = rateLimiter(cfg.RateLimit)
+	}
+	if cfg.OpenAPIValidation {
+		opts.OpenAPIValidation = openAPIValidator(ctx)
+	}
+	router := routes.NewRouter(opts)
+
+	server := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+	go func() {
+		log.Info("listening", zap.String("addr", server.Addr))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("server failed", zap.Error(err))
+		}
+	}()
+
+	metricsServer := &http.Server{Addr: ":" + cfg.MetricsPort, Handler: routes.NewMetricsRouter()}
+	go func() {
+		log.Info("metrics listening", zap.String("addr", metricsServer.Addr))
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("metrics server failed", zap.Error(err))
+		}
+	}()
+
+	grpcServer := grpcserver.NewServer()
+	go func() {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatal("gRPC listen failed", zap.Error(err))
+		}
+		log.Info("gRPC listening", zap.String("addr", listener.Addr().String()))
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("gRPC server failed", zap.Error(err))
+		}
+	}()
+
+	<-ctx.Done()
 
-	router.Run(":" + port)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	log.Info("shutting down")
+	if hub != nil {
+		// streams never end on their own, they would hold the shutdown
+		hub.Close()
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("server shutdown failed", zap.Error(err))
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("metrics server shutdown failed", zap.Error(err))
+	}
+	grpcServer.GracefulStop()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error("tracing shutdown failed", zap.Error(err))
+	}
+}
+
+// rateLimiter throttles logins and signups the hardest, then the list
+// endpoints that return whole collections, then everything else.
+func rateLimiter(cfg config.RateLimit) gin.HandlerFunc {
+	defaultLimit := mustParseLimit(cfg.Default)
+
+	return middleware.RateLimit(middleware.NewMemoryRateLimitStore(), middleware.StaticAPIKeys(cfg.APIKeys), defaultLimit,
+		middleware.RateLimitRule{
+			Group:  "auth",
+			Routes: []string{"POST /users/login", "POST /users/signup"},
+			Limit:  mustParseLimit(cfg.Auth),
+		},
+		middleware.RateLimitRule{
+			Group: "lists",
+			Routes: []string{
+				"GET /users",
+				"GET /foods",
+				"GET /foods/sold-out",
+				"GET /menus",
+				"GET /menus/active",
+				"GET /search",
+				"GET /tables",
+				"GET /orders",
+				"GET /orderItems",
+				"GET /order-items",
+				"GET /invoices",
+			},
+			Limit: mustParseLimit(cfg.Lists),
+		},
+	)
+}
+
+func openAPIValidator(ctx context.Context) gin.HandlerFunc {
+	doc, err := docs.Load(ctx)
+	if err != nil {
+		logger.L().Fatal("invalid OpenAPI document", zap.Error(err))
+	}
+
+	validator, err := middleware.OpenAPIValidation(doc)
+	if err != nil {
+		logger.L().Fatal("OpenAPI validation setup failed", zap.Error(err))
+	}
+	return validator
+}
+
+func mustParseLimit(value string) middleware.Limit {
+	limit, err := middleware.ParseLimit(value)
+	if err != nil {
+		logger.L().Fatal("invalid rate limit", zap.Error(err))
+	}
+	return limit
 }
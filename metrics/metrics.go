@@ -0,0 +1,245 @@
+package metrics
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const namespace = "restaurant"
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	requestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_errors_total",
+		Help:      "HTTP responses with a 4xx or 5xx status code.",
+	}, []string{"route", "code"})
+
+	mongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Duration of the Mongo commands by collection.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"collection", "command", "outcome"})
+
+	postgresDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "postgres_query_duration_seconds",
+		Help:      "Duration of the PostgreSQL queries by statement.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"statement", "outcome"})
+
+	ordersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_created_total",
+		Help:      "Orders created.",
+	})
+
+	itemsSold = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "items_sold_total",
+		Help:      "Order items sold by food.",
+	}, []string{"food_id"})
+
+	invoicesPaid = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invoices_paid_total",
+		Help:      "Invoices that became PAID.",
+	})
+
+	revenue = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "revenue_total",
+		Help:      "Amount of the paid invoices.",
+	})
+
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Reads of the menu and food cache by result.",
+	}, []string{"namespace", "result"})
+
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_attempts_total",
+		Help:      "Webhook delivery attempts by event and outcome: succeeded, retried or failed.",
+	}, []string{"event", "outcome"})
+
+	streamClients = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_clients",
+		Help:      "Clients connected to /stream by transport: sse or websocket.",
+	}, []string{"transport"})
+
+	eventPublications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_publications_total",
+		Help:      "Domain event publications by type and outcome: published, retried or failed.",
+	}, []string{"type", "outcome"})
+)
+
+// methods are the HTTP methods labelled as they are, the others being
+// labelled OTHER so that clients can't create new series.
+var methods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// ObserveRequest records a served HTTP request. Route is the gin route
+// template so that IDs in the path don't create new series.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	if !methods[method] {
+		method = "OTHER"
+	}
+
+	code := strconv.Itoa(status)
+	requestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+	if status >= 400 {
+		requestErrors.WithLabelValues(route, code).Inc()
+	}
+}
+
+func OrderCreated() {
+	ordersCreated.Inc()
+}
+
+func ItemSold(foodId string) {
+	itemsSold.WithLabelValues(foodId).Inc()
+}
+
+func InvoicePaid(amount float64) {
+	invoicesPaid.Inc()
+	revenue.Add(amount)
+}
+
+// RegisterOpenTables exposes the number of occupied tables, counted by count
+// on every scrape.
+func RegisterOpenTables(count func(ctx context.Context) (int, error)) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "open_tables",
+		Help:      "Tables currently occupied.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		n, err := count(ctx)
+		if err != nil {
+			return math.NaN()
+		}
+		return float64(n)
+	})
+}
+
+// MongoMonitor times every command sent to Mongo. The collection is only
+// known when the command starts, so it is kept until the command ends.
+func MongoMonitor() *event.CommandMonitor {
+	var collections sync.Map
+
+	finished := func(requestID int64, command, outcome string, duration time.Duration) {
+		collection, ok := collections.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		mongoDuration.WithLabelValues(collection.(string), command, outcome).Observe(duration.Seconds())
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			collection, ok := evt.Command.Index(0).Value().StringValueOK()
+			if !ok {
+				collection, ok = evt.Command.Lookup("collection").StringValueOK()
+			}
+			if !ok {
+				collection = evt.DatabaseName
+			}
+			collections.Store(evt.RequestID, collection)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			finished(evt.RequestID, evt.CommandName, "success", evt.Duration)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			finished(evt.RequestID, evt.CommandName, "failure", evt.Duration)
+		},
+	}
+}
+
+// CacheLookup records a read of the cache of namespace.
+func CacheLookup(namespace string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(namespace, result).Inc()
+}
+
+// WebhookDelivery counts an attempt to deliver event, outcome being
+// succeeded, retried or failed.
+func WebhookDelivery(event, outcome string) {
+	webhookDeliveries.WithLabelValues(event, outcome).Inc()
+}
+
+// EventPublished counts a publication of a domain event of eventType,
+// outcome being published, retried or failed.
+func EventPublished(eventType, outcome string) {
+	eventPublications.WithLabelValues(eventType, outcome).Inc()
+}
+
+// StreamConnected counts a client connecting to /stream over transport.
+func StreamConnected(transport string) {
+	streamClients.WithLabelValues(transport).Inc()
+}
+
+// StreamDisconnected counts a client of /stream going away.
+func StreamDisconnected(transport string) {
+	streamClients.WithLabelValues(transport).Dec()
+}
+
+// PostgresTracer times every query sent to PostgreSQL, by the statement it
+// starts with: SELECT, INSERT, UPDATE...
+type PostgresTracer struct{}
+
+type postgresQueryKey struct{}
+
+type postgresQuery struct {
+	statement string
+	start     time.Time
+}
+
+func (PostgresTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	statement := "OTHER"
+	if fields := strings.Fields(data.SQL); len(fields) > 0 {
+		statement = strings.ToUpper(fields[0])
+	}
+	return context.WithValue(ctx, postgresQueryKey{}, postgresQuery{statement, time.Now()})
+}
+
+func (PostgresTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(postgresQueryKey{}).(postgresQuery)
+	if !ok {
+		return
+	}
+	outcome := "success"
+	if data.Err != nil {
+		outcome = "failure"
+	}
+	postgresDuration.WithLabelValues(query.statement, outcome).Observe(time.Since(query.start).Seconds())
+}
@@ -3,20 +3,27 @@ package middleware
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
 	"net/http"
 	"restaurant_management/helpers"
+	"restaurant_management/logger"
+	"strings"
 )
 
 func Authentication() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clientToken := c.Request.Header.Get("token")
+		if clientToken == "" && streaming(c.Request) {
+			clientToken = c.Query("token")
+		}
 		if clientToken == "" {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("No Authorization header provided")})
 			c.Abort()
 			return
 		}
 
-		claims, err := helpers.ValidateToken(clientToken)
+		claims, err := validateToken(c, clientToken)
 		if err != "" {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err})
 			c.Abort()
@@ -28,6 +35,40 @@ func Authentication() gin.HandlerFunc {
 		c.Set("last_name", claims.Last_name)
 		c.Set("uid", claims.Uid)
 
+		ctx := c.Request.Context()
+		ctx = helpers.WithPermissions(ctx, claims.Permissions)
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, logger.FromContext(ctx).With(zap.String("uid", claims.Uid))))
+
 		c.Next()
 	}
 }
+
+// validatedToken is the outcome of validating the token of a request.
+type validatedToken struct {
+	token  string
+	claims *helpers.SignedDetails
+	msg    string
+}
+
+// validatedTokenKey keeps the validatedToken in the gin context.
+const validatedTokenKey = "validated_token"
+
+// validateToken validates token once per request: the rate limiter, running
+// before Authentication, and Authentication share the outcome.
+func validateToken(c *gin.Context, token string) (*helpers.SignedDetails, string) {
+	if v, ok := c.Get(validatedTokenKey); ok {
+		if validated := v.(validatedToken); validated.token == token {
+			return validated.claims, validated.msg
+		}
+	}
+
+	claims, msg := helpers.ValidateToken(token)
+	c.Set(validatedTokenKey, validatedToken{token, claims, msg})
+	return claims, msg
+}
+
+// streaming reports whether r opens a WebSocket or an event stream. Browsers
+// can't set headers on those, so they send the token as a query parameter.
+func streaming(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r) || strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
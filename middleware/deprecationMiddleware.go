@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecation dates the deprecation of routes, which are removed at Sunset.
+// Zero dates are not announced.
+type Deprecation struct {
+	At     time.Time
+	Sunset time.Time
+}
+
+// Deprecated tells the clients of the routes it guards that they should move
+// to the route whose path successor returns: the Deprecation (RFC 9745) and
+// Sunset (RFC 8594) headers date the deprecation and the removal, a Link
+// header points to the successor.
+func Deprecated(d Deprecation, successor func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !d.At.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(d.At.Unix(), 10))
+		}
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if path := successor(c); path != "" {
+			c.Header("Link", "<"+path+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// Successor returns the path of the route template, e.g.
+// "/v2/orders/:id/items", with the parameters of the request.
+func Successor(template string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		path := template
+		for _, param := range c.Params {
+			path = strings.Replace(path, ":"+param.Key, url.PathEscape(param.Value), 1)
+		}
+		return path
+	}
+}
+
+var versionPrefix = regexp.MustCompile(`^/v[0-9]+(/|$)`)
+
+// unversioned returns route without its API version, "/v1/foods/:id" being
+// "/foods/:id".
+func unversioned(route string) string {
+	if prefix := versionPrefix.FindString(route); prefix != "" {
+		return "/" + strings.TrimPrefix(route, prefix)
+	}
+	return route
+}
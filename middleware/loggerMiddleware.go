@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"restaurant_management/logger"
+	"time"
+)
+
+// Logger gives every request a logger carrying its request ID and route,
+// reachable with logger.FromContext, and logs the request once served.
+// It has to run after RequestID.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		l := logger.L().With(
+			zap.String("request_id", c.GetString("request_id")),
+			zap.String("method", c.Request.Method),
+			zap.String("route", c.FullPath()),
+		)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), l))
+
+		c.Next()
+
+		fields := []zap.Field{
+			zap.String("path", c.Request.URL.Path),
+			zap.String("query", logger.RedactQuery(c.Request.URL.RawQuery)),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.Int("size", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		// Authentication adds the uid to the request logger
+		l = logger.FromContext(c.Request.Context())
+		switch status := c.Writer.Status(); {
+		case status >= 500:
+			l.Error("request served", fields...)
+		case status >= 400:
+			l.Warn("request served", fields...)
+		default:
+			l.Info("request served", fields...)
+		}
+	}
+}
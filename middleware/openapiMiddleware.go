@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/logger"
+)
+
+// OpenAPIValidation checks the requests and responses of the routes described
+// by doc. Invalid requests are rejected with a 400 before reaching the
+// handler, invalid responses are sent anyway and logged as errors. Routes
+// missing from doc are not checked. Meant for development, responses are
+// copied in memory.
+func OpenAPIValidation(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		// the Authentication middleware checks the tokens
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		// streams never end, their responses can't be checked
+		if streaming(c.Request) {
+			c.Next()
+			return
+		}
+
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(c, requestInput); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		writer := &teeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 writer.Status(),
+			Header:                 writer.Header(),
+			Options:                options,
+		}
+		responseInput.SetBodyBytes(writer.body.Bytes())
+		if err := openapi3filter.ValidateResponse(c, responseInput); err != nil {
+			logger.FromContext(c).Error("response does not match the OpenAPI document",
+				zap.String("route", c.FullPath()),
+				zap.Int("status", writer.Status()),
+				zap.Error(err),
+			)
+		}
+	}, nil
+}
+
+// teeWriter keeps a copy of the response body while it is written.
+type teeWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *teeWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,232 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit allows Requests requests per Per, refilled continuously. Bursts up to
+// Requests are allowed.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseLimit parses limits written as "<requests>/<duration>", e.g. "10/1m".
+func ParseLimit(value string) (Limit, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected <requests>/<duration>", value)
+	}
+
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests < 1 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, requests must be a positive number", value)
+	}
+
+	per, err := time.ParseDuration(parts[1])
+	if err != nil || per <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, duration must be positive", value)
+	}
+
+	return Limit{Requests: requests, Per: per}, nil
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// RateLimitResult is the state of a bucket after a token was taken from it.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the token buckets. The in-process store only limits a
+// single instance, a shared store lets several instances share the buckets.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit Limit) (RateLimitResult, error)
+}
+
+// RateLimitRule applies Limit to the routes of a group. Routes are gin route
+// templates, optionally prefixed by a method, e.g. "POST /users/login" or
+// "/orderItems". They match the route under every API version, "/foods"
+// matching "/v1/foods" as well.
+type RateLimitRule struct {
+	Group  string
+	Routes []string
+	Limit  Limit
+}
+
+func (r RateLimitRule) matches(method, route string) bool {
+	route = unversioned(route)
+	for _, candidate := range r.Routes {
+		if candidate == route || candidate == method+" "+route {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyVerifier reports whether key is an API key of a client.
+type APIKeyVerifier func(key string) bool
+
+// StaticAPIKeys verifies the API keys against keys, nil when there are none.
+func StaticAPIKeys(keys []string) APIKeyVerifier {
+	if len(keys) == 0 {
+		return nil
+	}
+	return func(key string) bool {
+		valid := false
+		for _, candidate := range keys {
+			// every key is compared, so the time taken tells nothing
+			if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+				valid = true
+			}
+		}
+		return valid
+	}
+}
+
+// RateLimit throttles requests with token buckets kept in store. The first
+// rule matching the route decides the limit, requests matching no rule use
+// defaultLimit. Buckets are kept per group and per client, see RateLimitKey,
+// the API keys being checked with verifyKey.
+func RateLimit(store RateLimitStore, verifyKey APIKeyVerifier, defaultLimit Limit, rules ...RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		group, limit := "default", defaultLimit
+		for _, rule := range rules {
+			if rule.matches(c.Request.Method, c.FullPath()) {
+				group, limit = rule.Group, rule.Limit
+				break
+			}
+		}
+
+		result, err := store.Take(c, group+":"+RateLimitKey(c, verifyKey), limit)
+		if err != nil {
+			// never reject traffic because the limiter itself is down
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, retry later"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitKey identifies the client of a request: the authenticated user
+// when there is one, then the API key verifyKey accepts, then the client IP.
+// Unverified keys are ignored, a client sending a new one with every request
+// would get a new bucket every time.
+func RateLimitKey(c *gin.Context, verifyKey APIKeyVerifier) string {
+	if uid := c.GetString("uid"); uid != "" {
+		return "uid:" + uid
+	}
+
+	// the limiter may run before Authentication, so look at the token too,
+	// Authentication reusing its claims
+	if token := c.Request.Header.Get("token"); token != "" {
+		if claims, msg := validateToken(c, token); msg == "" && claims.Uid != "" {
+			return "uid:" + claims.Uid
+		}
+	}
+
+	if apiKey := c.Request.Header.Get("X-API-Key"); apiKey != "" && verifyKey != nil && verifyKey(apiKey) {
+		return "key:" + apiKey
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+// MemoryRateLimitStore keeps the buckets in process. Buckets that filled up
+// again are dropped periodically.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit Limit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	rate := limit.rate()
+	capacity := float64(limit.Requests)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	var result RateLimitResult
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) / rate * float64(time.Second))
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
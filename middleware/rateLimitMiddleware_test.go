@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/helpers"
+	"testing"
+	"time"
+)
+
+// limitedRouter serves /foods and POST /users/login, the latter in the auth
+// group, over store.
+func limitedRouter(store RateLimitStore, verifyKey APIKeyVerifier, limit Limit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(store, verifyKey, limit, RateLimitRule{
+		Group:  "auth",
+		Routes: []string{"POST /users/login"},
+		Limit:  Limit{Requests: 1, Per: time.Minute},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/foods", ok)
+	router.GET("/v1/foods", ok)
+	router.POST("/users/login", ok)
+	return router
+}
+
+// fakeClock drives the time of a store.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newClockedStore() (*MemoryRateLimitStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return clock.now }
+	return store, clock
+}
+
+func serve(router *gin.Engine, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestParseLimit(t *testing.T) {
+	for value, expected := range map[string]Limit{
+		"10/1m":  {Requests: 10, Per: time.Minute},
+		"300/1s": {Requests: 300, Per: time.Second},
+	} {
+		if limit, err := ParseLimit(value); err != nil || limit != expected {
+			t.Errorf("%q: expected %+v, got %+v, %v", value, expected, limit, err)
+		}
+	}
+	for _, value := range []string{"", "10", "ten/1m", "0/1m", "10/forever", "10/-1m"} {
+		if _, err := ParseLimit(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	store, clock := newClockedStore()
+	router := limitedRouter(store, nil, Limit{Requests: 2, Per: 2 * time.Second})
+
+	expect := func(status int, limit, remaining, reset, retryAfter string) {
+		t.Helper()
+		w := serve(router, "GET", "/foods", nil)
+		header := w.Header()
+		if w.Code != status || header.Get("RateLimit-Limit") != limit || header.Get("RateLimit-Remaining") != remaining ||
+			header.Get("RateLimit-Reset") != reset || header.Get("Retry-After") != retryAfter {
+			t.Fatalf("expected %d with %s/%s reset %s retry %q, got %d with %v", status, limit, remaining, reset, retryAfter, w.Code, header)
+		}
+		if status == http.StatusTooManyRequests {
+			var body struct{ Error string }
+			if json.Unmarshal(w.Body.Bytes(), &body) != nil || body.Error == "" {
+				t.Fatalf("expected an error, got %s", w.Body.String())
+			}
+		}
+	}
+
+	// bursts up to the limit are allowed
+	expect(http.StatusOK, "2", "1", "1", "")
+	expect(http.StatusOK, "2", "0", "2", "")
+	expect(http.StatusTooManyRequests, "2", "0", "2", "1")
+
+	// the tokens come back continuously, one a second
+	clock.advance(500 * time.Millisecond)
+	expect(http.StatusTooManyRequests, "2", "0", "2", "1")
+	clock.advance(500 * time.Millisecond)
+	expect(http.StatusOK, "2", "0", "2", "")
+	clock.advance(time.Minute)
+	expect(http.StatusOK, "2", "1", "1", "")
+
+	// the groups have buckets of their own, shared by the versions
+	if w := serve(router, "POST", "/users/login", nil); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "1" {
+		t.Fatalf("expected the auth limit, got %d with %v", w.Code, w.Header())
+	}
+	if w := serve(router, "POST", "/users/login", nil); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the logins to be limited, got %d", w.Code)
+	}
+	if w := serve(router, "GET", "/v1/foods", nil); w.Code != http.StatusOK || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("expected /v1/foods to share the bucket of /foods, got %d with %v", w.Code, w.Header())
+	}
+}
+
+func TestRateLimitAPIKeys(t *testing.T) {
+	store, _ := newClockedStore()
+	router := limitedRouter(store, StaticAPIKeys([]string{"a-key-of-a-partner"}), Limit{Requests: 1, Per: time.Minute})
+	withKey := func(key string) http.Header { return http.Header{"X-Api-Key": {key}} }
+
+	if w := serve(router, "GET", "/foods", withKey("random-1")); w.Code != http.StatusOK {
+		t.Fatalf("expected the first request to be allowed, got %d", w.Code)
+	}
+	// unknown keys are limited by the IP of the client
+	for _, key := range []string{"random-2", "random-3", ""} {
+		if w := serve(router, "GET", "/foods", withKey(key)); w.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected key %q to share the bucket of the IP, got %d", key, w.Code)
+		}
+	}
+	if w := serve(router, "GET", "/foods", withKey("a-key-of-a-partner")); w.Code != http.StatusOK {
+		t.Fatalf("expected a verified key to have a bucket of its own, got %d", w.Code)
+	}
+	if w := serve(router, "GET", "/foods", withKey("a-key-of-a-partner")); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the verified key to be limited, got %d", w.Code)
+	}
+
+	// without keys configured, none is trusted
+	router = limitedRouter(NewMemoryRateLimitStore(), StaticAPIKeys(nil), Limit{Requests: 1, Per: time.Minute})
+	serve(router, "GET", "/foods", nil)
+	if w := serve(router, "GET", "/foods", withKey("a-key-of-a-partner")); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the key to be ignored, got %d", w.Code)
+	}
+}
+
+func TestRateLimitToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	token, _, err := helpers.GenerateAllTokens("waiter@example.com", "Test", "Waiter", "a-waiter", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := helpers.SECRET_KEY
+	t.Cleanup(func() { helpers.SECRET_KEY = secret })
+
+	router := gin.New()
+	router.Use(RateLimit(NewMemoryRateLimitStore(), nil, Limit{Requests: 10, Per: time.Minute}))
+	// a token verified again after the limiter would now be rejected
+	router.Use(func(c *gin.Context) { helpers.SECRET_KEY = "another-secret" })
+	router.Use(Authentication())
+	router.GET("/foods", func(c *gin.Context) { c.String(http.StatusOK, c.GetString("uid")) })
+
+	w := serve(router, "GET", "/foods", http.Header{"Token": {token}})
+	if w.Code != http.StatusOK || w.Body.String() != "a-waiter" {
+		t.Fatalf("expected Authentication to reuse the claims of the limiter, got %d: %s", w.Code, w.Body.String())
+	}
+}
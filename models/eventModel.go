@@ -0,0 +1,22 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// OutboxEvent is a domain event written with the change it records, waiting
+// to be published. Status is PENDING until it was PUBLISHED or FAILED for
+// good.
+type OutboxEvent struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	Event_id        string             `json:"event_id"`
+	Type            string             `json:"type"`
+	Payload         string             `json:"payload"`
+	Status          string             `json:"status"`
+	Attempts        int                `json:"attempts"`
+	Next_attempt_at time.Time          `json:"next_attempt_at"`
+	Last_error      string             `json:"last_error,omitempty"`
+	Created_at      time.Time          `json:"created_at"`
+	Updated_at      time.Time          `json:"updated_at"`
+}
@@ -5,13 +5,66 @@ import (
 	"time"
 )
 
+// Food is a dish of a menu. Allergens lists the allergens of the EU 14 it
+// contains, nil meaning they were not declared. Size_prices are the prices
+// of the portion sizes, S, M or L, that don't cost Price.
+//
+// A food can't be ordered while it is Sold_out, until Sold_out_until when
+// set, or once Daily_limit portions were sold on the day. Portions_sold
+// counts the portions sold on Portions_date, a "2006-01-02" date.
 type Food struct {
-	ID         primitive.ObjectID `bson:"_id"`
-	Name       *string            `json:"name" validate:"required,min=2,max=100"`
-	Price      *float64           `json:"price" validate:"required"`
-	Food_image *string            `json:"food_image" validate:"required"`
-	Created_at time.Time          `json:"created_at"`
-	Updated_at time.Time          `json:"updated_at"`
-	Food_id    string             `json:"food_id"`
-	Menu_id    *string            `json:"menu_id" validate:"required"`
+	ID          primitive.ObjectID `bson:"_id"`
+	Name        *string            `json:"name" validate:"required,min=2,max=100"`
+	Description *string            `json:"description" validate:"omitempty,max=1000"`
+	Category    *string            `json:"category" validate:"omitempty,max=50"`
+	Price       *float64           `json:"price" validate:"required"`
+	Size_prices map[string]float64 `json:"size_prices" validate:"dive,keys,oneof=S M L,endkeys,gt=0"`
+	Food_image  *string            `json:"food_image"`
+	// Food_thumbnail is set with Food_image when it is uploaded.
+	Food_thumbnail *string  `json:"food_thumbnail"`
+	Tags           []string `json:"tags" validate:"dive,oneof=vegan vegetarian pescatarian halal kosher organic"`
+	Allergens      []string `json:"allergens" validate:"dive,oneof=celery gluten crustacean egg fish lupin milk mollusc mustard tree_nut peanut sesame soy sulphite"`
+	Spice_level    *int     `json:"spice_level" validate:"omitempty,min=0,max=3"`
+	// Modifier_groups are the choices made when ordering the food.
+	Modifier_groups []ModifierGroup `json:"modifier_groups" validate:"dive"`
+	Sold_out        *bool           `json:"sold_out"`
+	Sold_out_until  *time.Time      `json:"sold_out_until"`
+	Daily_limit     *int            `json:"daily_limit" validate:"omitempty,min=0"`
+	Portions_sold   int             `json:"portions_sold"`
+	Portions_date   string          `json:"portions_date"`
+	Created_at      time.Time       `json:"created_at"`
+	Updated_at      time.Time       `json:"updated_at"`
+	Food_id         string          `json:"food_id"`
+	Menu_id         *string         `json:"menu_id" validate:"required"`
 }
+
+// ModifierGroup is a choice made when ordering a food, e.g. its cooking or
+// its extras: between Min_selections and Max_selections of its Options are
+// selected, no limit being set by a zero Max_selections. A Required group
+// needs at least one option.
+type ModifierGroup struct {
+	Group_id       string           `json:"group_id"`
+	Name           string           `json:"name" validate:"required,max=100"`
+	Required       bool             `json:"required"`
+	Min_selections int              `json:"min_selections" validate:"min=0"`
+	Max_selections int              `json:"max_selections" validate:"min=0"`
+	Options        []ModifierOption `json:"options" validate:"required,min=1,dive"`
+}
+
+// ModifierOption is an option of a modifier group, changing the price of the
+// food by Price_delta.
+type ModifierOption struct {
+	Option_id   string  `json:"option_id"`
+	Name        string  `json:"name" validate:"required,max=100"`
+	Price_delta float64 `json:"price_delta"`
+}
+
+// Allergens are the 14 allergens EU regulation 1169/2011 requires to be
+// declared, in the order of its annex II.
+var Allergens = []string{
+	"gluten", "crustacean", "egg", "fish", "peanut", "soy", "milk",
+	"tree_nut", "celery", "mustard", "sesame", "sulphite", "lupin", "mollusc",
+}
+
+// DietaryTags are the tags a food can have.
+var DietaryTags = []string{"vegan", "vegetarian", "pescatarian", "halal", "kosher", "organic"}
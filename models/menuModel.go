@@ -5,13 +5,42 @@ import (
 	"time"
 )
 
+// Menu can be ordered from between Start_date and End_date, when they are
+// set, and when its Availability allows it.
 type Menu struct {
-	ID         primitive.ObjectID `bson:"_id"`
-	Name       string             `json:"name" validate:"required"`
-	Category   string             `json:"category" validate:"required"`
-	Start_date *time.Time         `json:"start_date"`
-	End_date   *time.Time         `json:"end_date"`
-	Created_at time.Time          `json:"created_at"`
-	Updated_at time.Time          `json:"update_at"`
-	Menu_id    string             `json:"menu_id"`
+	ID           primitive.ObjectID `bson:"_id"`
+	Name         string             `json:"name" validate:"required"`
+	Category     string             `json:"category" validate:"required"`
+	Start_date   *time.Time         `json:"start_date"`
+	End_date     *time.Time         `json:"end_date"`
+	Availability *Availability      `json:"availability"`
+	Created_at   time.Time          `json:"created_at"`
+	Updated_at   time.Time          `json:"update_at"`
+	Menu_id      string             `json:"menu_id"`
+}
+
+// Availability is the recurring schedule of a menu in Time_zone, UTC when
+// empty. A menu without Rules is available all day. Exceptions replace the
+// rules on their date, e.g. to close on holidays.
+type Availability struct {
+	Time_zone  string                  `json:"time_zone" validate:"omitempty,timezone"`
+	Rules      []AvailabilityRule      `json:"rules" validate:"dive"`
+	Exceptions []AvailabilityException `json:"exceptions" validate:"dive"`
+}
+
+// AvailabilityRule opens a menu from Start to End, "HH:MM" clock times, on
+// Days. A window ending at or before its start ends the next day.
+type AvailabilityRule struct {
+	Days  []string `json:"days" validate:"required,min=1,dive,oneof=MON TUE WED THU FRI SAT SUN"`
+	Start string   `json:"start" validate:"required,datetime=15:04"`
+	End   string   `json:"end" validate:"required,datetime=15:04"`
+}
+
+// AvailabilityException opens a menu from Start to End on Date,
+// "YYYY-MM-DD", whatever the rules, or closes it all day when they are empty.
+type AvailabilityException struct {
+	Date  string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name  string `json:"name,omitempty"`
+	Start string `json:"start,omitempty" validate:"omitempty,datetime=15:04"`
+	End   string `json:"end,omitempty" validate:"omitempty,datetime=15:04"`
 }
@@ -5,13 +5,27 @@ import (
 	"time"
 )
 
+// OrderItem is a food ordered in the portion size Quantity. Its Unit_price is
+// the price of the size with the modifiers selected, computed when ordering
+// unless overridden.
 type OrderItem struct {
 	ID            primitive.ObjectID `bson:"_id"`
 	Quantity      *string            `json:"quantity" validate:"required,eq=S|eq=M|eq=L"`
-	Unit_price    *float64           `json:"unit_price" validate:"required"`
+	Unit_price    *float64           `json:"unit_price" validate:"omitempty,min=0"`
 	Created_at    time.Time          `json:"created_at"`
 	Updated_at    time.Time          `json:"updated_at"`
 	Food_id       *string            `json:"food_id" validate:"required"`
+	Modifiers     []SelectedModifier `json:"modifiers" validate:"dive"`
 	Order_item_id string             `json:"order_item_id"`
 	Order_id      string             `json:"order_id" validate:"required"`
 }
+
+// SelectedModifier is an option of a modifier group of the food of an order
+// item. The names and the price delta are copied from the food when ordering.
+type SelectedModifier struct {
+	Group_id    string  `json:"group_id" validate:"required"`
+	Option_id   string  `json:"option_id" validate:"required"`
+	Group_name  string  `json:"group_name"`
+	Name        string  `json:"name"`
+	Price_delta float64 `json:"price_delta"`
+}
@@ -6,10 +6,11 @@ import (
 )
 
 type Order struct {
-	ID         primitive.ObjectID `bson:"_id"`
-	Order_date time.Time          `json:"order_date" validate:"required"`
-	Created_at time.Time          `json:"created_at"`
-	Updated_at time.Time          `json:"updated_at"`
-	Order_id   string             `json:"order_id"`
-	Table_id   *string            `json:"table_id" validate:"required"`
+	ID           primitive.ObjectID `bson:"_id"`
+	Order_date   time.Time          `json:"order_date" validate:"required"`
+	Order_status *string            `json:"order_status" validate:"omitempty,eq=OPEN|eq=PAID"`
+	Created_at   time.Time          `json:"created_at"`
+	Updated_at   time.Time          `json:"updated_at"`
+	Order_id     string             `json:"order_id"`
+	Table_id     *string            `json:"table_id" validate:"required"`
 }
@@ -5,17 +5,22 @@ import (
 	"time"
 )
 
+// User is a member of the staff. Its Permissions are granted in the
+// database, signing up grants none.
 type User struct {
-	ID            primitive.ObjectID `bson:"_id"`
-	First_name    *string            `json:"first_name" validate:"required,min=2,max=100"`
-	Last_name     *string            `json:"last_name" validate:"required,min=2,max=100"`
-	Password      *string            `json:"password"  validate:"required,min=6"`
-	Email         *string            `json:"email" validate:"email,required"`
-	Avatar        *string            `json:"avatar"`
-	Phone         *string            `json:"phone" validated:"required"`
-	Token         *string            `json:"token"`
-	Refresh_token *string            `json:"refresh_token"`
-	Created_at    time.Time          `json:"created_at"`
-	Updated_at    time.Time          `json:"updated_at"`
-	User_id       string             `json:"user_id"`
+	ID         primitive.ObjectID `bson:"_id"`
+	First_name *string            `json:"first_name" validate:"required,min=2,max=100"`
+	Last_name  *string            `json:"last_name" validate:"required,min=2,max=100"`
+	Password   *string            `json:"password"  validate:"required,min=6"`
+	Email      *string            `json:"email" validate:"email,required"`
+	Avatar     *string            `json:"avatar"`
+	// Avatar_thumbnail is set with Avatar when it is uploaded.
+	Avatar_thumbnail *string   `json:"avatar_thumbnail"`
+	Phone            *string   `json:"phone" validated:"required"`
+	Token            *string   `json:"token"`
+	Refresh_token    *string   `json:"refresh_token"`
+	Permissions      []string  `json:"permissions"`
+	Created_at       time.Time `json:"created_at"`
+	Updated_at       time.Time `json:"updated_at"`
+	User_id          string    `json:"user_id"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Webhook subscribes Url to Events. Deliveries are signed with Secret, which
+// is never returned once written.
+type Webhook struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Url        *string            `json:"url" validate:"required,url,startswith=http"`
+	Events     []string           `json:"events" validate:"required,min=1,dive,oneof=order.placed order.updated order.deleted order_item.added order_item.updated order_item.deleted invoice.created invoice.updated invoice.paid table.occupied table.freed food.sold_out food.restored"`
+	Secret     *string            `json:"secret,omitempty" bson:"secret" validate:"required,min=16"`
+	Active     *bool              `json:"active"`
+	Created_at time.Time          `json:"created_at"`
+	Updated_at time.Time          `json:"updated_at"`
+	Webhook_id string             `json:"webhook_id"`
+}
+
+// WebhookDelivery is a POST of an event to a webhook and the outcome of its
+// attempts. Status is PENDING until it SUCCEEDED or FAILED for good.
+type WebhookDelivery struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	Delivery_id     string             `json:"delivery_id"`
+	Webhook_id      string             `json:"webhook_id"`
+	Event_id        string             `json:"event_id"`
+	Event           string             `json:"event"`
+	Payload         string             `json:"payload"`
+	Status          string             `json:"status"`
+	Attempts        int                `json:"attempts"`
+	Next_attempt_at time.Time          `json:"next_attempt_at"`
+	Response_status int                `json:"response_status,omitempty"`
+	Last_error      string             `json:"last_error,omitempty"`
+	Redelivery_of   string             `json:"redelivery_of,omitempty"`
+	Created_at      time.Time          `json:"created_at"`
+	Updated_at      time.Time          `json:"updated_at"`
+}
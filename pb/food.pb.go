@@ -0,0 +1,1459 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: food.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Food struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FoodId    string                 `protobuf:"bytes,1,opt,name=food_id,json=foodId,proto3" json:"food_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price     float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	FoodImage string                 `protobuf:"bytes,4,opt,name=food_image,json=foodImage,proto3" json:"food_image,omitempty"`
+	MenuId    string                 `protobuf:"bytes,5,opt,name=menu_id,json=menuId,proto3" json:"menu_id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// modifier_groups are the choices made when ordering the food.
+	ModifierGroups []*ModifierGroup `protobuf:"bytes,8,rep,name=modifier_groups,json=modifierGroups,proto3" json:"modifier_groups,omitempty"`
+	Description    string           `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Category       string           `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"`
+	Tags           []string         `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	// allergens are among the 14 allergens of the EU, left out when they were
+	// not declared.
+	Allergens  *StringList `protobuf:"bytes,12,opt,name=allergens,proto3" json:"allergens,omitempty"`
+	SpiceLevel *int32      `protobuf:"varint,13,opt,name=spice_level,json=spiceLevel,proto3,oneof" json:"spice_level,omitempty"`
+	// size_prices are the prices of the portion sizes, S, M or L, that don't
+	// cost price.
+	SizePrices map[string]float64 `protobuf:"bytes,14,rep,name=size_prices,json=sizePrices,proto3" json:"size_prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// A food can't be ordered while it is sold_out, until sold_out_until when
+	// set, or once daily_limit portions were sold on the day.
+	SoldOut      bool                   `protobuf:"varint,15,opt,name=sold_out,json=soldOut,proto3" json:"sold_out,omitempty"`
+	SoldOutUntil *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=sold_out_until,json=soldOutUntil,proto3" json:"sold_out_until,omitempty"`
+	DailyLimit   *int32                 `protobuf:"varint,17,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+	// food_thumbnail is set with food_image when it is uploaded.
+	FoodThumbnail string `protobuf:"bytes,18,opt,name=food_thumbnail,json=foodThumbnail,proto3" json:"food_thumbnail,omitempty"`
+}
+
+func (x *Food) Reset() {
+	*x = Food{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Food) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Food) ProtoMessage() {}
+
+func (x *Food) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Food.ProtoReflect.Descriptor instead.
+func (*Food) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Food) GetFoodId() string {
+	if x != nil {
+		return x.FoodId
+	}
+	return ""
+}
+
+func (x *Food) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Food) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Food) GetFoodImage() string {
+	if x != nil {
+		return x.FoodImage
+	}
+	return ""
+}
+
+func (x *Food) GetMenuId() string {
+	if x != nil {
+		return x.MenuId
+	}
+	return ""
+}
+
+func (x *Food) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Food) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Food) GetModifierGroups() []*ModifierGroup {
+	if x != nil {
+		return x.ModifierGroups
+	}
+	return nil
+}
+
+func (x *Food) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Food) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Food) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Food) GetAllergens() *StringList {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *Food) GetSpiceLevel() int32 {
+	if x != nil && x.SpiceLevel != nil {
+		return *x.SpiceLevel
+	}
+	return 0
+}
+
+func (x *Food) GetSizePrices() map[string]float64 {
+	if x != nil {
+		return x.SizePrices
+	}
+	return nil
+}
+
+func (x *Food) GetSoldOut() bool {
+	if x != nil {
+		return x.SoldOut
+	}
+	return false
+}
+
+func (x *Food) GetSoldOutUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldOutUntil
+	}
+	return nil
+}
+
+func (x *Food) GetDailyLimit() int32 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+func (x *Food) GetFoodThumbnail() string {
+	if x != nil {
+		return x.FoodThumbnail
+	}
+	return ""
+}
+
+// ModifierGroup is a choice made when ordering a food: between
+// min_selections and max_selections of its options are selected, no limit
+// being set by a zero max_selections. A required group needs at least one
+// option. The API sets the IDs.
+type ModifierGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId       string            `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Name          string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Required      bool              `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	MinSelections int32             `protobuf:"varint,4,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	MaxSelections int32             `protobuf:"varint,5,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	Options       []*ModifierOption `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *ModifierGroup) Reset() {
+	*x = ModifierGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierGroup) ProtoMessage() {}
+
+func (x *ModifierGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierGroup.ProtoReflect.Descriptor instead.
+func (*ModifierGroup) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ModifierGroup) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ModifierGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModifierGroup) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ModifierGroup) GetMinSelections() int32 {
+	if x != nil {
+		return x.MinSelections
+	}
+	return 0
+}
+
+func (x *ModifierGroup) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
+	}
+	return 0
+}
+
+func (x *ModifierGroup) GetOptions() []*ModifierOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// ModifierOption changes the price of the food by price_delta.
+type ModifierOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OptionId   string  `protobuf:"bytes,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Name       string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PriceDelta float64 `protobuf:"fixed64,3,opt,name=price_delta,json=priceDelta,proto3" json:"price_delta,omitempty"`
+}
+
+func (x *ModifierOption) Reset() {
+	*x = ModifierOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierOption) ProtoMessage() {}
+
+func (x *ModifierOption) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierOption.ProtoReflect.Descriptor instead.
+func (*ModifierOption) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ModifierOption) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *ModifierOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModifierOption) GetPriceDelta() float64 {
+	if x != nil {
+		return x.PriceDelta
+	}
+	return 0
+}
+
+// ModifierGroups tells the modifier groups set by an update from none.
+type ModifierGroups struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*ModifierGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *ModifierGroups) Reset() {
+	*x = ModifierGroups{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierGroups) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierGroups) ProtoMessage() {}
+
+func (x *ModifierGroups) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierGroups.ProtoReflect.Descriptor instead.
+func (*ModifierGroups) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ModifierGroups) GetGroups() []*ModifierGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type ListFoodsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Foods      []*Food `protobuf:"bytes,1,rep,name=foods,proto3" json:"foods,omitempty"`
+	TotalCount int64   `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *ListFoodsResponse) Reset() {
+	*x = ListFoodsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFoodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFoodsResponse) ProtoMessage() {}
+
+func (x *ListFoodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFoodsResponse.ProtoReflect.Descriptor instead.
+func (*ListFoodsResponse) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListFoodsResponse) GetFoods() []*Food {
+	if x != nil {
+		return x.Foods
+	}
+	return nil
+}
+
+func (x *ListFoodsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type CreateFoodRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Price          float64          `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	FoodImage      string           `protobuf:"bytes,3,opt,name=food_image,json=foodImage,proto3" json:"food_image,omitempty"`
+	MenuId         string           `protobuf:"bytes,4,opt,name=menu_id,json=menuId,proto3" json:"menu_id,omitempty"`
+	ModifierGroups []*ModifierGroup `protobuf:"bytes,5,rep,name=modifier_groups,json=modifierGroups,proto3" json:"modifier_groups,omitempty"`
+	Description    string           `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Category       string           `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Tags           []string         `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// allergens are left out when they are not declared.
+	Allergens    *StringList            `protobuf:"bytes,9,opt,name=allergens,proto3" json:"allergens,omitempty"`
+	SpiceLevel   *int32                 `protobuf:"varint,10,opt,name=spice_level,json=spiceLevel,proto3,oneof" json:"spice_level,omitempty"`
+	SizePrices   map[string]float64     `protobuf:"bytes,11,rep,name=size_prices,json=sizePrices,proto3" json:"size_prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	SoldOut      bool                   `protobuf:"varint,12,opt,name=sold_out,json=soldOut,proto3" json:"sold_out,omitempty"`
+	SoldOutUntil *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=sold_out_until,json=soldOutUntil,proto3" json:"sold_out_until,omitempty"`
+	DailyLimit   *int32                 `protobuf:"varint,14,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+}
+
+func (x *CreateFoodRequest) Reset() {
+	*x = CreateFoodRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateFoodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFoodRequest) ProtoMessage() {}
+
+func (x *CreateFoodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFoodRequest.ProtoReflect.Descriptor instead.
+func (*CreateFoodRequest) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateFoodRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFoodRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateFoodRequest) GetFoodImage() string {
+	if x != nil {
+		return x.FoodImage
+	}
+	return ""
+}
+
+func (x *CreateFoodRequest) GetMenuId() string {
+	if x != nil {
+		return x.MenuId
+	}
+	return ""
+}
+
+func (x *CreateFoodRequest) GetModifierGroups() []*ModifierGroup {
+	if x != nil {
+		return x.ModifierGroups
+	}
+	return nil
+}
+
+func (x *CreateFoodRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateFoodRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateFoodRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateFoodRequest) GetAllergens() *StringList {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *CreateFoodRequest) GetSpiceLevel() int32 {
+	if x != nil && x.SpiceLevel != nil {
+		return *x.SpiceLevel
+	}
+	return 0
+}
+
+func (x *CreateFoodRequest) GetSizePrices() map[string]float64 {
+	if x != nil {
+		return x.SizePrices
+	}
+	return nil
+}
+
+func (x *CreateFoodRequest) GetSoldOut() bool {
+	if x != nil {
+		return x.SoldOut
+	}
+	return false
+}
+
+func (x *CreateFoodRequest) GetSoldOutUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldOutUntil
+	}
+	return nil
+}
+
+func (x *CreateFoodRequest) GetDailyLimit() int32 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+type UpdateFoodRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FoodId    string   `protobuf:"bytes,1,opt,name=food_id,json=foodId,proto3" json:"food_id,omitempty"`
+	Name      *string  `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Price     *float64 `protobuf:"fixed64,3,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	FoodImage *string  `protobuf:"bytes,4,opt,name=food_image,json=foodImage,proto3,oneof" json:"food_image,omitempty"`
+	MenuId    *string  `protobuf:"bytes,5,opt,name=menu_id,json=menuId,proto3,oneof" json:"menu_id,omitempty"`
+	// modifier_groups replace the modifier groups of the food when present.
+	ModifierGroups *ModifierGroups `protobuf:"bytes,6,opt,name=modifier_groups,json=modifierGroups,proto3" json:"modifier_groups,omitempty"`
+	Description    *string         `protobuf:"bytes,7,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category       *string         `protobuf:"bytes,8,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Tags           *StringList     `protobuf:"bytes,9,opt,name=tags,proto3" json:"tags,omitempty"`
+	Allergens      *StringList     `protobuf:"bytes,10,opt,name=allergens,proto3" json:"allergens,omitempty"`
+	SpiceLevel     *int32          `protobuf:"varint,11,opt,name=spice_level,json=spiceLevel,proto3,oneof" json:"spice_level,omitempty"`
+	// size_prices replace the prices of the sizes of the food when present.
+	SizePrices *SizePrices `protobuf:"bytes,12,opt,name=size_prices,json=sizePrices,proto3" json:"size_prices,omitempty"`
+	// sold_out sets sold_out_until with it, a food put back on sale ending its
+	// sold out period.
+	SoldOut      *bool                  `protobuf:"varint,13,opt,name=sold_out,json=soldOut,proto3,oneof" json:"sold_out,omitempty"`
+	SoldOutUntil *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=sold_out_until,json=soldOutUntil,proto3" json:"sold_out_until,omitempty"`
+	// A zero daily_limit removes the limit.
+	DailyLimit *int32 `protobuf:"varint,15,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+}
+
+func (x *UpdateFoodRequest) Reset() {
+	*x = UpdateFoodRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateFoodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFoodRequest) ProtoMessage() {}
+
+func (x *UpdateFoodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFoodRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFoodRequest) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateFoodRequest) GetFoodId() string {
+	if x != nil {
+		return x.FoodId
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetPrice() float64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *UpdateFoodRequest) GetFoodImage() string {
+	if x != nil && x.FoodImage != nil {
+		return *x.FoodImage
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetMenuId() string {
+	if x != nil && x.MenuId != nil {
+		return *x.MenuId
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetModifierGroups() *ModifierGroups {
+	if x != nil {
+		return x.ModifierGroups
+	}
+	return nil
+}
+
+func (x *UpdateFoodRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateFoodRequest) GetTags() *StringList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateFoodRequest) GetAllergens() *StringList {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *UpdateFoodRequest) GetSpiceLevel() int32 {
+	if x != nil && x.SpiceLevel != nil {
+		return *x.SpiceLevel
+	}
+	return 0
+}
+
+func (x *UpdateFoodRequest) GetSizePrices() *SizePrices {
+	if x != nil {
+		return x.SizePrices
+	}
+	return nil
+}
+
+func (x *UpdateFoodRequest) GetSoldOut() bool {
+	if x != nil && x.SoldOut != nil {
+		return *x.SoldOut
+	}
+	return false
+}
+
+func (x *UpdateFoodRequest) GetSoldOutUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldOutUntil
+	}
+	return nil
+}
+
+func (x *UpdateFoodRequest) GetDailyLimit() int32 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+// SizePrices tells the size prices set by an update from none.
+type SizePrices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prices map[string]float64 `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (x *SizePrices) Reset() {
+	*x = SizePrices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SizePrices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SizePrices) ProtoMessage() {}
+
+func (x *SizePrices) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SizePrices.ProtoReflect.Descriptor instead.
+func (*SizePrices) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SizePrices) GetPrices() map[string]float64 {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+// SoldOutFood is an entry of the 86 list, portions_left being left out
+// without a daily limit.
+type SoldOutFood struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FoodId  string `protobuf:"bytes,1,opt,name=food_id,json=foodId,proto3" json:"food_id,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MenuId  string `protobuf:"bytes,3,opt,name=menu_id,json=menuId,proto3" json:"menu_id,omitempty"`
+	SoldOut bool   `protobuf:"varint,4,opt,name=sold_out,json=soldOut,proto3" json:"sold_out,omitempty"`
+	// sold_out_until is left out when the food is sold out until further
+	// notice.
+	SoldOutUntil *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sold_out_until,json=soldOutUntil,proto3" json:"sold_out_until,omitempty"`
+	DailyLimit   *int32                 `protobuf:"varint,6,opt,name=daily_limit,json=dailyLimit,proto3,oneof" json:"daily_limit,omitempty"`
+	PortionsLeft *int32                 `protobuf:"varint,7,opt,name=portions_left,json=portionsLeft,proto3,oneof" json:"portions_left,omitempty"`
+}
+
+func (x *SoldOutFood) Reset() {
+	*x = SoldOutFood{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoldOutFood) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoldOutFood) ProtoMessage() {}
+
+func (x *SoldOutFood) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoldOutFood.ProtoReflect.Descriptor instead.
+func (*SoldOutFood) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SoldOutFood) GetFoodId() string {
+	if x != nil {
+		return x.FoodId
+	}
+	return ""
+}
+
+func (x *SoldOutFood) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SoldOutFood) GetMenuId() string {
+	if x != nil {
+		return x.MenuId
+	}
+	return ""
+}
+
+func (x *SoldOutFood) GetSoldOut() bool {
+	if x != nil {
+		return x.SoldOut
+	}
+	return false
+}
+
+func (x *SoldOutFood) GetSoldOutUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldOutUntil
+	}
+	return nil
+}
+
+func (x *SoldOutFood) GetDailyLimit() int32 {
+	if x != nil && x.DailyLimit != nil {
+		return *x.DailyLimit
+	}
+	return 0
+}
+
+func (x *SoldOutFood) GetPortionsLeft() int32 {
+	if x != nil && x.PortionsLeft != nil {
+		return *x.PortionsLeft
+	}
+	return 0
+}
+
+type ListSoldOutFoodsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Foods []*SoldOutFood `protobuf:"bytes,1,rep,name=foods,proto3" json:"foods,omitempty"`
+}
+
+func (x *ListSoldOutFoodsResponse) Reset() {
+	*x = ListSoldOutFoodsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_food_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSoldOutFoodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSoldOutFoodsResponse) ProtoMessage() {}
+
+func (x *ListSoldOutFoodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_food_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSoldOutFoodsResponse.ProtoReflect.Descriptor instead.
+func (*ListSoldOutFoodsResponse) Descriptor() ([]byte, []int) {
+	return file_food_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSoldOutFoodsResponse) GetFoods() []*SoldOutFood {
+	if x != nil {
+		return x.Foods
+	}
+	return nil
+}
+
+var File_food_proto protoreflect.FileDescriptor
+
+var file_food_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x66, 0x6f, 0x6f, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x72, 0x65,
+	0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbe, 0x06, 0x0a, 0x04, 0x46, 0x6f, 0x6f, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x6f, 0x6f, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x6f, 0x6f, 0x64, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x45, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0e, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x6c,
+	0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x70, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x70, 0x69, 0x63,
+	0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x73, 0x69, 0x7a,
+	0x65, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x6f, 0x6f, 0x64, 0x2e, 0x53, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x6f,
+	0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c,
+	0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x24, 0x0a, 0x0b,
+	0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x01, 0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x74, 0x68, 0x75, 0x6d, 0x62,
+	0x6e, 0x61, 0x69, 0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6f, 0x64,
+	0x54, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x69, 0x7a,
+	0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x73, 0x70, 0x69,
+	0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x61, 0x69,
+	0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xe1, 0x01, 0x0a, 0x0d, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d,
+	0x69, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x62, 0x0a, 0x0e,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x0a, 0x09, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61,
+	0x22, 0x46, 0x0a, 0x0e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x5f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74,
+	0x46, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x05, 0x66, 0x6f, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6f,
+	0x64, 0x52, 0x05, 0x66, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa2, 0x05, 0x0a, 0x11, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x6f, 0x6f,
+	0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66,
+	0x6f, 0x6f, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x75,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6e, 0x75, 0x49,
+	0x64, 0x12, 0x45, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0e, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x6c,
+	0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67,
+	0x65, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x70, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x70, 0x69, 0x63,
+	0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x51, 0x0a, 0x0b, 0x73, 0x69, 0x7a,
+	0x65, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30,
+	0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x53, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08,
+	0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x6f, 0x6c, 0x64, 0x5f,
+	0x6f, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x6f, 0x6c,
+	0x64, 0x4f, 0x75, 0x74, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x61, 0x69,
+	0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01,
+	0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x53, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x73, 0x70, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xfc,
+	0x05, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6f, 0x64, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x22, 0x0a, 0x0a, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x09, 0x66, 0x6f, 0x6f, 0x64, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x06, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x5f,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x0e, 0x6d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x25, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x04, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x09, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0b, 0x73,
+	0x70, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x06, 0x52, 0x0a, 0x73, 0x70, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01,
+	0x01, 0x12, 0x3a, 0x0a, 0x0b, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72,
+	0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x08, 0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x07, 0x52, 0x07, 0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a,
+	0x0e, 0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0c, 0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12,
+	0x24, 0x0a, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x08, 0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x6f, 0x6f,
+	0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x6e, 0x75,
+	0x5f, 0x69, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x73, 0x70, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x86, 0x01,
+	0x0a, 0x0a, 0x53, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x06,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x7a,
+	0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa2, 0x02, 0x0a, 0x0b, 0x53, 0x6f, 0x6c, 0x64, 0x4f,
+	0x75, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x6f, 0x6f, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6f, 0x64, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x73, 0x6f, 0x6c, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x6f, 0x6c, 0x64, 0x5f,
+	0x6f, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x6f, 0x6c,
+	0x64, 0x4f, 0x75, 0x74, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x61, 0x69,
+	0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x28, 0x0a, 0x0d, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6c, 0x65, 0x66, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0c, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x4c, 0x65, 0x66, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x61,
+	0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x22, 0x4c, 0x0a, 0x18, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x6f, 0x6f, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72,
+	0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x46, 0x6f,
+	0x6f, 0x64, 0x52, 0x05, 0x66, 0x6f, 0x6f, 0x64, 0x73, 0x32, 0xcc, 0x03, 0x0a, 0x0b, 0x46, 0x6f,
+	0x6f, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x09, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72,
+	0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x12,
+	0x18, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6f, 0x64, 0x12, 0x4d,
+	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x12, 0x20, 0x2e, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a,
+	0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x12, 0x20, 0x2e, 0x72, 0x65,
+	0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0a,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6f, 0x6f, 0x64, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x64, 0x4f,
+	0x75, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x27, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x64, 0x4f, 0x75, 0x74, 0x46, 0x6f, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1a, 0x5a, 0x18, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_food_proto_rawDescOnce sync.Once
+	file_food_proto_rawDescData = file_food_proto_rawDesc
+)
+
+func file_food_proto_rawDescGZIP() []byte {
+	file_food_proto_rawDescOnce.Do(func() {
+		file_food_proto_rawDescData = protoimpl.X.CompressGZIP(file_food_proto_rawDescData)
+	})
+	return file_food_proto_rawDescData
+}
+
+var file_food_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_food_proto_goTypes = []interface{}{
+	(*Food)(nil),                     // 0: restaurant.v1.Food
+	(*ModifierGroup)(nil),            // 1: restaurant.v1.ModifierGroup
+	(*ModifierOption)(nil),           // 2: restaurant.v1.ModifierOption
+	(*ModifierGroups)(nil),           // 3: restaurant.v1.ModifierGroups
+	(*ListFoodsResponse)(nil),        // 4: restaurant.v1.ListFoodsResponse
+	(*CreateFoodRequest)(nil),        // 5: restaurant.v1.CreateFoodRequest
+	(*UpdateFoodRequest)(nil),        // 6: restaurant.v1.UpdateFoodRequest
+	(*SizePrices)(nil),               // 7: restaurant.v1.SizePrices
+	(*SoldOutFood)(nil),              // 8: restaurant.v1.SoldOutFood
+	(*ListSoldOutFoodsResponse)(nil), // 9: restaurant.v1.ListSoldOutFoodsResponse
+	nil,                              // 10: restaurant.v1.Food.SizePricesEntry
+	nil,                              // 11: restaurant.v1.CreateFoodRequest.SizePricesEntry
+	nil,                              // 12: restaurant.v1.SizePrices.PricesEntry
+	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
+	(*StringList)(nil),               // 14: restaurant.v1.StringList
+	(*PageRequest)(nil),              // 15: restaurant.v1.PageRequest
+	(*IdRequest)(nil),                // 16: restaurant.v1.IdRequest
+	(*emptypb.Empty)(nil),            // 17: google.protobuf.Empty
+	(*CreateResponse)(nil),           // 18: restaurant.v1.CreateResponse
+	(*UpdateResponse)(nil),           // 19: restaurant.v1.UpdateResponse
+	(*DeleteResponse)(nil),           // 20: restaurant.v1.DeleteResponse
+}
+var file_food_proto_depIdxs = []int32{
+	13, // 0: restaurant.v1.Food.created_at:type_name -> google.protobuf.Timestamp
+	13, // 1: restaurant.v1.Food.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: restaurant.v1.Food.modifier_groups:type_name -> restaurant.v1.ModifierGroup
+	14, // 3: restaurant.v1.Food.allergens:type_name -> restaurant.v1.StringList
+	10, // 4: restaurant.v1.Food.size_prices:type_name -> restaurant.v1.Food.SizePricesEntry
+	13, // 5: restaurant.v1.Food.sold_out_until:type_name -> google.protobuf.Timestamp
+	2,  // 6: restaurant.v1.ModifierGroup.options:type_name -> restaurant.v1.ModifierOption
+	1,  // 7: restaurant.v1.ModifierGroups.groups:type_name -> restaurant.v1.ModifierGroup
+	0,  // 8: restaurant.v1.ListFoodsResponse.foods:type_name -> restaurant.v1.Food
+	1,  // 9: restaurant.v1.CreateFoodRequest.modifier_groups:type_name -> restaurant.v1.ModifierGroup
+	14, // 10: restaurant.v1.CreateFoodRequest.allergens:type_name -> restaurant.v1.StringList
+	11, // 11: restaurant.v1.CreateFoodRequest.size_prices:type_name -> restaurant.v1.CreateFoodRequest.SizePricesEntry
+	13, // 12: restaurant.v1.CreateFoodRequest.sold_out_until:type_name -> google.protobuf.Timestamp
+	3,  // 13: restaurant.v1.UpdateFoodRequest.modifier_groups:type_name -> restaurant.v1.ModifierGroups
+	14, // 14: restaurant.v1.UpdateFoodRequest.tags:type_name -> restaurant.v1.StringList
+	14, // 15: restaurant.v1.UpdateFoodRequest.allergens:type_name -> restaurant.v1.StringList
+	7,  // 16: restaurant.v1.UpdateFoodRequest.size_prices:type_name -> restaurant.v1.SizePrices
+	13, // 17: restaurant.v1.UpdateFoodRequest.sold_out_until:type_name -> google.protobuf.Timestamp
+	12, // 18: restaurant.v1.SizePrices.prices:type_name -> restaurant.v1.SizePrices.PricesEntry
+	13, // 19: restaurant.v1.SoldOutFood.sold_out_until:type_name -> google.protobuf.Timestamp
+	8,  // 20: restaurant.v1.ListSoldOutFoodsResponse.foods:type_name -> restaurant.v1.SoldOutFood
+	15, // 21: restaurant.v1.FoodService.ListFoods:input_type -> restaurant.v1.PageRequest
+	16, // 22: restaurant.v1.FoodService.GetFood:input_type -> restaurant.v1.IdRequest
+	5,  // 23: restaurant.v1.FoodService.CreateFood:input_type -> restaurant.v1.CreateFoodRequest
+	6,  // 24: restaurant.v1.FoodService.UpdateFood:input_type -> restaurant.v1.UpdateFoodRequest
+	16, // 25: restaurant.v1.FoodService.DeleteFood:input_type -> restaurant.v1.IdRequest
+	17, // 26: restaurant.v1.FoodService.ListSoldOutFoods:input_type -> google.protobuf.Empty
+	4,  // 27: restaurant.v1.FoodService.ListFoods:output_type -> restaurant.v1.ListFoodsResponse
+	0,  // 28: restaurant.v1.FoodService.GetFood:output_type -> restaurant.v1.Food
+	18, // 29: restaurant.v1.FoodService.CreateFood:output_type -> restaurant.v1.CreateResponse
+	19, // 30: restaurant.v1.FoodService.UpdateFood:output_type -> restaurant.v1.UpdateResponse
+	20, // 31: restaurant.v1.FoodService.DeleteFood:output_type -> restaurant.v1.DeleteResponse
+	9,  // 32: restaurant.v1.FoodService.ListSoldOutFoods:output_type -> restaurant.v1.ListSoldOutFoodsResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_food_proto_init() }
+func file_food_proto_init() {
+	if File_food_proto != nil {
+		return
+	}
+	file_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_food_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Food); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifierGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifierOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModifierGroups); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFoodsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateFoodRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateFoodRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SizePrices); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoldOutFood); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_food_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSoldOutFoodsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_food_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_food_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_food_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_food_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_food_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_food_proto_goTypes,
+		DependencyIndexes: file_food_proto_depIdxs,
+		MessageInfos:      file_food_proto_msgTypes,
+	}.Build()
+	File_food_proto = out.File
+	file_food_proto_rawDesc = nil
+	file_food_proto_goTypes = nil
+	file_food_proto_depIdxs = nil
+}
@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: food.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FoodService_ListFoods_FullMethodName        = "/restaurant.v1.FoodService/ListFoods"
+	FoodService_GetFood_FullMethodName          = "/restaurant.v1.FoodService/GetFood"
+	FoodService_CreateFood_FullMethodName       = "/restaurant.v1.FoodService/CreateFood"
+	FoodService_UpdateFood_FullMethodName       = "/restaurant.v1.FoodService/UpdateFood"
+	FoodService_DeleteFood_FullMethodName       = "/restaurant.v1.FoodService/DeleteFood"
+	FoodService_ListSoldOutFoods_FullMethodName = "/restaurant.v1.FoodService/ListSoldOutFoods"
+)
+
+// FoodServiceClient is the client API for FoodService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FoodServiceClient interface {
+	ListFoods(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*ListFoodsResponse, error)
+	GetFood(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Food, error)
+	CreateFood(ctx context.Context, in *CreateFoodRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// UpdateFood sets the fields that are present.
+	UpdateFood(ctx context.Context, in *UpdateFoodRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	DeleteFood(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// ListSoldOutFoods returns the 86 list: the foods sold out first, then
+	// those with a daily limit, by name.
+	ListSoldOutFoods(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSoldOutFoodsResponse, error)
+}
+
+type foodServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFoodServiceClient(cc grpc.ClientConnInterface) FoodServiceClient {
+	return &foodServiceClient{cc}
+}
+
+func (c *foodServiceClient) ListFoods(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*ListFoodsResponse, error) {
+	out := new(ListFoodsResponse)
+	err := c.cc.Invoke(ctx, FoodService_ListFoods_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodServiceClient) GetFood(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Food, error) {
+	out := new(Food)
+	err := c.cc.Invoke(ctx, FoodService_GetFood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodServiceClient) CreateFood(ctx context.Context, in *CreateFoodRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, FoodService_CreateFood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodServiceClient) UpdateFood(ctx context.Context, in *UpdateFoodRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, FoodService_UpdateFood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodServiceClient) DeleteFood(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, FoodService_DeleteFood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foodServiceClient) ListSoldOutFoods(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSoldOutFoodsResponse, error) {
+	out := new(ListSoldOutFoodsResponse)
+	err := c.cc.Invoke(ctx, FoodService_ListSoldOutFoods_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FoodServiceServer is the server API for FoodService service.
+// All implementations must embed UnimplementedFoodServiceServer
+// for forward compatibility
+type FoodServiceServer interface {
+	ListFoods(context.Context, *PageRequest) (*ListFoodsResponse, error)
+	GetFood(context.Context, *IdRequest) (*Food, error)
+	CreateFood(context.Context, *CreateFoodRequest) (*CreateResponse, error)
+	// UpdateFood sets the fields that are present.
+	UpdateFood(context.Context, *UpdateFoodRequest) (*UpdateResponse, error)
+	DeleteFood(context.Context, *IdRequest) (*DeleteResponse, error)
+	// ListSoldOutFoods returns the 86 list: the foods sold out first, then
+	// those with a daily limit, by name.
+	ListSoldOutFoods(context.Context, *emptypb.Empty) (*ListSoldOutFoodsResponse, error)
+	mustEmbedUnimplementedFoodServiceServer()
+}
+
+// UnimplementedFoodServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFoodServiceServer struct {
+}
+
+func (UnimplementedFoodServiceServer) ListFoods(context.Context, *PageRequest) (*ListFoodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFoods not implemented")
+}
+func (UnimplementedFoodServiceServer) GetFood(context.Context, *IdRequest) (*Food, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFood not implemented")
+}
+func (UnimplementedFoodServiceServer) CreateFood(context.Context, *CreateFoodRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFood not implemented")
+}
+func (UnimplementedFoodServiceServer) UpdateFood(context.Context, *UpdateFoodRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFood not implemented")
+}
+func (UnimplementedFoodServiceServer) DeleteFood(context.Context, *IdRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFood not implemented")
+}
+func (UnimplementedFoodServiceServer) ListSoldOutFoods(context.Context, *emptypb.Empty) (*ListSoldOutFoodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSoldOutFoods not implemented")
+}
+func (UnimplementedFoodServiceServer) mustEmbedUnimplementedFoodServiceServer() {}
+
+// UnsafeFoodServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FoodServiceServer will
+// result in compilation errors.
+type UnsafeFoodServiceServer interface {
+	mustEmbedUnimplementedFoodServiceServer()
+}
+
+func RegisterFoodServiceServer(s grpc.ServiceRegistrar, srv FoodServiceServer) {
+	s.RegisterService(&FoodService_ServiceDesc, srv)
+}
+
+func _FoodService_ListFoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).ListFoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_ListFoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).ListFoods(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoodService_GetFood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).GetFood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_GetFood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).GetFood(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoodService_CreateFood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).CreateFood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_CreateFood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).CreateFood(ctx, req.(*CreateFoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoodService_UpdateFood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).UpdateFood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_UpdateFood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).UpdateFood(ctx, req.(*UpdateFoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoodService_DeleteFood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).DeleteFood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_DeleteFood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).DeleteFood(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoodService_ListSoldOutFoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoodServiceServer).ListSoldOutFoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FoodService_ListSoldOutFoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoodServiceServer).ListSoldOutFoods(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FoodService_ServiceDesc is the grpc.ServiceDesc for FoodService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FoodService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restaurant.v1.FoodService",
+	HandlerType: (*FoodServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFoods",
+			Handler:    _FoodService_ListFoods_Handler,
+		},
+		{
+			MethodName: "GetFood",
+			Handler:    _FoodService_GetFood_Handler,
+		},
+		{
+			MethodName: "CreateFood",
+			Handler:    _FoodService_CreateFood_Handler,
+		},
+		{
+			MethodName: "UpdateFood",
+			Handler:    _FoodService_UpdateFood_Handler,
+		},
+		{
+			MethodName: "DeleteFood",
+			Handler:    _FoodService_DeleteFood_Handler,
+		},
+		{
+			MethodName: "ListSoldOutFoods",
+			Handler:    _FoodService_ListSoldOutFoods_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "food.proto",
+}
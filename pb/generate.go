@@ -0,0 +1,5 @@
+// Package pb holds the code generated from the protobuf definitions of the
+// gRPC API, in proto/.
+package pb
+
+//go:generate protoc -I ../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative common.proto food.proto menu.proto table.proto order.proto order_item.proto invoice.proto
@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: order_item.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	OrderItemService_ListOrderItems_FullMethodName    = "/restaurant.v1.OrderItemService/ListOrderItems"
+	OrderItemService_OrderItemsByOrder_FullMethodName = "/restaurant.v1.OrderItemService/OrderItemsByOrder"
+	OrderItemService_GetOrderItem_FullMethodName      = "/restaurant.v1.OrderItemService/GetOrderItem"
+	OrderItemService_CreateOrderItems_FullMethodName  = "/restaurant.v1.OrderItemService/CreateOrderItems"
+	OrderItemService_UpdateOrderItem_FullMethodName   = "/restaurant.v1.OrderItemService/UpdateOrderItem"
+	OrderItemService_DeleteOrderItem_FullMethodName   = "/restaurant.v1.OrderItemService/DeleteOrderItem"
+)
+
+// OrderItemServiceClient is the client API for OrderItemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderItemServiceClient interface {
+	ListOrderItems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrderItemsResponse, error)
+	// OrderItemsByOrder joins the items of an order with their food and table
+	// and sums the amount due.
+	OrderItemsByOrder(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OrderSummary, error)
+	GetOrderItem(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OrderItem, error)
+	// CreateOrderItems opens an order on the table with the items and occupies
+	// the table.
+	CreateOrderItems(ctx context.Context, in *CreateOrderItemsRequest, opts ...grpc.CallOption) (*CreateOrderItemsResponse, error)
+	// UpdateOrderItem sets the fields that are present.
+	UpdateOrderItem(ctx context.Context, in *UpdateOrderItemRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	DeleteOrderItem(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type orderItemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderItemServiceClient(cc grpc.ClientConnInterface) OrderItemServiceClient {
+	return &orderItemServiceClient{cc}
+}
+
+func (c *orderItemServiceClient) ListOrderItems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrderItemsResponse, error) {
+	out := new(ListOrderItemsResponse)
+	err := c.cc.Invoke(ctx, OrderItemService_ListOrderItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderItemServiceClient) OrderItemsByOrder(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OrderSummary, error) {
+	out := new(OrderSummary)
+	err := c.cc.Invoke(ctx, OrderItemService_OrderItemsByOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderItemServiceClient) GetOrderItem(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*OrderItem, error) {
+	out := new(OrderItem)
+	err := c.cc.Invoke(ctx, OrderItemService_GetOrderItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderItemServiceClient) CreateOrderItems(ctx context.Context, in *CreateOrderItemsRequest, opts ...grpc.CallOption) (*CreateOrderItemsResponse, error) {
+	out := new(CreateOrderItemsResponse)
+	err := c.cc.Invoke(ctx, OrderItemService_CreateOrderItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderItemServiceClient) UpdateOrderItem(ctx context.Context, in *UpdateOrderItemRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, OrderItemService_UpdateOrderItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderItemServiceClient) DeleteOrderItem(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, OrderItemService_DeleteOrderItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderItemServiceServer is the server API for OrderItemService service.
+// All implementations must embed UnimplementedOrderItemServiceServer
+// for forward compatibility
+type OrderItemServiceServer interface {
+	ListOrderItems(context.Context, *emptypb.Empty) (*ListOrderItemsResponse, error)
+	// OrderItemsByOrder joins the items of an order with their food and table
+	// and sums the amount due.
+	OrderItemsByOrder(context.Context, *IdRequest) (*OrderSummary, error)
+	GetOrderItem(context.Context, *IdRequest) (*OrderItem, error)
+	// CreateOrderItems opens an order on the table with the items and occupies
+	// the table.
+	CreateOrderItems(context.Context, *CreateOrderItemsRequest) (*CreateOrderItemsResponse, error)
+	// UpdateOrderItem sets the fields that are present.
+	UpdateOrderItem(context.Context, *UpdateOrderItemRequest) (*UpdateResponse, error)
+	DeleteOrderItem(context.Context, *IdRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedOrderItemServiceServer()
+}
+
+// UnimplementedOrderItemServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderItemServiceServer struct {
+}
+
+func (UnimplementedOrderItemServiceServer) ListOrderItems(context.Context, *emptypb.Empty) (*ListOrderItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrderItems not implemented")
+}
+func (UnimplementedOrderItemServiceServer) OrderItemsByOrder(context.Context, *IdRequest) (*OrderSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderItemsByOrder not implemented")
+}
+func (UnimplementedOrderItemServiceServer) GetOrderItem(context.Context, *IdRequest) (*OrderItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderItem not implemented")
+}
+func (UnimplementedOrderItemServiceServer) CreateOrderItems(context.Context, *CreateOrderItemsRequest) (*CreateOrderItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrderItems not implemented")
+}
+func (UnimplementedOrderItemServiceServer) UpdateOrderItem(context.Context, *UpdateOrderItemRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderItem not implemented")
+}
+func (UnimplementedOrderItemServiceServer) DeleteOrderItem(context.Context, *IdRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrderItem not implemented")
+}
+func (UnimplementedOrderItemServiceServer) mustEmbedUnimplementedOrderItemServiceServer() {}
+
+// UnsafeOrderItemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderItemServiceServer will
+// result in compilation errors.
+type UnsafeOrderItemServiceServer interface {
+	mustEmbedUnimplementedOrderItemServiceServer()
+}
+
+func RegisterOrderItemServiceServer(s grpc.ServiceRegistrar, srv OrderItemServiceServer) {
+	s.RegisterService(&OrderItemService_ServiceDesc, srv)
+}
+
+func _OrderItemService_ListOrderItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).ListOrderItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_ListOrderItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).ListOrderItems(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderItemService_OrderItemsByOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).OrderItemsByOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_OrderItemsByOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).OrderItemsByOrder(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderItemService_GetOrderItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).GetOrderItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_GetOrderItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).GetOrderItem(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderItemService_CreateOrderItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).CreateOrderItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_CreateOrderItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).CreateOrderItems(ctx, req.(*CreateOrderItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderItemService_UpdateOrderItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).UpdateOrderItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_UpdateOrderItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).UpdateOrderItem(ctx, req.(*UpdateOrderItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderItemService_DeleteOrderItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderItemServiceServer).DeleteOrderItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderItemService_DeleteOrderItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderItemServiceServer).DeleteOrderItem(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderItemService_ServiceDesc is the grpc.ServiceDesc for OrderItemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderItemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restaurant.v1.OrderItemService",
+	HandlerType: (*OrderItemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOrderItems",
+			Handler:    _OrderItemService_ListOrderItems_Handler,
+		},
+		{
+			MethodName: "OrderItemsByOrder",
+			Handler:    _OrderItemService_OrderItemsByOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderItem",
+			Handler:    _OrderItemService_GetOrderItem_Handler,
+		},
+		{
+			MethodName: "CreateOrderItems",
+			Handler:    _OrderItemService_CreateOrderItems_Handler,
+		},
+		{
+			MethodName: "UpdateOrderItem",
+			Handler:    _OrderItemService_UpdateOrderItem_Handler,
+		},
+		{
+			MethodName: "DeleteOrderItem",
+			Handler:    _OrderItemService_DeleteOrderItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "order_item.proto",
+}
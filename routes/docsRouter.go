@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/docs"
+)
+
+func DocsRoutes(routes gin.IRouter) {
+	routes.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", docs.SwaggerUI)
+	})
+	routes.GET("/docs/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", docs.Spec)
+	})
+}
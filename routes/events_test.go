@@ -0,0 +1,159 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/store"
+	"sync"
+	"testing"
+	"time"
+)
+
+// runRelay publishes the events of the outbox of s on bus until the test is
+// over, retrying fast.
+func runRelay(t *testing.T, s store.Store, bus *events.Bus) {
+	relay := events.NewRelay(s, bus, events.Options{
+		MaxAttempts:  3,
+		Backoff:      time.Millisecond,
+		MaxBackoff:   10 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go relay.Run(ctx)
+	t.Cleanup(cancel)
+}
+
+// subscriber records the events it is handed.
+type subscriber struct {
+	t   *testing.T
+	got chan events.Event
+}
+
+func subscribe(t *testing.T, bus *events.Bus, eventTypes ...string) *subscriber {
+	s := &subscriber{t: t, got: make(chan events.Event, 100)}
+	for _, eventType := range eventTypes {
+		bus.Subscribe(eventType, func(ctx context.Context, event events.Event) error {
+			s.got <- event
+			return nil
+		})
+	}
+	return s
+}
+
+func (s *subscriber) next() events.Event {
+	s.t.Helper()
+	select {
+	case event := <-s.got:
+		return event
+	case <-time.After(5 * time.Second):
+		s.t.Fatal("no event published")
+		return events.Event{}
+	}
+}
+
+func (s *subscriber) none() {
+	s.t.Helper()
+	select {
+	case event := <-s.got:
+		s.t.Fatalf("unexpected event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDomainEvents(t *testing.T) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+	bus := events.NewBus()
+	sub := subscribe(t, bus, events.Types...)
+	runRelay(t, s, bus)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	salad := a.createFood(menuId, "Salad", 7)
+	tableId := a.createTable(1)
+	orderId := a.order(tableId, soup, salad)
+
+	// without failures, the events of a write are published in the order
+	// they were written
+	for _, expected := range []string{events.OrderPlaced, events.ItemAdded, events.ItemAdded, events.TableOccupied} {
+		event := sub.next()
+		var data struct {
+			Order_id string
+			Table_id string
+		}
+		json.Unmarshal(event.Data, &data)
+		if event.Type != expected || event.ID == "" || (data.Order_id != orderId && data.Table_id != tableId) {
+			t.Fatalf("expected %s, got %+v", expected, event)
+		}
+	}
+
+	// an occupied table taking another order doesn't move
+	a.order(tableId, soup)
+	if event, other := sub.next(), sub.next(); event.Type != events.OrderPlaced || other.Type != events.ItemAdded {
+		t.Fatalf("unexpected events %+v %+v", event, other)
+	}
+	sub.none()
+
+	a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD", "payment_status": "PAID"})
+	var invoice struct{ Order_id, Payment_status string }
+	if event := sub.next(); json.Unmarshal(event.Data, &invoice) != nil || event.Type != events.InvoicePaid || invoice.Order_id != orderId || invoice.Payment_status != "PAID" {
+		t.Fatalf("expected the invoice to be paid, got %+v", event)
+	}
+	if event := sub.next(); event.Type != events.TableFreed {
+		t.Fatalf("expected the table to be freed, got %+v", event)
+	}
+
+	a.expect(http.StatusOK, "PATCH", "/tables/"+tableId, gin.H{"table_status": "OCCUPIED"}, nil)
+	var table struct{ Table_id, Table_status string }
+	if event := sub.next(); json.Unmarshal(event.Data, &table) != nil || event.Type != events.TableOccupied || table.Table_id != tableId || table.Table_status != "OCCUPIED" {
+		t.Fatalf("expected the table to be occupied, got %+v", event)
+	}
+	a.expect(http.StatusOK, "PATCH", "/tables/"+tableId, gin.H{"number_of_guests": 2}, nil)
+	sub.none()
+
+	// a rejected write publishes nothing
+	if w := a.do("POST", "/orderItems", gin.H{"table_id": "missing", "order_items": []gin.H{{"food_id": soup, "quantity": "M"}}}); w.Code == http.StatusOK {
+		t.Fatalf("expected an order on a missing table to be rejected, got %s", w.Body.String())
+	}
+	sub.none()
+}
+
+func TestDomainEventsRetried(t *testing.T) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+	bus := events.NewBus()
+
+	var mu sync.Mutex
+	calls := 0
+	bus.Subscribe(events.TableOccupied, func(ctx context.Context, event events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	sub := subscribe(t, bus, events.TableOccupied)
+	runRelay(t, s, bus)
+
+	a.expect(http.StatusOK, "PATCH", "/tables/"+a.createTable(1), gin.H{"table_status": "OCCUPIED"}, nil)
+
+	// every subscriber gets the event again when one of them failed
+	first, again := sub.next(), sub.next()
+	if first.ID != again.ID {
+		t.Fatalf("expected the same event twice, got %+v %+v", first, again)
+	}
+	sub.none()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 2 {
+		t.Fatalf("expected the failing subscriber to be called twice, got %d", calls)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	controller "restaurant_management/controllers"
 )
 
-func FoodRoutes(routes *gin.Engine) {
+func FoodRoutes(routes gin.IRouter) {
 	routes.GET("/foods", controller.GetFoods())
+	routes.GET("/foods/sold-out", controller.GetSoldOutFoods())
 	routes.GET("/foods/:id", controller.GetFood())
 	routes.POST("/foods", controller.CreateFood())
 	routes.PATCH("/foods/:id", controller.UpdateFood())
+	routes.PUT("/foods/:id/image", controller.UploadFoodImage())
 	routes.DELETE("/foods/:id", controller.DeleteFood())
 }
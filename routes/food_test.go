@@ -0,0 +1,122 @@
+package routes_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+// foodNames returns the names of the foods GET /foods answers to query.
+func (a *api) foodNames(query string) []string {
+	a.t.Helper()
+
+	var page []struct {
+		Food_items []struct{ Name string }
+	}
+	a.expect(http.StatusOK, "GET", "/foods?recordPerPage=20&page=1"+query, nil, &page)
+	names := []string{}
+	for _, p := range page {
+		for _, food := range p.Food_items {
+			names = append(names, food.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestFoodAllergensAndTags(t *testing.T) {
+	a := newVersionedAPI(t)
+
+	menuId := a.createMenu()
+	food := func(name string, details gin.H) string {
+		body := gin.H{"name": name, "price": 8, "food_image": name + ".png", "menu_id": menuId}
+		for key, value := range details {
+			body[key] = value
+		}
+		return a.create("/foods", body)
+	}
+	food("Satay", gin.H{"category": "starter", "allergens": []string{"peanut", "soy"}, "spice_level": 2})
+	food("Salad", gin.H{"category": "starter", "tags": []string{"vegan", "vegetarian"}, "allergens": []string{}, "spice_level": 0})
+	food("Omelette", gin.H{"category": "main", "tags": []string{"vegetarian"}, "allergens": []string{"egg", "milk"}})
+	curry := food("Curry", gin.H{"category": "main", "description": "Green curry", "tags": []string{"vegan"}, "spice_level": 3})
+
+	for query, expected := range map[string][]string{
+		"":                                 {"Curry", "Omelette", "Salad", "Satay"},
+		"&category=starter":                {"Salad", "Satay"},
+		"&tag=vegan":                       {"Curry", "Salad"},
+		"&tag=vegan&tag=vegetarian":        {"Salad"},
+		"&tag=vegan,vegetarian":            {"Salad"},
+		"&allergen_free=peanut":            {"Omelette", "Salad"},
+		"&allergen_free=peanut,milk":       {"Salad"},
+		"&max_spice_level=2":               {"Salad", "Satay"},
+		"&category=main&allergen_free=egg": {},
+	} {
+		if names := a.foodNames(query); len(names) != len(expected) || len(names) > 0 && !equal(names, expected) {
+			t.Errorf("GET /foods%s: expected %v, got %v", query, expected, names)
+		}
+	}
+	a.expect(http.StatusBadRequest, "GET", "/foods?recordPerPage=20&page=1&allergen_free=peanuts", nil, nil)
+	a.expect(http.StatusBadRequest, "GET", "/v2/foods?recordPerPage=20&page=1&max_spice_level=hot", nil, nil)
+
+	// the details are validated
+	for _, details := range []gin.H{
+		{"allergens": []string{"walnut"}},
+		{"tags": []string{"paleo"}},
+		{"spice_level": 4},
+	} {
+		body := gin.H{"name": "Broken", "price": 1, "food_image": "x.png", "menu_id": menuId}
+		for key, value := range details {
+			body[key] = value
+		}
+		if w := a.do("POST", "/foods", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", details, w.Code, w.Body.String())
+		}
+	}
+	if w := a.do("PATCH", "/foods/"+curry, gin.H{"allergens": []string{"walnut"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected an unknown allergen to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// the curry declares its allergens
+	a.expect(http.StatusOK, "PATCH", "/foods/"+curry, gin.H{"allergens": []string{"fish"}}, nil)
+	if names := a.foodNames("&allergen_free=peanut"); !equal(names, []string{"Curry", "Omelette", "Salad"}) {
+		t.Errorf("expected the curry to be free of peanuts, got %v", names)
+	}
+
+	var matrix struct {
+		Name      string
+		Allergens []string
+		Foods     []struct {
+			Name     string
+			Declared bool
+			Contains map[string]bool
+		}
+	}
+	a.expect(http.StatusOK, "GET", "/v2/menus/"+menuId+"/allergens", nil, &matrix)
+	if matrix.Name != "Lunch" || len(matrix.Allergens) != 14 || len(matrix.Foods) != 4 {
+		t.Fatalf("unexpected allergen matrix %+v", matrix)
+	}
+	rows := map[string]map[string]bool{}
+	for _, row := range matrix.Foods {
+		if !row.Declared || len(row.Contains) != 14 {
+			t.Fatalf("expected %s to declare the 14 allergens, got %+v", row.Name, row)
+		}
+		rows[row.Name] = row.Contains
+	}
+	if !rows["Satay"]["peanut"] || !rows["Satay"]["soy"] || rows["Satay"]["milk"] || !rows["Curry"]["fish"] || rows["Salad"]["gluten"] {
+		t.Fatalf("unexpected allergens %v", rows)
+	}
+	a.expect(http.StatusNotFound, "GET", "/menus/missing/allergens", nil, nil)
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"restaurant_management/graph"
+)
+
+func GraphQLRoutes(routes gin.IRouter, limits graph.Limits) {
+	routes.POST("/graphql", graph.Handler(limits))
+}
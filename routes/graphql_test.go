@@ -0,0 +1,298 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/graph"
+	"restaurant_management/models"
+	"restaurant_management/routes"
+	"restaurant_management/store"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// countingStore counts the batched reads the GraphQL loaders make.
+type countingStore struct {
+	store.Store
+	calls *calls
+}
+
+type calls struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *calls) add(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[name]++
+}
+
+// reset forgets the calls made so far.
+func (c *calls) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = map[string]int{}
+}
+
+func (c *calls) get(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[name]
+}
+
+func (s countingStore) Menus() store.MenuStore {
+	return countingMenus{s.Store.Menus(), s.calls}
+}
+
+func (s countingStore) Foods() store.FoodStore {
+	return countingFoods{s.Store.Foods(), s.calls}
+}
+
+func (s countingStore) Tables() store.TableStore {
+	return countingTables{s.Store.Tables(), s.calls}
+}
+
+func (s countingStore) Orders() store.OrderStore {
+	return countingOrders{s.Store.Orders(), s.calls}
+}
+
+func (s countingStore) OrderItems() store.OrderItemStore {
+	return countingOrderItems{s.Store.OrderItems(), s.calls}
+}
+
+type countingMenus struct {
+	store.MenuStore
+	calls *calls
+}
+
+func (m countingMenus) GetMany(ctx context.Context, menuIds []string) ([]models.Menu, error) {
+	m.calls.add("menus")
+	return m.MenuStore.GetMany(ctx, menuIds)
+}
+
+type countingFoods struct {
+	store.FoodStore
+	calls *calls
+}
+
+func (f countingFoods) GetMany(ctx context.Context, foodIds []string) ([]models.Food, error) {
+	f.calls.add("foods")
+	return f.FoodStore.GetMany(ctx, foodIds)
+}
+
+func (f countingFoods) ByMenus(ctx context.Context, menuIds []string) ([]models.Food, error) {
+	f.calls.add("menuFoods")
+	return f.FoodStore.ByMenus(ctx, menuIds)
+}
+
+type countingTables struct {
+	store.TableStore
+	calls *calls
+}
+
+func (t countingTables) GetMany(ctx context.Context, tableIds []string) ([]models.Table, error) {
+	t.calls.add("tables")
+	return t.TableStore.GetMany(ctx, tableIds)
+}
+
+type countingOrders struct {
+	store.OrderStore
+	calls *calls
+}
+
+func (o countingOrders) GetMany(ctx context.Context, orderIds []string) ([]models.Order, error) {
+	o.calls.add("orders")
+	return o.OrderStore.GetMany(ctx, orderIds)
+}
+
+type countingOrderItems struct {
+	store.OrderItemStore
+	calls *calls
+}
+
+func (o countingOrderItems) ByOrders(ctx context.Context, orderIds []string) ([]models.OrderItem, error) {
+	o.calls.add("orderItems")
+	return o.OrderItemStore.ByOrders(ctx, orderIds)
+}
+
+type graphqlResult struct {
+	Data   json.RawMessage
+	Errors []struct {
+		Message string
+	}
+}
+
+func (a *api) graphql(status int, query string, variables gin.H, data interface{}) graphqlResult {
+	a.t.Helper()
+
+	var result graphqlResult
+	a.expect(status, "POST", "/graphql", gin.H{"query": query, "variables": variables}, &result)
+	if data != nil {
+		if len(result.Errors) > 0 {
+			a.t.Fatalf("unexpected errors %+v", result.Errors)
+		}
+		if err := json.Unmarshal(result.Data, data); err != nil {
+			a.t.Fatalf("%v: %s", err, result.Data)
+		}
+	}
+	return result
+}
+
+func TestGraphQLBatchesRelations(t *testing.T) {
+	counts := &calls{counts: map[string]int{}}
+	a := newAPIOver(t, countingStore{store.NewMemoryStore(), counts})
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	salad := a.createFood(menuId, "Salad", 7)
+	tables := []string{a.createTable(1), a.createTable(2), a.createTable(3)}
+	for _, tableId := range tables {
+		a.order(tableId, soup, salad)
+	}
+	// ordering reads the foods and the menus
+	counts.reset()
+
+	var data struct {
+		Orders struct {
+			Total_count int
+			Items       []struct {
+				Table struct {
+					Table_number int
+				}
+				Items []struct {
+					Quantity string
+					Food     struct {
+						Name string
+						Menu struct {
+							Name  string
+							Foods []struct {
+								Name string
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a.graphql(http.StatusOK, `query Orders($size: Int) {
+		orders(recordPerPage: $size) {
+			total_count
+			items {
+				table { table_number }
+				items { quantity food { name menu { name foods { name } } } }
+			}
+		}
+	}`, gin.H{"size": 10}, &data)
+
+	orders := data.Orders
+	if orders.Total_count != 3 || len(orders.Items) != 3 {
+		t.Fatalf("expected the 3 orders, got %+v", orders)
+	}
+	for i, order := range orders.Items {
+		if order.Table.Table_number != i+1 || len(order.Items) != 2 {
+			t.Fatalf("unexpected order %+v", order)
+		}
+		for _, item := range order.Items {
+			if item.Quantity != "M" || item.Food.Name == "" || item.Food.Menu.Name != "Lunch" || len(item.Food.Menu.Foods) != 2 {
+				t.Fatalf("unexpected order item %+v", item)
+			}
+		}
+	}
+
+	// one read per relation, whatever the number of orders
+	for _, name := range []string{"tables", "orderItems", "foods", "menus", "menuFoods"} {
+		if n := counts.get(name); n != 1 {
+			t.Errorf("expected 1 batched read of %s, got %d", name, n)
+		}
+	}
+}
+
+func TestGraphQLInvoiceAndMenu(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	tableId := a.createTable(5)
+	orderId := a.order(tableId, soup, soup)
+	invoiceId := a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD"})
+
+	var data struct {
+		Invoice struct {
+			Payment_status string
+			Order          struct {
+				Order_id string
+				Table    struct {
+					Table_status string
+				}
+				Items []struct {
+					Unit_price float64
+				}
+			}
+		}
+		Menu struct {
+			Foods []struct {
+				Food_id string
+				Price   float64
+			}
+		}
+	}
+	a.graphql(http.StatusOK, `query Invoice($invoice: ID!, $menu: ID!) {
+		invoice(id: $invoice) {
+			payment_status
+			order { order_id table { table_status } items { unit_price } }
+		}
+		menu(id: $menu) { foods { food_id price } }
+	}`, gin.H{"invoice": invoiceId, "menu": menuId}, &data)
+
+	invoice := data.Invoice
+	if invoice.Payment_status != "PENDING" || invoice.Order.Order_id != orderId || invoice.Order.Table.Table_status != "OCCUPIED" || len(invoice.Order.Items) != 2 {
+		t.Fatalf("unexpected invoice %+v", invoice)
+	}
+	if foods := data.Menu.Foods; len(foods) != 1 || foods[0].Food_id != soup || foods[0].Price != 4.5 {
+		t.Fatalf("unexpected foods %+v", foods)
+	}
+
+	result := a.graphql(http.StatusOK, `{ food(id: "unknown") { name } }`, nil, nil)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "error occurred while fetching the food item" {
+		t.Fatalf("expected the food not to be found, got %+v", result.Errors)
+	}
+
+	result = a.graphql(http.StatusBadRequest, `{ food(id: "unknown") { calories } }`, nil, nil)
+	if len(result.Errors) == 0 {
+		t.Fatal("expected an unknown field to be rejected")
+	}
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	a := newAPI(t)
+	a.router = routes.NewRouter(routes.RouterOptions{
+		GraphQLLimits: graph.Limits{MaxDepth: 4, MaxComplexity: 250},
+	})
+
+	a.graphql(http.StatusOK, `{ menus { name foods { menu { name } } } }`, nil, &struct{}{})
+
+	result := a.graphql(http.StatusBadRequest, `{ menus { foods { menu { foods { name } } } } }`, nil, nil)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "depth 5") {
+		t.Fatalf("expected the query to be too deep, got %+v", result.Errors)
+	}
+
+	// fragments count as much as the fields they hold
+	result = a.graphql(http.StatusBadRequest, `
+		{ menus { ...menu } }
+		fragment menu on Menu { foods { name price menu { name } } }
+	`, nil, nil)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "complexity") {
+		t.Fatalf("expected the query to be too complex, got %+v", result.Errors)
+	}
+
+	// paginated lists cost their page size
+	a.graphql(http.StatusOK, `{ foods(recordPerPage: 5) { items { name price menu { name } } } }`, nil, &struct{}{})
+	result = a.graphql(http.StatusBadRequest, `{ foods(recordPerPage: 100) { items { name price menu { name } } } }`, nil, nil)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "complexity") {
+		t.Fatalf("expected the page to be too complex, got %+v", result.Errors)
+	}
+}
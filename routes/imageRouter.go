@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "restaurant_management/controllers"
+)
+
+// ImageRoutes serves the images uploaded, public like the pages showing them.
+func ImageRoutes(routes gin.IRouter) {
+	routes.GET("/images/*key", controller.GetImage())
+}
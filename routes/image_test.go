@@ -0,0 +1,185 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/service"
+	"restaurant_management/storage"
+	"strings"
+	"testing"
+)
+
+type uploaded struct {
+	Image     string
+	Thumbnail string
+}
+
+// useImages stores the images uploaded in a temporary directory.
+func useImages(t *testing.T, opts service.ImageOptions) {
+	t.Helper()
+
+	images, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.UseImages(images, opts)
+	t.Cleanup(func() { service.UseImages(nil, service.ImageOptions{}) })
+}
+
+// upload sends data as the image field of a multipart form.
+func (a *api) upload(path string, data []byte) *httptest.ResponseRecorder {
+	a.t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("image", "upload")
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	part.Write(data)
+	form.Close()
+
+	req := httptest.NewRequest("PUT", path, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("token", a.token)
+	w := httptest.NewRecorder()
+	a.router.ServeHTTP(w, req)
+	return w
+}
+
+func (a *api) expectUpload(path string, data []byte) uploaded {
+	a.t.Helper()
+
+	w := a.upload(path, data)
+	var result uploaded
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &result) != nil {
+		a.t.Fatalf("PUT %s: expected the image to be uploaded, got %d: %s", path, w.Code, w.Body.String())
+	}
+	return result
+}
+
+// expectImage gets the image at url, checking its type and its size.
+func (a *api) expectImage(url, contentType string, width, height int) {
+	a.t.Helper()
+
+	w := a.do("GET", url, nil)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != contentType {
+		a.t.Fatalf("GET %s: expected a %s, got %d %s", url, contentType, w.Code, w.Header().Get("Content-Type"))
+	}
+	config, _, err := image.DecodeConfig(w.Body)
+	if err != nil || config.Width != width || config.Height != height {
+		a.t.Fatalf("GET %s: expected %dx%d, got %+v: %v", url, width, height, config, err)
+	}
+}
+
+func testImage(t *testing.T, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(buf *bytes.Buffer, img image.Image) error {
+	return png.Encode(buf, img)
+}
+
+func encodeJPEG(buf *bytes.Buffer, img image.Image) error {
+	return jpeg.Encode(buf, img, nil)
+}
+
+func TestFoodImage(t *testing.T) {
+	useImages(t, service.ImageOptions{MaxBytes: 64 << 10, MaxPixels: 300 * 300, ThumbnailSize: 50})
+	a := newVersionedAPI(t)
+
+	// foods are created without an image, then uploaded one
+	foodId := a.create("/foods", gin.H{"name": "Soup", "price": 4.5, "menu_id": a.createMenu()})
+	first := a.expectUpload("/foods/"+foodId+"/image", testImage(t, 200, 100, encodePNG))
+	if !strings.HasPrefix(first.Image, "/images/foods/"+foodId+"/") || !strings.HasSuffix(first.Thumbnail, "_thumbnail.png") {
+		t.Fatalf("unexpected URLs %+v", first)
+	}
+	a.expectImage(first.Image, "image/png", 200, 100)
+	a.expectImage(first.Thumbnail, "image/png", 50, 25)
+	if w := a.do("GET", first.Image, nil); w.Header().Get("Cache-Control") != "public, max-age=31536000, immutable" {
+		t.Fatalf("expected the image to be cached, got %q", w.Header().Get("Cache-Control"))
+	}
+
+	// a new image replaces the one uploaded before
+	second := a.expectUpload("/v2/foods/"+foodId+"/image", testImage(t, 80, 120, encodeJPEG))
+	a.expectImage(second.Image, "image/jpeg", 80, 120)
+	a.expectImage(second.Thumbnail, "image/jpeg", 33, 50)
+	var food struct{ Food_image, Food_thumbnail string }
+	a.expect(http.StatusOK, "GET", "/foods/"+foodId, nil, &food)
+	if food.Food_image != second.Image || food.Food_thumbnail != second.Thumbnail {
+		t.Fatalf("expected the food to show the new image, got %+v", food)
+	}
+	for _, url := range []string{first.Image, first.Thumbnail} {
+		if w := a.do("GET", url, nil); w.Code != http.StatusNotFound {
+			t.Fatalf("expected %s to be deleted, got %d", url, w.Code)
+		}
+	}
+
+	for name, test := range map[string]struct {
+		data   []byte
+		status int
+	}{
+		"text":      {[]byte("not an image at all"), http.StatusUnsupportedMediaType},
+		"truncated": {testImage(t, 10, 10, encodePNG)[:20], http.StatusBadRequest},
+		"too big":   {bytes.Repeat([]byte{0xff, 0xd8, 0xff}, 30<<10), http.StatusRequestEntityTooLarge},
+		"too wide":  {testImage(t, 1000, 100, encodePNG), http.StatusRequestEntityTooLarge},
+	} {
+		if w := a.upload("/foods/"+foodId+"/image", test.data); w.Code != test.status {
+			t.Errorf("%s: expected %d, got %d: %s", name, test.status, w.Code, w.Body.String())
+		}
+	}
+	if w := a.upload("/foods/"+strings.Repeat("0", 24)+"/image", testImage(t, 10, 10, encodePNG)); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a missing food, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// deleting the food deletes its images
+	a.expect(http.StatusOK, "DELETE", "/foods/"+foodId, nil, nil)
+	if w := a.do("GET", second.Image, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected the image to be deleted, got %d", w.Code)
+	}
+	if w := a.do("GET", "/images/../go.mod", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected the path to be rejected, got %d", w.Code)
+	}
+}
+
+func TestAvatar(t *testing.T) {
+	useImages(t, service.ImageOptions{MaxBytes: 64 << 10, MaxPixels: 300 * 300, ThumbnailSize: 50})
+	a := newVersionedAPI(t)
+
+	var user struct{ User_id string }
+	a.expect(http.StatusOK, "POST", "/users/login", gin.H{"email": "waiter@example.com", "password": "secret-password"}, &user)
+	avatar := a.expectUpload("/users/"+user.User_id+"/avatar", testImage(t, 64, 64, encodeJPEG))
+	a.expectImage(avatar.Thumbnail, "image/jpeg", 50, 50)
+	var got struct{ Avatar, Avatar_thumbnail string }
+	a.expect(http.StatusOK, "GET", "/users/"+user.User_id, nil, &got)
+	if got.Avatar != avatar.Image || got.Avatar_thumbnail != avatar.Thumbnail {
+		t.Fatalf("expected the user to show the avatar, got %+v", got)
+	}
+
+	// users only upload their own avatar, once logged in
+	if w := a.upload("/users/"+strings.Repeat("0", 24)+"/avatar", testImage(t, 64, 64, encodeJPEG)); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the upload to be forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+	a.token = ""
+	if w := a.upload("/users/"+user.User_id+"/avatar", testImage(t, 64, 64, encodeJPEG)); w.Code == http.StatusOK {
+		t.Fatalf("expected the upload to need a token, got %s", w.Body.String())
+	}
+}
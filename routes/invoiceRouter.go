@@ -5,7 +5,7 @@ import (
 	controller "restaurant_management/controllers"
 )
 
-func InvoiceRoutes(routes *gin.Engine) {
+func InvoiceRoutes(routes gin.IRouter) {
 	routes.GET("/invoices", controller.GetInvoices())
 	routes.GET("/invoices/:id", controller.GetInvoice())
 	routes.POST("/invoices", controller.CreateInvoice())
@@ -5,9 +5,11 @@ import (
 	controller "restaurant_management/controllers"
 )
 
-func MenuRoutes(routes *gin.Engine) {
+func MenuRoutes(routes gin.IRouter) {
 	routes.GET("/menus", controller.GetMenus())
+	routes.GET("/menus/active", controller.GetActiveMenus())
 	routes.GET("/menus/:id", controller.GetMenu())
+	routes.GET("/menus/:id/allergens", controller.GetMenuAllergens())
 	routes.POST("/menus", controller.CreateMenu())
 	routes.PATCH("/menus/:id", controller.UpdateMenu())
 	routes.DELETE("/menus/:id", controller.DeleteMenu())
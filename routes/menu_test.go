@@ -0,0 +1,107 @@
+package routes_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// activeMenus returns the IDs of the menus active at at.
+func (a *api) activeMenus(at string) map[string]bool {
+	a.t.Helper()
+
+	var menus []struct{ Menu_id string }
+	a.expect(http.StatusOK, "GET", "/menus/active?at="+url.QueryEscape(at), nil, &menus)
+	active := map[string]bool{}
+	for _, menu := range menus {
+		active[menu.Menu_id] = true
+	}
+	return active
+}
+
+func TestMenuAvailability(t *testing.T) {
+	a := newVersionedAPI(t)
+
+	breakfast := a.create("/menus", gin.H{
+		"name":     "Breakfast",
+		"category": "breakfast",
+		"availability": gin.H{
+			"time_zone": "Europe/Paris",
+			"rules": []gin.H{
+				{"days": []string{"MON", "TUE", "WED", "THU", "FRI"}, "start": "07:00", "end": "11:00"},
+				{"days": []string{"FRI"}, "start": "22:00", "end": "02:00"},
+			},
+			"exceptions": []gin.H{
+				{"date": "2026-12-24", "name": "Christmas Eve", "start": "07:00", "end": "09:00"},
+				{"date": "2026-12-25", "name": "Christmas"},
+			},
+		},
+	})
+	lunch := a.createMenu()
+
+	for at, active := range map[string]bool{
+		"2026-10-19T08:00:00+02:00": true,  // Monday morning
+		"2026-10-19T11:00:00+02:00": false, // closed at the end of the window
+		"2026-10-24T08:00:00+02:00": false, // Saturday morning
+		"2026-10-23T23:00:00+02:00": true,  // Friday night
+		"2026-10-24T01:30:00+02:00": true,  // still Friday night
+		"2026-10-24T02:00:00+02:00": false,
+		"2026-12-24T08:30:00+01:00": true, // shorter on Christmas Eve
+		"2026-12-24T10:00:00+01:00": false,
+		"2026-12-25T08:00:00+01:00": false, // closed on Christmas
+		"2026-10-19T06:30:00Z":      true,  // 08:30 in Paris
+	} {
+		if got := a.activeMenus(at); got[breakfast] != active {
+			t.Errorf("at %s: expected breakfast to be active %v, got %v", at, active, got)
+		}
+	}
+	if active := a.activeMenus(time.Now().Format(time.RFC3339)); !active[lunch] {
+		t.Errorf("expected the lunch menu to be active now, got %v", active)
+	}
+	a.expect(http.StatusBadRequest, "GET", "/menus/active?at=tomorrow", nil, nil)
+
+	// invalid schedules are rejected
+	for _, availability := range []gin.H{
+		{"rules": []gin.H{{"days": []string{"MONDAY"}, "start": "07:00", "end": "11:00"}}},
+		{"rules": []gin.H{{"days": []string{"MON"}, "start": "7h", "end": "11:00"}}},
+		{"exceptions": []gin.H{{"date": "2026-12-25", "start": "07:00"}}},
+		{"time_zone": "Mars/Olympus_Mons"},
+	} {
+		body := gin.H{"name": "Broken", "category": "main", "availability": availability}
+		if w := a.do("POST", "/menus", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", availability, w.Code, w.Body.String())
+		}
+	}
+
+	// the foods of a closed menu can't be ordered
+	today := time.Now().UTC()
+	closed := a.create("/menus", gin.H{
+		"name":     "Closed",
+		"category": "main",
+		"availability": gin.H{"exceptions": []gin.H{
+			{"date": today.Format("2006-01-02")},
+			{"date": today.AddDate(0, 0, 1).Format("2006-01-02")},
+		}},
+	})
+	soup := a.createFood(closed, "Soup", 4.5)
+	tableId := a.createTable(1)
+
+	w := a.do("POST", "/v2/order-items", gin.H{"table_id": tableId, "order_items": []gin.H{{"food_id": soup, "quantity": "M"}}})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Soup is not available") {
+		t.Fatalf("expected the soup not to be available, got %d: %s", w.Code, w.Body.String())
+	}
+	if status := a.tableStatus(tableId); status != "FREE" {
+		t.Fatalf("expected the table to stay free, got %s", status)
+	}
+	w = a.do("POST", "/v2/order-items", gin.H{"table_id": tableId, "order_items": []gin.H{{"food_id": "missing", "quantity": "M"}}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a missing food to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// until the menu opens all day
+	a.expect(http.StatusOK, "PATCH", "/menus/"+closed, gin.H{"availability": gin.H{}}, nil)
+	a.order(tableId, soup)
+}
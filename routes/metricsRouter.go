@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsRouter serves /metrics apart from the API, on a listener only the
+// monitoring reaches: the metrics count the revenue and the sales of every
+// food.
+func NewMetricsRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	MetricsRoutes(router)
+	return router
+}
+
+func MetricsRoutes(routes gin.IRouter) {
+	routes.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -0,0 +1,29 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/routes"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	a := newAPI(t)
+
+	// the API doesn't expose the revenue
+	if w := a.do("GET", "/metrics", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected /metrics to be served apart from the API, got %d", w.Code)
+	}
+	a.do("BREW", "/foods", nil)
+
+	w := httptest.NewRecorder()
+	routes.NewMetricsRouter().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the metrics, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `restaurant_http_request_duration_seconds_count{method="OTHER"`) || strings.Contains(body, `method="BREW"`) {
+		t.Fatalf("expected an unknown method to be labelled OTHER, got %s", body)
+	}
+}
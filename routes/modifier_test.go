@@ -0,0 +1,154 @@
+package routes_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFoodModifiers(t *testing.T) {
+	a := newVersionedAPI(t)
+
+	menuId := a.createMenu()
+	burgerId := a.create("/foods", gin.H{
+		"name":       "Burger",
+		"price":      12,
+		"food_image": "burger.png",
+		"menu_id":    menuId,
+		"modifier_groups": []gin.H{
+			{"name": "Cooking", "required": true, "max_selections": 1, "options": []gin.H{
+				{"name": "Rare"}, {"name": "Medium"}, {"name": "Well done"},
+			}},
+			{"name": "Extras", "max_selections": 2, "options": []gin.H{
+				{"name": "Cheese", "price_delta": 1.5}, {"name": "Bacon", "price_delta": 2}, {"name": "Egg", "price_delta": 1},
+			}},
+		},
+	})
+
+	type option struct {
+		Option_id   string
+		Name        string
+		Price_delta float64
+	}
+	var burger struct {
+		Modifier_groups []struct {
+			Group_id string
+			Name     string
+			Options  []option
+		}
+	}
+	a.expect(http.StatusOK, "GET", "/foods/"+burgerId, nil, &burger)
+	if len(burger.Modifier_groups) != 2 || len(burger.Modifier_groups[1].Options) != 3 {
+		t.Fatalf("unexpected modifier groups %+v", burger.Modifier_groups)
+	}
+	options := map[string]option{}
+	for _, group := range burger.Modifier_groups {
+		if group.Group_id == "" {
+			t.Fatalf("expected %s to have an ID", group.Name)
+		}
+		for _, option := range group.Options {
+			if option.Option_id == "" {
+				t.Fatalf("expected %s to have an ID", option.Name)
+			}
+			options[option.Name] = option
+		}
+	}
+	cooking, extras := burger.Modifier_groups[0].Group_id, burger.Modifier_groups[1].Group_id
+	modifier := func(groupId, name string) gin.H {
+		return gin.H{"group_id": groupId, "option_id": options[name].Option_id}
+	}
+	orderBurger := func(tableId string, modifiers ...gin.H) *httptest.ResponseRecorder {
+		return a.do("POST", "/v2/order-items", gin.H{"table_id": tableId, "order_items": []gin.H{
+			{"food_id": burgerId, "quantity": "M", "modifiers": modifiers},
+		}})
+	}
+
+	// the selections are validated against the groups of the food
+	tableId := a.createTable(7)
+	for message, modifiers := range map[string][]gin.H{
+		"Burger needs at least 1 Cooking": {modifier(extras, "Cheese")},
+		"Burger allows at most 1 Cooking": {modifier(cooking, "Rare"), modifier(cooking, "Medium")},
+		"Burger allows at most 2 Extras": {
+			modifier(cooking, "Rare"), modifier(extras, "Cheese"), modifier(extras, "Bacon"), modifier(extras, "Egg"),
+		},
+		"Burger has no modifier " + cooking: {modifier(cooking, "Rare"), modifier(cooking, "Bacon")},
+		"Burger has no modifier bun":        {{"group_id": "bun", "option_id": "brioche"}},
+		"Egg is selected twice":             {modifier(cooking, "Rare"), modifier(extras, "Egg"), modifier(extras, "Egg")},
+	} {
+		if w := orderBurger(tableId, modifiers...); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), message) {
+			t.Errorf("expected %q, got %d: %s", message, w.Code, w.Body.String())
+		}
+	}
+	if status := a.tableStatus(tableId); status != "FREE" {
+		t.Fatalf("expected the table to stay free, got %s", status)
+	}
+
+	// the price deltas are added to the amounts and the payment due
+	if w := orderBurger(tableId, modifier(cooking, "Medium"), modifier(extras, "Cheese"), modifier(extras, "Bacon")); w.Code != http.StatusOK {
+		t.Fatalf("expected the burger to be ordered, got %d: %s", w.Code, w.Body.String())
+	}
+	var items []struct{ Order_id, Order_item_id string }
+	a.expect(http.StatusOK, "GET", "/v2/order-items", nil, &items)
+	if len(items) != 1 {
+		t.Fatalf("expected one order item, got %+v", items)
+	}
+	orderId := items[0].Order_id
+
+	type selected struct {
+		Group_name  string
+		Name        string
+		Price_delta float64
+	}
+	var summaries []struct {
+		Payment_due float64
+		Order_items []struct {
+			Amount    float64
+			Price     float64
+			Modifiers []selected
+		}
+	}
+	a.expect(http.StatusOK, "GET", "/v2/orders/"+orderId+"/items", nil, &summaries)
+	if len(summaries) != 1 || summaries[0].Payment_due != 15.5 || len(summaries[0].Order_items) != 1 {
+		t.Fatalf("unexpected summaries %+v", summaries)
+	}
+	item := summaries[0].Order_items[0]
+	if item.Price != 15.5 || item.Amount != 15.5 || len(item.Modifiers) != 3 || item.Modifiers[1] != (selected{"Extras", "Cheese", 1.5}) {
+		t.Fatalf("unexpected order item %+v", item)
+	}
+
+	invoiceId := a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD"})
+	var invoice struct {
+		Payment_due   float64
+		Order_details []struct{ Modifiers []selected }
+	}
+	a.expect(http.StatusOK, "GET", "/invoices/"+invoiceId, nil, &invoice)
+	if invoice.Payment_due != 15.5 || len(invoice.Order_details) != 1 || len(invoice.Order_details[0].Modifiers) != 3 {
+		t.Fatalf("unexpected invoice %+v", invoice)
+	}
+
+	// the modifiers can be changed, the item being priced again
+	itemPath := "/v2/order-items/" + items[0].Order_item_id
+	if w := a.do("PATCH", itemPath, gin.H{"modifiers": []gin.H{modifier(extras, "Egg")}}); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "needs at least 1 Cooking") {
+		t.Fatalf("expected the cooking to be required, got %d: %s", w.Code, w.Body.String())
+	}
+	a.expect(http.StatusOK, "PATCH", itemPath, gin.H{"modifiers": []gin.H{modifier(cooking, "Rare"), modifier(extras, "Egg")}}, nil)
+	a.expect(http.StatusOK, "GET", "/v2/orders/"+orderId+"/items", nil, &summaries)
+	if item := summaries[0].Order_items[0]; item.Amount != 13 || len(item.Modifiers) != 2 || item.Modifiers[1] != (selected{"Extras", "Egg", 1}) {
+		t.Fatalf("expected the item to be priced again, got %+v", item)
+	}
+
+	// invalid groups are rejected
+	for _, groups := range [][]gin.H{
+		{{"options": []gin.H{{"name": "Rare"}}}},
+		{{"name": "Cooking"}},
+		{{"name": "Cooking", "min_selections": 2, "max_selections": 1, "options": []gin.H{{"name": "Rare"}, {"name": "Medium"}}}},
+		{{"name": "Cooking", "min_selections": 2, "options": []gin.H{{"name": "Rare"}}}},
+		{{"name": "Cooking", "options": []gin.H{{"option_id": "rare", "name": "Rare"}, {"option_id": "rare", "name": "Medium"}}}},
+	} {
+		if w := a.do("PATCH", "/foods/"+burgerId, gin.H{"modifier_groups": groups}); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", groups, w.Code, w.Body.String())
+		}
+	}
+}
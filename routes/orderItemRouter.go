@@ -3,13 +3,31 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	controller "restaurant_management/controllers"
+	"restaurant_management/middleware"
 )
 
-func OrderItemRoutes(routes *gin.Engine) {
-	routes.GET("/orderItems", controller.GetOrderItems())
-	routes.GET("/orderItems/:id", controller.GetOrderItem())
-	routes.GET("/orderItems-order/:id", controller.GetOrderItemsByOrder())
-	routes.POST("/orderItems", controller.CreateOrderItem())
-	routes.PATCH("/orderItems/:id", controller.UpdateOrderItem())
-	routes.DELETE("/orderItems/:id", controller.DeleteOrderItem())
+// OrderItemRoutes serves the order items under the paths of /v1, deprecated
+// in favour of those of OrderItemV2Routes.
+func OrderItemRoutes(routes gin.IRouter, deprecation middleware.Deprecation) {
+	replacedBy := func(template string) gin.HandlerFunc {
+		return middleware.Deprecated(deprecation, middleware.Successor(template))
+	}
+
+	routes.GET("/orderItems", replacedBy("/v2/order-items"), controller.GetOrderItems())
+	routes.GET("/orderItems/:id", replacedBy("/v2/order-items/:id"), controller.GetOrderItem())
+	routes.GET("/orderItems-order/:id", replacedBy("/v2/orders/:id/items"), controller.GetOrderItemsByOrder())
+	routes.POST("/orderItems", replacedBy("/v2/order-items"), controller.CreateOrderItem())
+	routes.PATCH("/orderItems/:id", replacedBy("/v2/order-items/:id"), controller.UpdateOrderItem())
+	routes.DELETE("/orderItems/:id", replacedBy("/v2/order-items/:id"), controller.DeleteOrderItem())
+}
+
+// OrderItemV2Routes serves the order items under resource paths, the items of
+// an order being a subresource of the order.
+func OrderItemV2Routes(routes gin.IRouter) {
+	routes.GET("/order-items", controller.GetOrderItems())
+	routes.GET("/order-items/:id", controller.GetOrderItem())
+	routes.GET("/orders/:id/items", controller.GetOrderItemsByOrder())
+	routes.POST("/order-items", controller.CreateOrderItem())
+	routes.PATCH("/order-items/:id", controller.UpdateOrderItem())
+	routes.DELETE("/order-items/:id", controller.DeleteOrderItem())
 }
@@ -5,7 +5,7 @@ import (
 	controller "restaurant_management/controllers"
 )
 
-func OrderRoutes(routes *gin.Engine) {
+func OrderRoutes(routes gin.IRouter) {
 	routes.GET("/orders", controller.GetOrders())
 	routes.GET("/orders/:id", controller.GetOrder())
 	routes.POST("/orders", controller.CreateOrder())
@@ -0,0 +1,172 @@
+package routes_test
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"restaurant_management/helpers"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"testing"
+	"time"
+)
+
+// actAs creates a user holding permissions in s, the store a serves, and
+// drives the router as them.
+func (a *api) actAs(s store.Store, email string, permissions ...string) {
+	a.t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	firstName, lastName, password := "Test", "Manager", string(hash)
+	user := models.User{
+		ID:          primitive.NewObjectID(),
+		First_name:  &firstName,
+		Last_name:   &lastName,
+		Password:    &password,
+		Email:       &email,
+		Permissions: permissions,
+		Created_at:  time.Now(),
+		Updated_at:  time.Now(),
+	}
+	user.User_id = user.ID.Hex()
+	if _, err := s.Users().Create(context.Background(), user); err != nil {
+		a.t.Fatal(err)
+	}
+
+	var loggedIn struct {
+		Token       string
+		Permissions []string
+	}
+	a.expect(http.StatusOK, "POST", "/users/login", gin.H{"email": email, "password": "secret-password"}, &loggedIn)
+	if !equal(loggedIn.Permissions, permissions) {
+		a.t.Fatalf("expected the permissions %v, got %v", permissions, loggedIn.Permissions)
+	}
+	a.token = loggedIn.Token
+}
+
+func TestServerSidePricing(t *testing.T) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+
+	menuId := a.createMenu()
+	coffeeId := a.create("/foods", gin.H{
+		"name":        "Coffee",
+		"price":       3,
+		"size_prices": gin.H{"S": 2.5, "L": 4.254},
+		"food_image":  "coffee.png",
+		"menu_id":     menuId,
+		"modifier_groups": []gin.H{
+			{"name": "Milk", "options": []gin.H{{"name": "Oat", "price_delta": 0.5}}},
+		},
+	})
+	var coffee struct {
+		Size_prices     map[string]float64
+		Modifier_groups []struct {
+			Group_id string
+			Options  []struct{ Option_id string }
+		}
+	}
+	a.expect(http.StatusOK, "GET", "/foods/"+coffeeId, nil, &coffee)
+	if coffee.Size_prices["L"] != 4.25 || len(coffee.Modifier_groups) != 1 {
+		t.Fatalf("unexpected food %+v", coffee)
+	}
+	oat := gin.H{"group_id": coffee.Modifier_groups[0].Group_id, "option_id": coffee.Modifier_groups[0].Options[0].Option_id}
+
+	// the prices of the sizes and of the modifiers are applied
+	tableId := a.createTable(5)
+	var result inserted
+	a.expect(http.StatusOK, "POST", "/orderItems", gin.H{"table_id": tableId, "order_items": []gin.H{
+		{"food_id": coffeeId, "quantity": "S"},
+		{"food_id": coffeeId, "quantity": "M"},
+		{"food_id": coffeeId, "quantity": "L"},
+		{"food_id": coffeeId, "quantity": "L", "modifiers": []gin.H{oat}},
+	}}, &result)
+
+	unitPrice := func(orderItemId string) (float64, string) {
+		var item struct {
+			Unit_price float64
+			Order_id   string
+		}
+		a.expect(http.StatusOK, "GET", "/orderItems/"+orderItemId, nil, &item)
+		return item.Unit_price, item.Order_id
+	}
+	for i, expected := range []float64{2.5, 3, 4.25, 4.75} {
+		if price, _ := unitPrice(result.InsertedIDs[i]); price != expected {
+			t.Errorf("item %d: expected a unit price of %v, got %v", i, expected, price)
+		}
+	}
+	_, orderId := unitPrice(result.InsertedIDs[0])
+
+	paymentDue := func() float64 {
+		var summaries []struct{ Payment_due float64 }
+		a.expect(http.StatusOK, "GET", "/orderItems-order/"+orderId, nil, &summaries)
+		if len(summaries) != 1 {
+			t.Fatalf("expected one summary, got %+v", summaries)
+		}
+		return summaries[0].Payment_due
+	}
+	if due := paymentDue(); due != 14.5 {
+		t.Fatalf("expected 14.5 due, got %v", due)
+	}
+
+	// the lines of the invoice show what each item costs, not the base price
+	invoiceId := a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD"})
+	var invoice struct {
+		Payment_due   float64
+		Order_details []struct{ Price, Amount float64 }
+	}
+	a.expect(http.StatusOK, "GET", "/invoices/"+invoiceId, nil, &invoice)
+	if len(invoice.Order_details) != 4 || invoice.Payment_due != 14.5 {
+		t.Fatalf("unexpected invoice %+v", invoice)
+	}
+	for i, expected := range []float64{2.5, 3, 4.25, 4.75} {
+		if line := invoice.Order_details[i]; line.Price != expected || line.Amount != expected {
+			t.Errorf("line %d: expected a price of %v, got %+v", i, expected, line)
+		}
+	}
+
+	// changing the size prices the item again
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+result.InsertedIDs[1], gin.H{"quantity": "L"}, nil)
+	if price, _ := unitPrice(result.InsertedIDs[1]); price != 4.25 {
+		t.Fatalf("expected the item to cost 4.25, got %v", price)
+	}
+	if w := a.do("PATCH", "/orderItems/"+result.InsertedIDs[1], gin.H{"quantity": "XL"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid size to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// the waiter can't set prices
+	if w := a.do("PATCH", "/orderItems/"+result.InsertedIDs[0], gin.H{"unit_price": 0}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a price override to be forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+	w := a.do("POST", "/orderItems", gin.H{"table_id": a.createTable(6), "order_items": []gin.H{{"food_id": coffeeId, "quantity": "M", "unit_price": 1}}})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected a price override to be forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, prices := range []gin.H{{"XL": 5}, {"S": 0}} {
+		if w := a.do("PATCH", "/foods/"+coffeeId, gin.H{"size_prices": prices}); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", prices, w.Code, w.Body.String())
+		}
+	}
+
+	// the manager can
+	a.actAs(s, "manager@example.com", helpers.PriceOverride)
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+result.InsertedIDs[0], gin.H{"unit_price": 0}, nil)
+	if price, _ := unitPrice(result.InsertedIDs[0]); price != 0 {
+		t.Fatalf("expected the item to be offered, got %v", price)
+	}
+	if due := paymentDue(); due != 13.25 {
+		t.Fatalf("expected 13.25 due, got %v", due)
+	}
+	a.expect(http.StatusOK, "POST", "/orderItems", gin.H{"table_id": a.createTable(7), "order_items": []gin.H{
+		{"food_id": coffeeId, "quantity": "M", "unit_price": 1.999},
+	}}, &result)
+	if price, _ := unitPrice(result.InsertedIDs[0]); price != 2 {
+		t.Fatalf("expected the price set to be kept, got %v", price)
+	}
+}
@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"restaurant_management/graph"
+	"restaurant_management/middleware"
+	"restaurant_management/stream"
+	"restaurant_management/tracing"
+	"time"
+)
+
+// RouterOptions holds the optional middlewares of the API, nil ones are left
+// out, and the limits of the GraphQL queries.
+type RouterOptions struct {
+	RateLimiter       gin.HandlerFunc
+	OpenAPIValidation gin.HandlerFunc
+	GraphQLLimits     graph.Limits
+	// Stream serves /stream when set, keeping the connections alive every
+	// StreamHeartbeat.
+	Stream          *stream.Hub
+	StreamHeartbeat time.Duration
+	// Deprecation is announced on the unversioned routes and on the /v1
+	// routes replaced in /v2.
+	Deprecation middleware.Deprecation
+}
+
+// NewRouter builds the API: the observability middlewares, then the routes of
+// every version. The unversioned routes are those of /v1, deprecated, kept
+// for the clients that haven't moved to a version yet.
+func NewRouter(opts RouterOptions) *gin.Engine {
+	router := gin.New()
+	// handlers pass the gin context to the store, it has to carry the span
+	router.ContextWithFallback = true
+	router.Use(otelgin.Middleware(tracing.ServiceName))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger())
+	router.Use(gin.Recovery())
+	router.Use(middleware.Metrics())
+	DocsRoutes(router)
+	if opts.RateLimiter != nil {
+		router.Use(opts.RateLimiter)
+	}
+	if opts.OpenAPIValidation != nil {
+		router.Use(opts.OpenAPIValidation)
+	}
+	ImageRoutes(router)
+
+	// groups copy the middlewares of the router, they are made once all of
+	// them are in use
+	legacy := router.Group("/", middleware.Deprecated(opts.Deprecation, func(c *gin.Context) string {
+		return "/v1" + c.Request.URL.Path
+	}))
+	V1Routes(legacy, opts)
+	V1Routes(router.Group("/v1"), opts)
+	V2Routes(router.Group("/v2"), opts)
+
+	return router
+}
+
+// V1Routes serves the public routes, then the routes behind Authentication,
+// under the paths the API started with.
+func V1Routes(api *gin.RouterGroup, opts RouterOptions) {
+	UserRoutes(api)
+	api.Use(middleware.Authentication())
+
+	FoodRoutes(api)
+	MenuRoutes(api)
+	SearchRoutes(api)
+	TableRoutes(api)
+	OrderItemRoutes(api, opts.Deprecation)
+	OrderRoutes(api)
+	InvoiceRoutes(api)
+	WebhookRoutes(api)
+	GraphQLRoutes(api, opts.GraphQLLimits)
+	if opts.Stream != nil {
+		StreamRoutes(api, opts.Stream, opts.StreamHeartbeat)
+	}
+}
+
+// V2Routes serves the routes of V1Routes, the order items under resource
+// paths.
+func V2Routes(api *gin.RouterGroup, opts RouterOptions) {
+	UserRoutes(api)
+	api.Use(middleware.Authentication())
+
+	FoodRoutes(api)
+	MenuRoutes(api)
+	SearchRoutes(api)
+	TableRoutes(api)
+	OrderItemV2Routes(api)
+	OrderRoutes(api)
+	InvoiceRoutes(api)
+	WebhookRoutes(api)
+	GraphQLRoutes(api, opts.GraphQLLimits)
+	if opts.Stream != nil {
+		StreamRoutes(api, opts.Stream, opts.StreamHeartbeat)
+	}
+}
@@ -0,0 +1,434 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/cache"
+	"restaurant_management/controllers"
+	"restaurant_management/routes"
+	"restaurant_management/store"
+	"testing"
+	"time"
+)
+
+// api drives the router as the signed up user.
+type api struct {
+	t      *testing.T
+	router *gin.Engine
+	token  string
+}
+
+// newAPI serves the API over a cached in-memory store, or over PostgreSQL
+// when POSTGRES_TEST_URL is set.
+func newAPI(t *testing.T) *api {
+	t.Helper()
+	if postgresURL != "" {
+		return newAPIOver(t, newPostgresStore(t))
+	}
+	return newAPIOver(t, store.NewCachedStore(store.NewMemoryStore(), cache.NewLRU(100), time.Minute))
+}
+
+func newAPIOver(t *testing.T, s store.Store) *api {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	controllers.UseStore(s)
+
+	a := &api{t: t, router: routes.NewRouter(routes.RouterOptions{})}
+
+	a.expect(http.StatusOK, "POST", "/users/signup", gin.H{
+		"first_name": "Test",
+		"last_name":  "Waiter",
+		"email":      "waiter@example.com",
+		"password":   "secret-password",
+		"phone":      "0102030405",
+	}, nil)
+
+	var user struct {
+		Token string `json:"token"`
+	}
+	a.expect(http.StatusOK, "POST", "/users/login", gin.H{
+		"email":    "waiter@example.com",
+		"password": "secret-password",
+	}, &user)
+	if user.Token == "" {
+		t.Fatal("login returned no token")
+	}
+	a.token = user.Token
+
+	return a
+}
+
+func (a *api) do(method, path string, body interface{}) *httptest.ResponseRecorder {
+	a.t.Helper()
+	return a.doWithHeader(method, path, body, nil)
+}
+
+func (a *api) doWithHeader(method, path string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+	a.t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			a.t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		req.Header.Set("token", a.token)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	w := httptest.NewRecorder()
+	a.router.ServeHTTP(w, req)
+	return w
+}
+
+// expect sends the request, checks the status and decodes the response into
+// out when it isn't nil.
+func (a *api) expect(status int, method, path string, body, out interface{}) {
+	a.t.Helper()
+
+	w := a.do(method, path, body)
+	if w.Code != status {
+		a.t.Fatalf("%s %s: expected %d, got %d: %s", method, path, status, w.Code, w.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			a.t.Fatalf("%s %s: %v: %s", method, path, err, w.Body.String())
+		}
+	}
+}
+
+type inserted struct {
+	InsertedID  string
+	InsertedIDs []string
+}
+
+func (a *api) create(path string, body interface{}) string {
+	a.t.Helper()
+
+	var result inserted
+	a.expect(http.StatusOK, "POST", path, body, &result)
+	return result.InsertedID
+}
+
+func (a *api) createMenu() string {
+	start := time.Now().Add(-time.Hour)
+	return a.create("/menus", gin.H{
+		"name":       "Lunch",
+		"category":   "main",
+		"start_date": start,
+		"end_date":   start.Add(24 * time.Hour),
+	})
+}
+
+func (a *api) createFood(menuId, name string, price float64) string {
+	return a.create("/foods", gin.H{"name": name, "price": price, "food_image": "food.png", "menu_id": menuId})
+}
+
+func (a *api) createTable(number int) string {
+	return a.create("/tables", gin.H{"number_of_guests": 4, "table_number": number})
+}
+
+// order creates an order through POST /orderItems and returns its ID.
+func (a *api) order(tableId string, foodIds ...string) string {
+	a.t.Helper()
+
+	var items []gin.H
+	for _, foodId := range foodIds {
+		items = append(items, gin.H{"food_id": foodId, "quantity": "M"})
+	}
+
+	var result inserted
+	a.expect(http.StatusOK, "POST", "/orderItems", gin.H{"table_id": tableId, "order_items": items}, &result)
+	if len(result.InsertedIDs) != len(foodIds) {
+		a.t.Fatalf("expected %d order items, got %v", len(foodIds), result.InsertedIDs)
+	}
+
+	var item struct {
+		Order_id string `json:"order_id"`
+	}
+	a.expect(http.StatusOK, "GET", "/orderItems/"+result.InsertedIDs[0], nil, &item)
+	return item.Order_id
+}
+
+func (a *api) tableStatus(tableId string) string {
+	a.t.Helper()
+
+	var table struct {
+		Table_status string `json:"table_status"`
+	}
+	a.expect(http.StatusOK, "GET", "/tables/"+tableId, nil, &table)
+	return table.Table_status
+}
+
+func (a *api) orderStatus(orderId string) string {
+	a.t.Helper()
+
+	var order struct {
+		Order_status string `json:"order_status"`
+	}
+	a.expect(http.StatusOK, "GET", "/orders/"+orderId, nil, &order)
+	return order.Order_status
+}
+
+func TestSignUpAndLogIn(t *testing.T) {
+	a := newAPI(t)
+
+	if w := a.do("POST", "/users/signup", gin.H{
+		"first_name": "Other",
+		"last_name":  "Waiter",
+		"email":      "waiter@example.com",
+		"password":   "secret-password",
+		"phone":      "0600000000",
+	}); w.Code == http.StatusOK {
+		t.Fatalf("expected a duplicate email to be rejected, got %s", w.Body.String())
+	}
+
+	if w := a.do("POST", "/users/login", gin.H{"email": "waiter@example.com", "password": "wrong-password"}); w.Code == http.StatusOK {
+		t.Fatalf("expected a wrong password to be rejected, got %s", w.Body.String())
+	}
+
+	a.expect(http.StatusOK, "GET", "/menus", nil, nil)
+
+	a.token = "not-a-token"
+	if w := a.do("GET", "/menus", nil); w.Code == http.StatusOK {
+		t.Fatalf("expected an invalid token to be rejected, got %s", w.Body.String())
+	}
+
+	a.token = ""
+	if w := a.do("GET", "/menus", nil); w.Code == http.StatusOK {
+		t.Fatalf("expected a missing token to be rejected, got %s", w.Body.String())
+	}
+}
+
+func TestMenuAndFood(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	foodId := a.createFood(menuId, "Soup", 4.567)
+
+	var food struct {
+		Name    string  `json:"name"`
+		Price   float64 `json:"price"`
+		Menu_id string  `json:"menu_id"`
+	}
+	a.expect(http.StatusOK, "GET", "/foods/"+foodId, nil, &food)
+	if food.Name != "Soup" || food.Price != 4.57 || food.Menu_id != menuId {
+		t.Fatalf("unexpected food %+v", food)
+	}
+
+	a.createFood(menuId, "Salad", 6)
+	a.createFood(menuId, "Stew", 9)
+
+	var page []struct {
+		Total_count int               `json:"total_count"`
+		Food_items  []json.RawMessage `json:"food_items"`
+	}
+	a.expect(http.StatusOK, "GET", "/foods?recordPerPage=2&page=2", nil, &page)
+	if len(page) != 1 || page[0].Total_count != 3 || len(page[0].Food_items) != 1 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	if w := a.do("POST", "/foods", gin.H{"name": "Ghost", "price": 1, "food_image": "x", "menu_id": "missing"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a food of a missing menu to be rejected, got %d", w.Code)
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	foodId := a.createFood(menuId, "Soup", 5)
+
+	for _, path := range []string{"/menus", "/menus/" + menuId, "/foods?recordPerPage=10&page=1", "/foods/" + foodId} {
+		w := a.do("GET", path, nil)
+		etag := w.Header().Get("ETag")
+		if w.Code != http.StatusOK || etag == "" {
+			t.Fatalf("GET %s: expected 200 with an ETag, got %d %q", path, w.Code, etag)
+		}
+		if cacheControl := w.Header().Get("Cache-Control"); cacheControl != "private, no-cache" {
+			t.Fatalf("GET %s: unexpected Cache-Control %q", path, cacheControl)
+		}
+
+		w = a.doWithHeader("GET", path, nil, http.Header{"If-None-Match": {etag}})
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Fatalf("GET %s: expected 304 without a body, got %d: %s", path, w.Code, w.Body.String())
+		}
+
+		w = a.doWithHeader("GET", path, nil, http.Header{"If-None-Match": {`"stale"`}})
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200 for a stale ETag, got %d", path, w.Code)
+		}
+	}
+
+	etag := a.do("GET", "/foods/"+foodId, nil).Header().Get("ETag")
+	a.expect(http.StatusOK, "PATCH", "/foods/"+foodId, gin.H{"name": "Broth"}, nil)
+
+	w := a.doWithHeader("GET", "/foods/"+foodId, nil, http.Header{"If-None-Match": {etag}})
+	var food struct {
+		Name string `json:"name"`
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the updated food to be sent again, got %d", w.Code)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &food); err != nil || food.Name != "Broth" {
+		t.Fatalf("expected the cached food to be invalidated, got %s", w.Body.String())
+	}
+
+	var page []struct {
+		Total_count int `json:"total_count"`
+	}
+	a.createFood(menuId, "Salad", 6)
+	a.expect(http.StatusOK, "GET", "/foods?recordPerPage=10&page=1", nil, &page)
+	if len(page) != 1 || page[0].Total_count != 2 {
+		t.Fatalf("expected the cached page to be invalidated, got %+v", page)
+	}
+
+	var menu struct {
+		Name string `json:"name"`
+	}
+	a.expect(http.StatusOK, "PATCH", "/menus/"+menuId, gin.H{"name": "Brunch"}, nil)
+	a.expect(http.StatusOK, "GET", "/menus/"+menuId, nil, &menu)
+	if menu.Name != "Brunch" {
+		t.Fatalf("expected the cached menu to be invalidated, got %+v", menu)
+	}
+}
+
+func TestCreateOrderItem(t *testing.T) {
+	a := newAPI(t)
+
+	foodId := a.createFood(a.createMenu(), "Soup", 5)
+	tableId := a.createTable(1)
+
+	// one invalid item rejects the whole order
+	w := a.do("POST", "/orderItems", gin.H{"table_id": tableId, "order_items": []gin.H{
+		{"food_id": foodId, "quantity": "M"},
+		{"food_id": foodId, "quantity": "XXL"},
+	}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid quantity to be rejected, got %d", w.Code)
+	}
+
+	var orders []json.RawMessage
+	a.expect(http.StatusOK, "GET", "/orders?recordPerPage=10&page=1", nil, &orders)
+	if len(orders) != 0 {
+		t.Fatalf("expected no order after a rejected request, got %s", orders)
+	}
+	if status := a.tableStatus(tableId); status != "FREE" {
+		t.Fatalf("expected the table to stay free, got %q", status)
+	}
+
+	orderId := a.order(tableId, foodId, foodId)
+
+	if status := a.orderStatus(orderId); status != "OPEN" {
+		t.Fatalf("expected an open order, got %q", status)
+	}
+	if status := a.tableStatus(tableId); status != "OCCUPIED" {
+		t.Fatalf("expected the table to be occupied, got %q", status)
+	}
+
+	if w := a.do("POST", "/orderItems", gin.H{"table_id": "missing", "order_items": []gin.H{}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a missing table to be rejected, got %d", w.Code)
+	}
+}
+
+func TestItemsByOrderTotals(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	steak := a.createFood(menuId, "Steak", 20.25)
+	tableId := a.createTable(12)
+
+	orderId := a.order(tableId, soup, steak, steak)
+	// another order must not be counted
+	a.order(a.createTable(13), soup)
+
+	var summaries []struct {
+		Payment_due  float64 `json:"payment_due"`
+		Total_count  int     `json:"total_count"`
+		Table_number int     `json:"table_number"`
+		Order_items  []struct {
+			Food_name string  `json:"food_name"`
+			Price     float64 `json:"price"`
+			Order_id  string  `json:"order_id"`
+			Table_id  string  `json:"table_id"`
+		} `json:"order_items"`
+	}
+	a.expect(http.StatusOK, "GET", "/orderItems-order/"+orderId, nil, &summaries)
+
+	if len(summaries) != 1 {
+		t.Fatalf("expected one summary, got %d", len(summaries))
+	}
+	summary := summaries[0]
+	if summary.Payment_due != 45 || summary.Total_count != 3 || summary.Table_number != 12 || len(summary.Order_items) != 3 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	for _, item := range summary.Order_items {
+		if item.Order_id != orderId || item.Table_id != tableId || item.Food_name == "" || item.Price == 0 {
+			t.Fatalf("unexpected order item %+v", item)
+		}
+	}
+}
+
+func TestInvoicePayment(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	tableId := a.createTable(3)
+	orderId := a.order(tableId, soup, soup)
+
+	invoiceId := a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD"})
+
+	var invoice struct {
+		Payment_status string
+		Payment_due    float64
+		Table_number   int
+		Order_details  []json.RawMessage
+	}
+	a.expect(http.StatusOK, "GET", "/invoices/"+invoiceId, nil, &invoice)
+	if invoice.Payment_status != "PENDING" || invoice.Payment_due != 9 || invoice.Table_number != 3 || len(invoice.Order_details) != 2 {
+		t.Fatalf("unexpected invoice %+v", invoice)
+	}
+	if status := a.tableStatus(tableId); status != "OCCUPIED" {
+		t.Fatalf("expected the table to stay occupied until payment, got %q", status)
+	}
+
+	if w := a.do("PATCH", "/invoices/"+invoiceId, gin.H{"payment_status": "REFUNDED"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an unknown payment status to be rejected, got %d", w.Code)
+	}
+
+	a.expect(http.StatusOK, "PATCH", "/invoices/"+invoiceId, gin.H{"payment_status": "PAID"}, nil)
+
+	if status := a.orderStatus(orderId); status != "PAID" {
+		t.Fatalf("expected the order to be paid, got %q", status)
+	}
+	if status := a.tableStatus(tableId); status != "FREE" {
+		t.Fatalf("expected the table to be freed, got %q", status)
+	}
+
+	// an invoice created as paid settles its order right away
+	otherTable := a.createTable(4)
+	otherOrder := a.order(otherTable, soup)
+	a.create("/invoices", gin.H{"order_id": otherOrder, "payment_method": "CASH", "payment_status": "PAID"})
+
+	if status := a.orderStatus(otherOrder); status != "PAID" {
+		t.Fatalf("expected the order to be paid, got %q", status)
+	}
+	if status := a.tableStatus(otherTable); status != "FREE" {
+		t.Fatalf("expected the table to be freed, got %q", status)
+	}
+
+	if w := a.do("POST", "/invoices", gin.H{"order_id": "missing"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invoice of a missing order to be rejected, got %d", w.Code)
+	}
+}
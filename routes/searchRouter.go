@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "restaurant_management/controllers"
+)
+
+func SearchRoutes(routes gin.IRouter) {
+	routes.GET("/search", controller.Search())
+}
@@ -0,0 +1,93 @@
+package routes_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type searchResult struct {
+	Type      string
+	Id        string
+	Name      string
+	Menu_name string
+	Score     float64
+}
+
+func (a *api) search(query url.Values) []searchResult {
+	a.t.Helper()
+
+	var results []searchResult
+	a.expect(http.StatusOK, "GET", "/search?"+query.Encode(), nil, &results)
+	return results
+}
+
+func names(results []searchResult) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	return names
+}
+
+func TestSearch(t *testing.T) {
+	a := newVersionedAPI(t)
+
+	menuId := a.createMenu()
+	food := func(name, description string, tags ...string) {
+		a.create("/foods", gin.H{"name": name, "description": description, "tags": append([]string{}, tags...), "price": 9, "food_image": "food.png", "menu_id": menuId})
+	}
+	food("Chicken Curry", "Tender chicken in a creamy sauce", "halal")
+	food("Chicken Wings", "Six wings and a smoky sauce")
+	food("Caesar Salad", "Romaine, croutons and grilled chicken")
+	food("Chocolate Cake", "Dark chocolate sponge", "vegetarian")
+	food("Falafel Wrap", "Chickpeas and tahini", "vegan")
+
+	// typos and words being typed match, the names first
+	for query, expected := range map[string][]string{
+		"chiken":        {"Chicken Curry", "Chicken Wings", "Caesar Salad"},
+		"chicken curry": {"Chicken Curry"},
+		"curry chicken": {"Chicken Curry"},
+		"choc":          {"Chocolate Cake"},
+		"chocolat cak":  {"Chocolate Cake"},
+		"vegan":         {"Falafel Wrap"},
+		"tahini":        {"Falafel Wrap"},
+		"sauce":         {"Chicken Curry", "Chicken Wings"},
+		"lunch":         {"Lunch"},
+		"pizza":         nil,
+		"chicken pizza": nil,
+		"wings tahini":  nil,
+	} {
+		results := a.search(url.Values{"q": {query}})
+		if got := names(results); !equal(got, expected) {
+			t.Errorf("%q: expected %v, got %v", query, expected, got)
+		}
+	}
+
+	results := a.search(url.Values{"q": {"chicken"}, "limit": {"2"}})
+	if !equal(names(results), []string{"Chicken Curry", "Chicken Wings"}) || results[0].Type != "food" || results[0].Menu_name != "Lunch" || results[0].Score == 0 {
+		t.Fatalf("unexpected results %+v", results)
+	}
+	if results := a.search(url.Values{"q": {"lunch"}}); results[0].Type != "menu" || results[0].Id != menuId {
+		t.Fatalf("expected the menu, got %+v", results)
+	}
+	if results := a.search(url.Values{"q": {"main"}, "type": {"food"}}); len(results) != 0 {
+		t.Fatalf("expected no food, got %+v", results)
+	}
+	if results := a.search(url.Values{"q": {"main"}, "type": {"menu"}}); len(results) != 1 {
+		t.Fatalf("expected the menu of the category, got %+v", results)
+	}
+
+	for _, query := range []url.Values{
+		{},
+		{"q": {"  "}},
+		{"q": {"chicken"}, "type": {"drink"}},
+		{"q": {"chicken"}, "limit": {"0"}},
+		{"q": {"chicken"}, "limit": {"many"}},
+	} {
+		if w := a.do("GET", "/search?"+query.Encode(), nil); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
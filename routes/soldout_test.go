@@ -0,0 +1,277 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/models"
+	"restaurant_management/service"
+	"restaurant_management/store"
+	"strings"
+	"testing"
+	"time"
+)
+
+type soldOutFood struct {
+	Food_id        string
+	Name           string
+	Sold_out       bool
+	Sold_out_until *time.Time
+	Portions_left  *int
+}
+
+func (a *api) soldOutFoods() []soldOutFood {
+	a.t.Helper()
+
+	var foods []soldOutFood
+	a.expect(http.StatusOK, "GET", "/foods/sold-out", nil, &foods)
+	return foods
+}
+
+func TestSoldOutFoods(t *testing.T) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+	bus := events.NewBus()
+	sub := subscribe(t, bus, events.FoodSoldOut, events.FoodRestored)
+	runRelay(t, s, bus)
+
+	expectEvent := func(eventType, foodId string) {
+		t.Helper()
+		event := sub.next()
+		var food struct{ Food_id string }
+		if json.Unmarshal(event.Data, &food) != nil || event.Type != eventType || food.Food_id != foodId {
+			t.Fatalf("expected %s for %s, got %+v", eventType, foodId, event)
+		}
+	}
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	salad := a.createFood(menuId, "Salad", 7)
+	special := a.create("/foods", gin.H{"name": "Special", "price": 15, "food_image": "special.png", "menu_id": menuId, "daily_limit": 3})
+	if foods := a.soldOutFoods(); len(foods) != 1 || foods[0].Food_id != special || foods[0].Sold_out || *foods[0].Portions_left != 3 {
+		t.Fatalf("expected the special to be counted, got %+v", foods)
+	}
+
+	// a food sold out can't be ordered
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	a.expect(http.StatusOK, "PATCH", "/foods/"+soup, gin.H{"sold_out": true, "sold_out_until": until}, nil)
+	expectEvent(events.FoodSoldOut, soup)
+	w := a.do("POST", "/orderItems", gin.H{"table_id": a.createTable(1), "order_items": []gin.H{
+		{"food_id": salad, "quantity": "M"}, {"food_id": soup, "quantity": "M"},
+	}})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Soup is sold out") {
+		t.Fatalf("expected the soup to be sold out, got %d: %s", w.Code, w.Body.String())
+	}
+	a.expect(http.StatusOK, "PATCH", "/foods/"+salad, gin.H{"sold_out": true}, nil)
+	expectEvent(events.FoodSoldOut, salad)
+
+	for _, update := range []gin.H{
+		{"sold_out_until": until},
+		{"sold_out": false, "sold_out_until": until},
+		{"sold_out": true, "sold_out_until": time.Now().Add(-time.Minute)},
+		{"daily_limit": -1},
+	} {
+		if w := a.do("PATCH", "/foods/"+soup, update); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %v to be rejected, got %d: %s", update, w.Code, w.Body.String())
+		}
+	}
+
+	// the daily limit is taken by the orders, nothing being taken by a
+	// rejected one
+	tableId := a.createTable(2)
+	a.order(tableId, special, special)
+	w = a.do("POST", "/orderItems", gin.H{"table_id": a.createTable(3), "order_items": []gin.H{
+		{"food_id": special, "quantity": "M"}, {"food_id": special, "quantity": "L"},
+	}})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Special has 1 portions left") {
+		t.Fatalf("expected the portions to run out, got %d: %s", w.Code, w.Body.String())
+	}
+	a.order(tableId, special)
+	expectEvent(events.FoodSoldOut, special)
+	if w := a.do("POST", "/orderItems", gin.H{"table_id": tableId, "order_items": []gin.H{{"food_id": special, "quantity": "M"}}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected the special to be sold out, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// the 86 list holds the foods sold out first
+	foods := a.soldOutFoods()
+	if len(foods) != 3 || foods[0].Name != "Salad" || foods[1].Name != "Soup" || foods[2].Name != "Special" {
+		t.Fatalf("unexpected 86 list %+v", foods)
+	}
+	if foods[0].Sold_out_until != nil || !foods[1].Sold_out_until.Equal(until) || !foods[2].Sold_out || *foods[2].Portions_left != 0 {
+		t.Fatalf("unexpected 86 list %+v", foods)
+	}
+
+	// the foods are put back on sale when their time comes
+	if err := service.RestoreFoods(context.Background(), until); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(events.FoodRestored, soup)
+	sub.none()
+	a.order(a.createTable(4), soup)
+	if err := service.RestoreFoods(context.Background(), time.Now().AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(events.FoodRestored, special)
+
+	a.expect(http.StatusOK, "PATCH", "/foods/"+salad, gin.H{"sold_out": false}, nil)
+	expectEvent(events.FoodRestored, salad)
+	a.expect(http.StatusOK, "PATCH", "/foods/"+special, gin.H{"daily_limit": 0}, nil)
+	sub.none()
+	if foods := a.soldOutFoods(); len(foods) != 0 {
+		t.Fatalf("expected an empty 86 list, got %+v", foods)
+	}
+}
+
+func TestSwapOrderItemFood(t *testing.T) {
+	a := newAPI(t)
+
+	menuId := a.createMenu()
+	salad := a.createFood(menuId, "Salad", 7)
+	soup := a.createFood(menuId, "Soup", 4.5)
+	special := a.create("/foods", gin.H{"name": "Special", "price": 15, "menu_id": menuId, "daily_limit": 1})
+	later := time.Now().Add(48 * time.Hour)
+	dinner := a.create("/menus", gin.H{"name": "Dinner", "category": "main", "start_date": later, "end_date": later.Add(time.Hour)})
+	steak := a.createFood(dinner, "Steak", 25)
+
+	var result inserted
+	a.expect(http.StatusOK, "POST", "/orderItems", gin.H{"table_id": a.createTable(1), "order_items": []gin.H{
+		{"food_id": salad, "quantity": "M"}, {"food_id": salad, "quantity": "M"},
+	}}, &result)
+	first, second := result.InsertedIDs[0], result.InsertedIDs[1]
+
+	// a food can't be swapped in when it couldn't be ordered
+	a.expect(http.StatusOK, "PATCH", "/foods/"+soup, gin.H{"sold_out": true}, nil)
+	for foodId, message := range map[string]string{soup: "Soup is sold out", steak: "Steak is not available"} {
+		w := a.do("PATCH", "/orderItems/"+first, gin.H{"food_id": foodId})
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), message) {
+			t.Errorf("expected %q, got %d: %s", message, w.Code, w.Body.String())
+		}
+	}
+
+	// swapping a food in takes a portion of it
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+first, gin.H{"food_id": special}, nil)
+	if foods := a.soldOutFoods(); len(foods) != 2 || foods[1].Food_id != special || !foods[1].Sold_out || *foods[1].Portions_left != 0 {
+		t.Fatalf("expected the special to be sold out, got %+v", foods)
+	}
+	if w := a.do("PATCH", "/orderItems/"+second, gin.H{"food_id": special}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected the special to be sold out, got %d: %s", w.Code, w.Body.String())
+	}
+	var item struct{ Food_id string }
+	a.expect(http.StatusOK, "GET", "/orderItems/"+second, nil, &item)
+	if item.Food_id != salad {
+		t.Fatalf("expected the salad to be kept, got %s", item.Food_id)
+	}
+
+	// keeping the food takes nothing
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+first, gin.H{"food_id": special, "quantity": "L"}, nil)
+}
+
+// standaloneStore runs the units of work without transactions, like a
+// standalone Mongo server, its foods being read before the sales of others
+// when stale is set.
+type standaloneStore struct {
+	store.Store
+	stale *bool
+}
+
+func (s standaloneStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s standaloneStore) Foods() store.FoodStore {
+	if *s.stale {
+		return staleFoods{s.Store.Foods()}
+	}
+	return s.Store.Foods()
+}
+
+type staleFoods struct{ store.FoodStore }
+
+func (f staleFoods) Get(ctx context.Context, foodId string) (models.Food, error) {
+	food, err := f.FoodStore.Get(ctx, foodId)
+	food.Portions_sold = 0
+	return food, err
+}
+
+func (f staleFoods) GetMany(ctx context.Context, foodIds []string) ([]models.Food, error) {
+	foods, err := f.FoodStore.GetMany(ctx, foodIds)
+	for i := range foods {
+		foods[i].Portions_sold = 0
+	}
+	return foods, err
+}
+
+func TestPortionsWithoutTransactions(t *testing.T) {
+	memory := store.NewMemoryStore()
+	stale := false
+	a := newAPIOver(t, standaloneStore{memory, &stale})
+
+	menuId := a.createMenu()
+	salad := a.createFood(menuId, "Salad", 7)
+	special := a.create("/foods", gin.H{"name": "Special", "price": 15, "menu_id": menuId, "daily_limit": 2})
+	tableId := a.createTable(1)
+	var result inserted
+	a.expect(http.StatusOK, "POST", "/orderItems", gin.H{"table_id": tableId, "order_items": []gin.H{
+		{"food_id": special, "quantity": "M"}, {"food_id": salad, "quantity": "M"},
+	}}, &result)
+	saladItem := result.InsertedIDs[1]
+
+	type written struct{ orders, items, events int }
+	count := func() written {
+		t.Helper()
+		ctx := context.Background()
+		_, orders, err := memory.Orders().Page(ctx, 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items, err := memory.OrderItems().All(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := memory.Outbox().Due(ctx, time.Now().Add(time.Hour), 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return written{int(orders), len(items), len(events)}
+	}
+	before := count()
+
+	// the portions asked are added up before anything is written
+	w := a.do("POST", "/orderItems", gin.H{"table_id": a.createTable(2), "order_items": []gin.H{
+		{"food_id": special, "quantity": "M"}, {"food_id": salad, "quantity": "M"}, {"food_id": special, "quantity": "L"},
+	}})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Special has 1 portions left") {
+		t.Fatalf("expected the special to run short, got %d: %s", w.Code, w.Body.String())
+	}
+	if after := count(); after != before {
+		t.Fatalf("expected nothing to be written, had %+v, got %+v", before, after)
+	}
+
+	// the portions are taken first, a sale made meanwhile leaves nothing
+	// behind either
+	stale = true
+	w = a.do("POST", "/orderItems", gin.H{"table_id": a.createTable(3), "order_items": []gin.H{
+		{"food_id": salad, "quantity": "M"}, {"food_id": special, "quantity": "M"}, {"food_id": special, "quantity": "L"},
+	}})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "portions left") {
+		t.Fatalf("expected the special to run short, got %d: %s", w.Code, w.Body.String())
+	}
+	if after := count(); after != before {
+		t.Fatalf("expected nothing to be written, had %+v, got %+v", before, after)
+	}
+
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+saladItem, gin.H{"food_id": special}, nil)
+	if w := a.do("PATCH", "/orderItems/"+saladItem, gin.H{"food_id": salad}); w.Code != http.StatusOK {
+		t.Fatalf("expected the salad to be swapped back, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := a.do("PATCH", "/orderItems/"+saladItem, gin.H{"food_id": special}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected the special to be sold out, got %d: %s", w.Code, w.Body.String())
+	}
+	var item struct{ Food_id string }
+	a.expect(http.StatusOK, "GET", "/orderItems/"+saladItem, nil, &item)
+	if item.Food_id != salad {
+		t.Fatalf("expected the salad to be kept, got %s", item.Food_id)
+	}
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"restaurant_management/stream"
+	"time"
+)
+
+func StreamRoutes(routes gin.IRouter, hub *stream.Hub, heartbeat time.Duration) {
+	routes.GET("/stream", stream.Handler(hub, heartbeat))
+}
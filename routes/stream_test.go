@@ -0,0 +1,290 @@
+package routes_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"restaurant_management/events"
+	"restaurant_management/routes"
+	"restaurant_management/store"
+	"restaurant_management/stream"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStreamAPI serves the API with /stream, the events of the outbox being
+// relayed to the hub.
+func newStreamAPI(t *testing.T) (*api, *httptest.Server) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+
+	hub := stream.NewHub()
+	bus := events.NewBus()
+	bus.AddTransport(hub)
+	runRelay(t, s, bus)
+
+	a.router = routes.NewRouter(routes.RouterOptions{Stream: hub, StreamHeartbeat: time.Second})
+	server := httptest.NewServer(a.router)
+	t.Cleanup(func() {
+		hub.Close()
+		server.Close()
+	})
+	return a, server
+}
+
+func streamURL(server *httptest.Server, channels ...string) string {
+	query := url.Values{"channel": channels}
+	return server.URL + "/stream?" + query.Encode()
+}
+
+// sse reads the Server-Sent Events of a stream.
+func (a *api) sse(server *httptest.Server, channels ...string) <-chan stream.Message {
+	a.t.Helper()
+
+	req, _ := http.NewRequest("GET", streamURL(server, channels...), nil)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("token", a.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	a.t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		a.t.Fatalf("expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	messages := make(chan stream.Message, 100)
+	go func() {
+		defer close(messages)
+
+		var name string
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var msg stream.Message
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg); err != nil || msg.Type != name {
+					a.t.Errorf("unexpected event %s: %s", name, line)
+				}
+				messages <- msg
+			}
+		}
+	}()
+	return messages
+}
+
+func nextMessage(t *testing.T, messages <-chan stream.Message) stream.Message {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+		return stream.Message{}
+	}
+}
+
+func noMessage(t *testing.T, messages <-chan stream.Message) {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		t.Fatalf("unexpected message %+v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func expectMessage(t *testing.T, messages <-chan stream.Message, eventType string, channels ...string) stream.Message {
+	t.Helper()
+	msg := nextMessage(t, messages)
+	if msg.Type != eventType || strings.Join(msg.Channels, ",") != strings.Join(channels, ",") {
+		t.Fatalf("expected %s on %v, got %s on %v", eventType, channels, msg.Type, msg.Channels)
+	}
+	return msg
+}
+
+func TestStreamEvents(t *testing.T) {
+	a, server := newStreamAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	table, other := a.createTable(1), a.createTable(2)
+
+	tableChannel := stream.TableChannel(table)
+	kitchen := stream.StationChannel("main")
+	messages := a.sse(server, tableChannel, kitchen)
+
+	orderId := a.order(table, soup)
+	expectMessage(t, messages, events.OrderPlaced, tableChannel)
+	item := expectMessage(t, messages, events.ItemAdded, tableChannel, kitchen)
+	var orderItem struct{ Order_id string }
+	if json.Unmarshal(item.Data, &orderItem); orderItem.Order_id != orderId || item.ID == "" {
+		t.Fatalf("unexpected order item %s", item.Data)
+	}
+	expectMessage(t, messages, events.TableOccupied, tableChannel)
+
+	// the other table only reaches the kitchen
+	a.order(other, soup)
+	expectMessage(t, messages, events.ItemAdded, kitchen)
+	noMessage(t, messages)
+
+	a.create("/invoices", map[string]string{"order_id": orderId, "payment_method": "CASH", "payment_status": "PAID"})
+	expectMessage(t, messages, events.InvoicePaid, tableChannel)
+	expectMessage(t, messages, events.TableFreed, tableChannel)
+}
+
+func TestStreamWebSocket(t *testing.T) {
+	a, server := newStreamAPI(t)
+
+	// browsers can't set headers on a WebSocket, the token is a query parameter
+	query := url.Values{"channel": {stream.Tables}, "token": {a.token}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/stream?"+query.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	messages := make(chan stream.Message, 100)
+	replies := make(chan map[string]string, 10)
+	go func() {
+		defer close(messages)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg struct {
+				stream.Message
+				Action string
+				Error  string
+			}
+			json.Unmarshal(data, &msg)
+			if msg.Action != "" {
+				replies <- map[string]string{"action": msg.Action, "error": msg.Error}
+				continue
+			}
+			messages <- msg.Message
+		}
+	}()
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	table := a.createTable(1)
+
+	a.order(table, soup)
+	if msg := expectMessage(t, messages, events.TableOccupied, stream.Tables); msg.ID == "" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+
+	conn.WriteJSON(map[string]string{"action": "subscribe", "channel": stream.Orders})
+	if reply := <-replies; reply["action"] != "subscribe" || reply["error"] != "" {
+		t.Fatalf("unexpected reply %v", reply)
+	}
+	conn.WriteJSON(map[string]string{"action": "subscribe", "channel": "everything"})
+	if reply := <-replies; reply["error"] == "" {
+		t.Fatalf("expected an unknown channel to be refused, got %v", reply)
+	}
+	conn.WriteJSON(map[string]string{"action": "unsubscribe", "channel": stream.Tables})
+	<-replies
+
+	a.order(a.createTable(2), soup)
+	expectMessage(t, messages, events.OrderPlaced, stream.Orders)
+	expectMessage(t, messages, events.ItemAdded, stream.Orders)
+	noMessage(t, messages)
+}
+
+func TestStreamRejected(t *testing.T) {
+	a, server := newStreamAPI(t)
+
+	for _, path := range []string{"/stream", "/stream?channel=everything", "/stream?channel=table:"} {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("token", a.token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("GET %s: expected 400, got %d", path, resp.StatusCode)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", streamURL(server, stream.Orders), nil)
+	req.Header.Set("token", a.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotAcceptable {
+		t.Fatalf("expected a request accepting no stream to be refused, got %d", resp.StatusCode)
+	}
+
+	// the token is only read from the query by streams
+	resp, err = http.Get(server.URL + "/menus?token=" + a.token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Fatal("expected a token in the query to be refused outside of streams")
+	}
+}
+
+func TestStreamChanges(t *testing.T) {
+	a, server := newStreamAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	table, other := a.createTable(1), a.createTable(2)
+
+	tableChannel, otherChannel := stream.TableChannel(table), stream.TableChannel(other)
+	kitchen := stream.StationChannel("main")
+	messages := a.sse(server, tableChannel, otherChannel, kitchen)
+
+	orderId := a.order(table, soup)
+	expectMessage(t, messages, events.OrderPlaced, tableChannel)
+	item := expectMessage(t, messages, events.ItemAdded, tableChannel, kitchen)
+	expectMessage(t, messages, events.TableOccupied, tableChannel)
+	var orderItem struct{ Order_item_id string }
+	json.Unmarshal(item.Data, &orderItem)
+
+	// the order moved to the other table takes it and frees its own
+	orderDate := time.Now().Add(time.Hour).Format(time.RFC3339)
+	a.expect(http.StatusOK, "PATCH", "/orders/"+orderId, map[string]string{"table_id": other, "order_date": orderDate}, nil)
+	expectMessage(t, messages, events.OrderUpdated, otherChannel)
+	expectMessage(t, messages, events.TableOccupied, otherChannel)
+	expectMessage(t, messages, events.TableFreed, tableChannel)
+
+	a.expect(http.StatusOK, "PATCH", "/orderItems/"+orderItem.Order_item_id, map[string]string{"quantity": "L"}, nil)
+	updated := expectMessage(t, messages, events.ItemUpdated, otherChannel, kitchen)
+	var quantity struct{ Quantity string }
+	if json.Unmarshal(updated.Data, &quantity); quantity.Quantity != "L" {
+		t.Fatalf("expected the item changed, got %s", updated.Data)
+	}
+
+	var invoice struct{ InsertedID string }
+	a.expect(http.StatusOK, "POST", "/invoices", map[string]string{"order_id": orderId, "payment_method": "CASH"}, &invoice)
+	expectMessage(t, messages, events.InvoiceCreated, otherChannel)
+	a.expect(http.StatusOK, "PATCH", "/invoices/"+invoice.InsertedID, map[string]string{"payment_method": "CARD"}, nil)
+	expectMessage(t, messages, events.InvoiceUpdated, otherChannel)
+
+	a.expect(http.StatusOK, "DELETE", "/orderItems/"+orderItem.Order_item_id, nil, nil)
+	expectMessage(t, messages, events.ItemDeleted, otherChannel, kitchen)
+	a.expect(http.StatusOK, "DELETE", "/orders/"+orderId, nil, nil)
+	expectMessage(t, messages, events.OrderDeleted, otherChannel)
+	noMessage(t, messages)
+
+	// an order missing moves nothing
+	if w := a.do("PATCH", "/orders/missing", map[string]string{"table_id": table, "order_date": orderDate}); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a missing order not to be found, got %d", w.Code)
+	}
+	noMessage(t, messages)
+}
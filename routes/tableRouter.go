@@ -5,7 +5,7 @@ import (
 	controller "restaurant_management/controllers"
 )
 
-func TableRoutes(routes *gin.Engine) {
+func TableRoutes(routes gin.IRouter) {
 	routes.GET("/tables", controller.GetTables())
 	routes.GET("/tables/:id", controller.GetTable())
 	routes.POST("/tables", controller.CreateTable())
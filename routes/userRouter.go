@@ -3,11 +3,13 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	controller "restaurant_management/controllers"
+	"restaurant_management/middleware"
 )
 
-func UserRoutes(routes *gin.Engine) {
+func UserRoutes(routes gin.IRouter) {
 	routes.GET("/users", controller.GetUsers())
 	routes.GET("/users/:id", controller.GetUser())
 	routes.POST("/users/signup", controller.SignUp())
 	routes.POST("/users/login", controller.LogIn())
+	routes.PUT("/users/:id/avatar", middleware.Authentication(), controller.UploadAvatar())
 }
@@ -0,0 +1,106 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"restaurant_management/docs"
+	"restaurant_management/middleware"
+	"restaurant_management/routes"
+	"testing"
+	"time"
+)
+
+var deprecation = middleware.Deprecation{
+	At:     time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+	Sunset: time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+}
+
+// newVersionedAPI serves the API with deprecation dates and the requests
+// checked against the OpenAPI document.
+func newVersionedAPI(t *testing.T) *api {
+	a := newAPI(t)
+
+	doc, err := docs.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	validation, err := middleware.OpenAPIValidation(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.router = routes.NewRouter(routes.RouterOptions{OpenAPIValidation: validation, Deprecation: deprecation})
+	return a
+}
+
+// expectDeprecated checks that the route is deprecated in favour of
+// successor, or isn't deprecated when successor is empty.
+func (a *api) expectDeprecated(path, successor string) {
+	a.t.Helper()
+
+	w := a.do("GET", path, nil)
+	if w.Code != http.StatusOK {
+		a.t.Fatalf("GET %s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+	}
+	header := w.Header()
+	if successor == "" {
+		if header.Get("Deprecation") != "" || header.Get("Sunset") != "" || header.Get("Link") != "" {
+			a.t.Fatalf("GET %s: expected no deprecation, got %v", path, header)
+		}
+		return
+	}
+	if header.Get("Deprecation") != "@1792368000" || header.Get("Sunset") != "Mon, 19 Apr 2027 00:00:00 GMT" {
+		a.t.Fatalf("GET %s: expected the deprecation to be dated, got %v", path, header)
+	}
+	if link := header.Get("Link"); link != "<"+successor+`>; rel="successor-version"` {
+		a.t.Fatalf("GET %s: expected %s to succeed it, got %s", path, successor, link)
+	}
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	a := newVersionedAPI(t)
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	orderId := a.order(a.createTable(1), soup)
+
+	a.expectDeprecated("/menus/"+menuId, "/v1/menus/"+menuId)
+	a.expectDeprecated("/v1/menus/"+menuId, "")
+	a.expectDeprecated("/v2/menus/"+menuId, "")
+
+	// the order items moved in /v2
+	a.expectDeprecated("/orderItems-order/"+orderId, "/v2/orders/"+orderId+"/items")
+	a.expectDeprecated("/v1/orderItems-order/"+orderId, "/v2/orders/"+orderId+"/items")
+	a.expectDeprecated("/v1/orderItems", "/v2/order-items")
+	a.expectDeprecated("/v2/orders/"+orderId+"/items", "")
+
+	var legacy, items []struct{ Total_count int }
+	a.expect(http.StatusOK, "GET", "/orderItems-order/"+orderId, nil, &legacy)
+	a.expect(http.StatusOK, "GET", "/v2/orders/"+orderId+"/items", nil, &items)
+	if len(items) != 1 || items[0].Total_count != 1 || len(legacy) != 1 || legacy[0] != items[0] {
+		t.Fatalf("expected the items of the order, got %+v and %+v", items, legacy)
+	}
+
+	var list []struct{ Order_item_id string }
+	a.expect(http.StatusOK, "GET", "/v2/order-items", nil, &list)
+	if len(list) != 1 {
+		t.Fatalf("expected one order item, got %+v", list)
+	}
+	a.expect(http.StatusOK, "GET", "/v2/order-items/"+list[0].Order_item_id, nil, nil)
+	a.expect(http.StatusNotFound, "GET", "/v2/orderItems", nil, nil)
+
+	// every version checks the tokens and the documented requests
+	a.token = ""
+	a.expect(http.StatusInternalServerError, "GET", "/v2/menus", nil, nil)
+	var user struct {
+		Token string `json:"token"`
+	}
+	a.expect(http.StatusOK, "POST", "/v2/users/login", gin.H{"email": "waiter@example.com", "password": "secret-password"}, &user)
+	a.token = user.Token
+	w := a.do("POST", "/v2/order-items", gin.H{"table_id": "missing"})
+	var body struct{ Error string }
+	if json.Unmarshal(w.Body.Bytes(), &body); w.Code != http.StatusBadRequest || body.Error == "" {
+		t.Fatalf("expected a request missing the items to be rejected, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	controller "restaurant_management/controllers"
+)
+
+func WebhookRoutes(routes gin.IRouter) {
+	routes.GET("/webhooks", controller.GetWebhooks())
+	routes.GET("/webhooks/:id", controller.GetWebhook())
+	routes.POST("/webhooks", controller.CreateWebhook())
+	routes.PATCH("/webhooks/:id", controller.UpdateWebhook())
+	routes.DELETE("/webhooks/:id", controller.DeleteWebhook())
+	routes.GET("/webhooks/:id/deliveries", controller.GetWebhookDeliveries())
+	routes.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", controller.RedeliverWebhook())
+}
@@ -0,0 +1,346 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"restaurant_management/events"
+	"restaurant_management/helpers"
+	"restaurant_management/models"
+	"restaurant_management/service"
+	"restaurant_management/store"
+	"restaurant_management/webhook"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const webhookSecret = "a-secret-of-the-receiver"
+
+type received struct {
+	Event    string
+	Delivery string
+	Body     events.Event
+}
+
+// receiver records the signed deliveries it gets, answering status to them.
+type receiver struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu     sync.Mutex
+	status int
+	got    chan received
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{t: t, status: status, got: make(chan received, 100)}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if !webhook.Verify(webhookSecret, req.Header.Get(webhook.HeaderTimestamp), req.Header.Get(webhook.HeaderSignature), body, time.Minute) {
+			t.Errorf("delivery %s has a wrong signature", req.Header.Get(webhook.HeaderDelivery))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var event events.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("delivery body %s: %v", body, err)
+		}
+
+		r.mu.Lock()
+		status := r.status
+		r.mu.Unlock()
+		if status == http.StatusOK {
+			r.got <- received{req.Header.Get(webhook.HeaderEvent), req.Header.Get(webhook.HeaderDelivery), event}
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *receiver) answer(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *receiver) next() received {
+	r.t.Helper()
+	select {
+	case delivery := <-r.got:
+		return delivery
+	case <-time.After(5 * time.Second):
+		r.t.Fatal("no delivery received")
+		return received{}
+	}
+}
+
+// newWebhookAPI runs a dispatcher retrying fast over the store of the API,
+// the transport of the events of the outbox. The receivers of the tests
+// being local, it sends to the private networks.
+func newWebhookAPI(t *testing.T) *api {
+	a, _ := newDispatchingAPI(t, true)
+	return a
+}
+
+// newDispatchingAPI runs a dispatcher like newWebhookAPI, acting as a
+// manager of the webhooks.
+func newDispatchingAPI(t *testing.T, allowPrivateNetworks bool) (*api, store.Store) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+	a.actAs(s, "manager@example.com", helpers.ManageWebhooks)
+
+	dispatcher := webhook.NewDispatcher(s, webhook.Options{
+		MaxAttempts:          3,
+		Backoff:              time.Millisecond,
+		MaxBackoff:           10 * time.Millisecond,
+		Timeout:              time.Second,
+		PollInterval:         10 * time.Millisecond,
+		AllowPrivateNetworks: allowPrivateNetworks,
+	})
+	service.UseWebhooks(dispatcher)
+
+	bus := events.NewBus()
+	bus.AddTransport(dispatcher)
+	runRelay(t, s, bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go dispatcher.Run(ctx)
+	t.Cleanup(func() {
+		cancel()
+		service.UseWebhooks(nil)
+	})
+	return a, s
+}
+
+func (a *api) createWebhook(url string, events ...string) string {
+	return a.create("/webhooks", gin.H{"url": url, "events": events, "secret": webhookSecret})
+}
+
+type delivery struct {
+	Delivery_id     string
+	Event_id        string
+	Event           string
+	Status          string
+	Attempts        int
+	Response_status int
+	Last_error      string
+	Redelivery_of   string
+}
+
+// settled waits for the deliveries of a webhook not to be pending anymore.
+func (a *api) settled(webhookId string, count int) []delivery {
+	a.t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var deliveries []delivery
+		a.expect(http.StatusOK, "GET", "/webhooks/"+webhookId+"/deliveries", nil, &deliveries)
+
+		pending := len(deliveries) < count
+		for _, d := range deliveries {
+			pending = pending || d.Status == webhook.Pending
+		}
+		if !pending {
+			return deliveries
+		}
+		if time.Now().After(deadline) {
+			a.t.Fatalf("deliveries still pending: %+v", deliveries)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	a := newWebhookAPI(t)
+	r := newReceiver(t, http.StatusOK)
+
+	webhookId := a.createWebhook(r.server.URL, events.OrderPlaced, events.ItemAdded, events.InvoicePaid)
+
+	var got struct {
+		Url    string
+		Events []string
+		Secret *string
+		Active bool
+	}
+	a.expect(http.StatusOK, "GET", "/webhooks/"+webhookId, nil, &got)
+	if got.Url != r.server.URL || len(got.Events) != 3 || got.Secret != nil || !got.Active {
+		t.Fatalf("unexpected webhook %+v", got)
+	}
+
+	menuId := a.createMenu()
+	soup := a.createFood(menuId, "Soup", 4.5)
+	orderId := a.order(a.createTable(1), soup, soup)
+
+	counts := map[string]int{}
+	for i := 0; i < 3; i++ {
+		delivery := r.next()
+		if delivery.Event != delivery.Body.Type || delivery.Delivery == "" {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+		var data struct{ Order_id string }
+		json.Unmarshal(delivery.Body.Data, &data)
+		if data.Order_id != orderId {
+			t.Fatalf("expected an event of order %s, got %+v", orderId, data)
+		}
+		counts[delivery.Event]++
+	}
+	if counts[events.OrderPlaced] != 1 || counts[events.ItemAdded] != 2 {
+		t.Fatalf("unexpected events %v", counts)
+	}
+
+	invoiceId := a.create("/invoices", gin.H{"order_id": orderId, "payment_method": "CARD"})
+	a.expect(http.StatusOK, "PATCH", "/invoices/"+invoiceId, gin.H{"payment_status": "PAID"}, nil)
+	delivery := r.next()
+	var invoice struct{ Invoice_id string }
+	json.Unmarshal(delivery.Body.Data, &invoice)
+	if delivery.Event != events.InvoicePaid || invoice.Invoice_id != invoiceId {
+		t.Fatalf("expected the invoice to be paid, got %+v", delivery)
+	}
+
+	deliveries := a.settled(webhookId, 4)
+	if len(deliveries) != 4 || deliveries[0].Event != events.InvoicePaid {
+		t.Fatalf("expected the 4 deliveries, the latest first, got %+v", deliveries)
+	}
+	for _, d := range deliveries {
+		if d.Status != webhook.Succeeded || d.Attempts != 1 || d.Response_status != http.StatusOK {
+			t.Fatalf("unexpected delivery %+v", d)
+		}
+	}
+
+	// unsubscribed events aren't delivered
+	a.expect(http.StatusOK, "PATCH", "/webhooks/"+webhookId, gin.H{"events": []string{events.InvoicePaid}}, nil)
+	a.order(a.createTable(2), soup)
+	if deliveries := a.settled(webhookId, 4); len(deliveries) != 4 {
+		t.Fatalf("expected no new delivery, got %+v", deliveries)
+	}
+}
+
+func TestWebhookRetriesAndRedelivery(t *testing.T) {
+	a := newWebhookAPI(t)
+	r := newReceiver(t, http.StatusInternalServerError)
+
+	webhookId := a.createWebhook(r.server.URL, events.OrderPlaced)
+	menuId := a.createMenu()
+	a.order(a.createTable(1), a.createFood(menuId, "Soup", 4.5))
+
+	deliveries := a.settled(webhookId, 1)
+	failed := deliveries[0]
+	if failed.Status != webhook.Failed || failed.Attempts != 3 || failed.Response_status != http.StatusInternalServerError || failed.Last_error == "" {
+		t.Fatalf("expected the delivery to fail after 3 attempts, got %+v", failed)
+	}
+
+	r.answer(http.StatusOK)
+	var redelivery delivery
+	a.expect(http.StatusAccepted, "POST", "/webhooks/"+webhookId+"/deliveries/"+failed.Delivery_id+"/redeliver", nil, &redelivery)
+	if redelivery.Redelivery_of != failed.Delivery_id || redelivery.Event_id != failed.Event_id {
+		t.Fatalf("unexpected redelivery %+v", redelivery)
+	}
+	if got := r.next(); got.Delivery != redelivery.Delivery_id || got.Body.ID != failed.Event_id {
+		t.Fatalf("expected the redelivery of event %s, got %+v", failed.Event_id, got)
+	}
+
+	deliveries = a.settled(webhookId, 2)
+	if deliveries[0].Delivery_id != redelivery.Delivery_id || deliveries[0].Status != webhook.Succeeded {
+		t.Fatalf("expected the redelivery to succeed, got %+v", deliveries)
+	}
+
+	if w := a.do("POST", "/webhooks/"+webhookId+"/deliveries/unknown/redeliver", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected an unknown delivery not to be found, got %d", w.Code)
+	}
+}
+
+func TestWebhookValidation(t *testing.T) {
+	s := store.NewMemoryStore()
+	a := newAPIOver(t, s)
+
+	// managing the webhooks takes a permission
+	if w := a.do("POST", "/webhooks", gin.H{"url": "https://example.com", "events": []string{events.OrderPlaced}, "secret": webhookSecret}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the webhook to be forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := a.do("GET", "/webhooks", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the webhooks to be forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+	a.actAs(s, "manager@example.com", helpers.ManageWebhooks)
+
+	for _, body := range []gin.H{
+		{"url": "ftp://example.com", "events": []string{events.OrderPlaced}, "secret": webhookSecret},
+		{"url": "https://example.com", "events": []string{"order.archived"}, "secret": webhookSecret},
+		{"url": "https://example.com", "events": []string{}, "secret": webhookSecret},
+		{"url": "https://example.com", "events": []string{events.OrderPlaced}, "secret": "short"},
+	} {
+		if w := a.do("POST", "/webhooks", body); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected %v to be rejected, got %d", body, w.Code)
+		}
+	}
+
+	webhookId := a.createWebhook("https://example.com/hook", events.OrderPlaced)
+	for _, body := range []gin.H{
+		{"url": "not a url"},
+		{"events": []string{"order.archived"}},
+		{"secret": "short"},
+	} {
+		if w := a.do("PATCH", "/webhooks/"+webhookId, body); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected %v to be rejected, got %d", body, w.Code)
+		}
+	}
+	a.expect(http.StatusOK, "PATCH", "/webhooks/"+webhookId, gin.H{"active": false, "secret": "another-secret-long-enough"}, nil)
+
+	// without a dispatcher, there is nothing to redeliver with
+	if w := a.do("POST", "/webhooks/"+webhookId+"/deliveries/unknown/redeliver", nil); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected redelivery to be unavailable, got %d", w.Code)
+	}
+
+	a.expect(http.StatusOK, "DELETE", "/webhooks/"+webhookId, nil, nil)
+	if w := a.do("GET", "/webhooks/"+webhookId, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected the webhook to be deleted, got %d", w.Code)
+	}
+}
+
+func TestWebhookPrivateNetworks(t *testing.T) {
+	a, s := newDispatchingAPI(t, false)
+	r := newReceiver(t, http.StatusOK)
+
+	for _, url := range []string{
+		r.server.URL,
+		"http://localhost:8080/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://[::ffff:192.168.1.1]/hook",
+	} {
+		body := gin.H{"url": url, "events": []string{events.OrderPlaced}, "secret": webhookSecret}
+		if w := a.do("POST", "/webhooks", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected %s to be rejected, got %d: %s", url, w.Code, w.Body.String())
+		}
+	}
+	publicId := a.createWebhook("http://93.184.216.34/hook", events.OrderPlaced)
+	if w := a.do("PATCH", "/webhooks/"+publicId, gin.H{"url": "http://127.0.0.1/hook"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected the private URL to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+	a.expect(http.StatusOK, "PATCH", "/webhooks/"+publicId, gin.H{"active": false}, nil)
+
+	// a host resolving to a private address once registered isn't reached
+	// either, the address connected to is checked
+	url, secret, active := r.server.URL, webhookSecret, true
+	hook := models.Webhook{ID: primitive.NewObjectID(), Url: &url, Events: []string{events.OrderPlaced}, Secret: &secret, Active: &active}
+	hook.Webhook_id = hook.ID.Hex()
+	if _, err := s.Webhooks().Create(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+	a.order(a.createTable(1), a.createFood(a.createMenu(), "Soup", 4.5))
+	deliveries := a.settled(hook.Webhook_id, 1)
+	if deliveries[0].Status != webhook.Failed || !strings.Contains(deliveries[0].Last_error, webhook.ErrPrivateAddress.Error()) {
+		t.Fatalf("expected the delivery to be refused, got %+v", deliveries[0])
+	}
+	if len(r.got) != 0 {
+		t.Fatal("expected the receiver to get nothing")
+	}
+}
@@ -0,0 +1,67 @@
+package seed
+
+type dish struct {
+	name  string
+	price float64
+}
+
+type menuTemplate struct {
+	name     string
+	category string
+	dishes   []dish
+}
+
+var catalog = []menuTemplate{
+	{"Breakfast", "morning", []dish{
+		{"Eggs Benedict", 11.5},
+		{"Buttermilk Pancakes", 9},
+		{"Avocado Toast", 10.5},
+		{"Granola Bowl", 7.5},
+		{"Croque Madame", 12},
+		{"Shakshuka", 11},
+	}},
+	{"Lunch", "main", []dish{
+		{"Caesar Salad", 12.5},
+		{"Club Sandwich", 13},
+		{"Chicken Ramen", 14.5},
+		{"Falafel Wrap", 11},
+		{"Fish and Chips", 16},
+		{"Tomato Soup", 7},
+		{"Beef Burger", 15.5},
+	}},
+	{"Dinner", "main", []dish{
+		{"Ribeye Steak", 29},
+		{"Grilled Salmon", 24.5},
+		{"Mushroom Risotto", 18},
+		{"Duck Confit", 26},
+		{"Lamb Tagine", 23},
+		{"Seafood Linguine", 22.5},
+		{"Roast Chicken", 19.5},
+	}},
+	{"Desserts", "dessert", []dish{
+		{"Chocolate Fondant", 8.5},
+		{"Creme Brulee", 7.5},
+		{"Lemon Tart", 7},
+		{"Tiramisu", 8},
+		{"Ice Cream Trio", 6},
+	}},
+	{"Drinks", "beverage", []dish{
+		{"Espresso", 2.5},
+		{"Cappuccino", 3.5},
+		{"Fresh Orange Juice", 4.5},
+		{"Sparkling Water", 3},
+		{"House Red Wine", 7},
+		{"Craft Beer", 6},
+		{"Lemonade", 4},
+	}},
+}
+
+var firstNames = []string{
+	"Alice", "Bruno", "Chloe", "Diego", "Emma", "Farid", "Grace", "Hugo", "Ines", "Jonas",
+	"Keiko", "Liam", "Maya", "Nadia", "Oscar", "Priya", "Quentin", "Rosa", "Samir", "Tara",
+}
+
+var lastNames = []string{
+	"Martin", "Garcia", "Nguyen", "Kowalski", "Okafor", "Rossi", "Schmidt", "Dubois", "Silva", "Tanaka",
+	"Haddad", "Novak", "Larsen", "Moreau", "Yilmaz",
+}
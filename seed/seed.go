@@ -0,0 +1,462 @@
+// Package seed fills a store with generated demo data: menus, foods, tables,
+// staff users and weeks of paid orders with their items and invoices.
+//
+// Seeding a store again keeps what it already holds: the staff users are
+// found by email, the menus by name, their foods by name and the tables by
+// number, and the orders are only generated into a store without any.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+	"math/rand"
+	"restaurant_management/helpers"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"strings"
+	"time"
+)
+
+type Options struct {
+	// Seed makes the generated data reproducible.
+	Seed int64
+	// Weeks of order history, ending now.
+	Weeks int
+	// OrdersPerDay is the average number of orders of a weekday, weekends
+	// are busier.
+	OrdersPerDay int
+	Tables       int
+	Staff        int
+	// OpenOrders are left unpaid on occupied tables.
+	OpenOrders int
+	// StaffPassword is the password of every staff user.
+	StaffPassword string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Seed:          time.Now().UnixNano(),
+		Weeks:         4,
+		OrdersPerDay:  40,
+		Tables:        12,
+		Staff:         5,
+		OpenOrders:    3,
+		StaffPassword: "password123",
+	}
+}
+
+type Summary struct {
+	Users      int
+	Menus      int
+	Foods      int
+	Tables     int
+	Orders     int
+	OrderItems int
+	Invoices   int
+}
+
+type generator struct {
+	store  store.Store
+	rand   *rand.Rand
+	opts   Options
+	now    time.Time
+	menus  map[string][]models.Food
+	tables []models.Table
+	// ordered is set when the store already holds orders.
+	ordered bool
+	sum     Summary
+}
+
+// Run generates the data described by opts into s. The summary counts what
+// was created.
+func Run(ctx context.Context, s store.Store, opts Options) (*Summary, error) {
+	if opts.Tables < opts.OpenOrders {
+		return nil, fmt.Errorf("%d open orders need as many tables, only %d requested", opts.OpenOrders, opts.Tables)
+	}
+
+	g := &generator{
+		store: s,
+		rand:  rand.New(rand.NewSource(opts.Seed)),
+		opts:  opts,
+		now:   time.Now().UTC().Truncate(time.Second),
+		menus: map[string][]models.Food{},
+	}
+
+	steps := []func(ctx context.Context) error{
+		g.checkOrders,
+		g.staff,
+		g.catalog,
+		g.createTables,
+		g.history,
+		g.openOrders,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return &g.sum, err
+		}
+	}
+	return &g.sum, nil
+}
+
+func (g *generator) checkOrders(ctx context.Context) error {
+	_, total, err := g.store.Orders().Page(ctx, 0, 1)
+	g.ordered = total > 0
+	return err
+}
+
+func (g *generator) staff(ctx context.Context) error {
+	// the default cost, the API's is too slow for a batch of users
+	hash, err := bcrypt.GenerateFromPassword([]byte(g.opts.StaffPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	password := string(hash)
+
+	for i := 0; i < g.opts.Staff; i++ {
+		firstName := firstNames[g.rand.Intn(len(firstNames))]
+		lastName := lastNames[g.rand.Intn(len(lastNames))]
+		email := fmt.Sprintf("%s.%s%d@restaurant.example", strings.ToLower(firstName), strings.ToLower(lastName), i+1)
+		phone := fmt.Sprintf("+1555%07d", g.rand.Intn(10000000))
+
+		count, err := g.store.Users().CountByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		user := models.User{
+			ID:         primitive.NewObjectID(),
+			First_name: &firstName,
+			Last_name:  &lastName,
+			Password:   &password,
+			Email:      &email,
+			Phone:      &phone,
+			Created_at: g.now,
+			Updated_at: g.now,
+		}
+		user.User_id = user.ID.Hex()
+		if i == 0 {
+			// the first one manages the floor
+			user.Permissions = []string{helpers.PriceOverride}
+		}
+
+		if _, err := g.store.Users().Create(ctx, user); err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+		g.sum.Users++
+	}
+	return nil
+}
+
+func (g *generator) catalog(ctx context.Context) error {
+	start := g.now.AddDate(0, 0, -7*g.opts.Weeks-1)
+	end := g.now.AddDate(1, 0, 0)
+
+	docs, err := g.store.Menus().All(ctx)
+	if err != nil {
+		return err
+	}
+	menus, err := store.Decode[models.Menu](docs)
+	if err != nil {
+		return err
+	}
+
+	for _, template := range catalog {
+		menu, existing := findMenu(menus, template.name)
+		if !existing {
+			menu = models.Menu{
+				ID:         primitive.NewObjectID(),
+				Name:       template.name,
+				Category:   template.category,
+				Start_date: &start,
+				End_date:   &end,
+				Created_at: start,
+				Updated_at: start,
+			}
+			menu.Menu_id = menu.ID.Hex()
+
+			if _, err := g.store.Menus().Create(ctx, menu); err != nil {
+				return fmt.Errorf("create menu: %w", err)
+			}
+			g.sum.Menus++
+		}
+
+		foods := map[string]models.Food{}
+		if existing {
+			menuFoods, err := g.store.Foods().ByMenus(ctx, []string{menu.Menu_id})
+			if err != nil {
+				return err
+			}
+			for _, food := range menuFoods {
+				foods[value(food.Name)] = food
+			}
+		}
+
+		for _, dish := range template.dishes {
+			name := dish.name
+			// prices move a little from one seed to another
+			price := toCents(dish.price * (0.9 + 0.2*g.rand.Float64()))
+			image := "https://picsum.photos/seed/" + slug(dish.name) + "/640/480"
+
+			if food, ok := foods[name]; ok {
+				g.menus[template.name] = append(g.menus[template.name], food)
+				continue
+			}
+
+			food := models.Food{
+				ID:          primitive.NewObjectID(),
+				Name:        &name,
+				Price:       &price,
+				Size_prices: map[string]float64{"S": toCents(price * 0.8), "L": toCents(price * 1.25)},
+				Food_image:  &image,
+				Menu_id:     &menu.Menu_id,
+				Created_at:  start,
+				Updated_at:  start,
+			}
+			food.Food_id = food.ID.Hex()
+
+			if _, err := g.store.Foods().Create(ctx, food); err != nil {
+				return fmt.Errorf("create food: %w", err)
+			}
+			g.menus[template.name] = append(g.menus[template.name], food)
+			g.sum.Foods++
+		}
+	}
+	return nil
+}
+
+func (g *generator) createTables(ctx context.Context) error {
+	docs, err := g.store.Tables().All(ctx)
+	if err != nil {
+		return err
+	}
+	existing, err := store.Decode[models.Table](docs)
+	if err != nil {
+		return err
+	}
+	byNumber := map[int]models.Table{}
+	for _, table := range existing {
+		byNumber[value(table.Table_number)] = table
+	}
+
+	for i := 1; i <= g.opts.Tables; i++ {
+		number := i
+		guests := []int{2, 2, 4, 4, 4, 6, 8}[g.rand.Intn(7)]
+		status := "FREE"
+
+		if table, ok := byNumber[number]; ok {
+			g.tables = append(g.tables, table)
+			continue
+		}
+
+		table := models.Table{
+			ID:               primitive.NewObjectID(),
+			Number_of_guests: &guests,
+			Table_number:     &number,
+			Table_status:     &status,
+			Created_at:       g.now,
+			Updated_at:       g.now,
+		}
+		table.Table_id = table.ID.Hex()
+
+		if _, err := g.store.Tables().Create(ctx, table); err != nil {
+			return fmt.Errorf("create table: %w", err)
+		}
+		g.tables = append(g.tables, table)
+		g.sum.Tables++
+	}
+	return nil
+}
+
+// history creates the paid orders of the past weeks, busier at lunch, dinner
+// and on weekends.
+func (g *generator) history(ctx context.Context) error {
+	if len(g.tables) == 0 || g.ordered {
+		return nil
+	}
+
+	days := 7 * g.opts.Weeks
+	today := g.now.Truncate(24 * time.Hour)
+	for day := days; day >= 1; day-- {
+		date := today.AddDate(0, 0, -day)
+
+		volume := float64(g.opts.OrdersPerDay) * (0.8 + 0.4*g.rand.Float64())
+		if weekday := date.Weekday(); weekday == time.Friday || weekday == time.Saturday || weekday == time.Sunday {
+			volume *= 1.4
+		}
+
+		for i := 0; i < int(volume); i++ {
+			at := date.Add(g.serviceTime())
+			table := g.tables[g.rand.Intn(len(g.tables))]
+
+			order, err := g.order(ctx, table, at, "PAID")
+			if err != nil {
+				return err
+			}
+			if err := g.invoice(ctx, order, at.Add(time.Duration(30+g.rand.Intn(90))*time.Minute)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// openOrders leaves the last orders of the day unpaid on their tables.
+func (g *generator) openOrders(ctx context.Context) error {
+	if g.ordered {
+		return nil
+	}
+	tables := g.rand.Perm(len(g.tables))[:g.opts.OpenOrders]
+	for _, i := range tables {
+		table := g.tables[i]
+		at := g.now.Add(-time.Duration(g.rand.Intn(60)) * time.Minute)
+
+		if _, err := g.order(ctx, table, at, "OPEN"); err != nil {
+			return err
+		}
+
+		set := bson.D{{"table_status", "OCCUPIED"}, {"updated_at", at}}
+		if _, err := g.store.Tables().Update(ctx, table.Table_id, set); err != nil {
+			return fmt.Errorf("occupy table: %w", err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) order(ctx context.Context, table models.Table, at time.Time, status string) (models.Order, error) {
+	order := models.Order{
+		ID:           primitive.NewObjectID(),
+		Order_date:   at,
+		Order_status: &status,
+		Table_id:     &table.Table_id,
+		Created_at:   at,
+		Updated_at:   at,
+	}
+	order.Order_id = order.ID.Hex()
+
+	if _, err := g.store.Orders().Create(ctx, order); err != nil {
+		return order, fmt.Errorf("create order: %w", err)
+	}
+	g.sum.Orders++
+
+	items := g.items(order, *table.Number_of_guests)
+	if _, err := g.store.OrderItems().CreateMany(ctx, items); err != nil {
+		return order, fmt.Errorf("create order items: %w", err)
+	}
+	g.sum.OrderItems += len(items)
+
+	return order, nil
+}
+
+// items picks a main per guest or so from the menu of the time of day, with
+// drinks and sometimes desserts.
+func (g *generator) items(order models.Order, guests int) []models.OrderItem {
+	mains := "Dinner"
+	switch hour := order.Order_date.Hour(); {
+	case hour < 11:
+		mains = "Breakfast"
+	case hour < 17:
+		mains = "Lunch"
+	}
+
+	var foods []models.Food
+	diners := 1 + g.rand.Intn(guests)
+	for i := 0; i < diners; i++ {
+		foods = append(foods, g.pick(mains))
+		if g.rand.Float64() < 0.7 {
+			foods = append(foods, g.pick("Drinks"))
+		}
+		if mains != "Breakfast" && g.rand.Float64() < 0.3 {
+			foods = append(foods, g.pick("Desserts"))
+		}
+	}
+
+	items := make([]models.OrderItem, len(foods))
+	for i, food := range foods {
+		quantity := []string{"S", "M", "M", "L"}[g.rand.Intn(4)]
+		price := *food.Price
+		if sizePrice, ok := food.Size_prices[quantity]; ok {
+			price = sizePrice
+		}
+
+		items[i] = models.OrderItem{
+			ID:         primitive.NewObjectID(),
+			Quantity:   &quantity,
+			Unit_price: &price,
+			Food_id:    &food.Food_id,
+			Order_id:   order.Order_id,
+			Created_at: order.Order_date,
+			Updated_at: order.Order_date,
+		}
+		items[i].Order_item_id = items[i].ID.Hex()
+	}
+	return items
+}
+
+func (g *generator) invoice(ctx context.Context, order models.Order, paidAt time.Time) error {
+	method := "CARD"
+	if g.rand.Float64() < 0.3 {
+		method = "CASH"
+	}
+	status := "PAID"
+
+	invoice := models.Invoice{
+		ID:               primitive.NewObjectID(),
+		Order_id:         order.Order_id,
+		Payment_method:   &method,
+		Payment_status:   &status,
+		Payment_due_date: order.Order_date.AddDate(0, 0, 1),
+		Created_at:       paidAt,
+		Updated_at:       paidAt,
+	}
+	invoice.Invoice_id = invoice.ID.Hex()
+
+	if _, err := g.store.Invoices().Create(ctx, invoice); err != nil {
+		return fmt.Errorf("create invoice: %w", err)
+	}
+	g.sum.Invoices++
+	return nil
+}
+
+func (g *generator) pick(menu string) models.Food {
+	foods := g.menus[menu]
+	return foods[g.rand.Intn(len(foods))]
+}
+
+// serviceTime returns a time of day during a service, lunch and dinner peaks
+// being the busiest.
+func (g *generator) serviceTime() time.Duration {
+	hours := []int{8, 9, 10, 11, 12, 12, 12, 13, 13, 13, 14, 15, 18, 19, 19, 19, 20, 20, 20, 21, 22}
+	hour := hours[g.rand.Intn(len(hours))]
+	return time.Duration(hour)*time.Hour + time.Duration(g.rand.Intn(3600))*time.Second
+}
+
+func findMenu(menus []models.Menu, name string) (models.Menu, bool) {
+	for _, menu := range menus {
+		if menu.Name == name {
+			return menu, true
+		}
+	}
+	return models.Menu{}, false
+}
+
+func value[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func toCents(price float64) float64 {
+	return float64(int(price*100+0.5)) / 100
+}
+
+func slug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+}
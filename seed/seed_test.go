@@ -0,0 +1,220 @@
+package seed
+
+import (
+	"context"
+	"golang.org/x/crypto/bcrypt"
+	"restaurant_management/helpers"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"strings"
+	"testing"
+)
+
+func testOptions() Options {
+	return Options{
+		Seed:          42,
+		Weeks:         1,
+		OrdersPerDay:  3,
+		Tables:        4,
+		Staff:         3,
+		OpenOrders:    2,
+		StaffPassword: "secret-password",
+	}
+}
+
+// counts returns the number of documents of every collection seeded.
+func counts(t *testing.T, s store.Store) Summary {
+	t.Helper()
+	ctx := context.Background()
+
+	var sum Summary
+	var total int64
+	var err error
+	if _, total, err = s.Users().Page(ctx, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	sum.Users = int(total)
+	if _, total, err = s.Foods().Page(ctx, store.FoodFilter{}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	sum.Foods = int(total)
+	if _, total, err = s.Orders().Page(ctx, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	sum.Orders = int(total)
+
+	menus, err := s.Menus().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := s.Tables().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := s.OrderItems().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoices, err := s.Invoices().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum.Menus, sum.Tables, sum.OrderItems, sum.Invoices = len(menus), len(tables), len(items), len(invoices)
+	return sum
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	opts := testOptions()
+
+	summary, err := Run(ctx, s, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Users != 3 || summary.Menus != len(catalog) || summary.Foods != 32 || summary.Tables != 4 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Orders == 0 || summary.OrderItems < summary.Orders || summary.Invoices != summary.Orders-opts.OpenOrders {
+		t.Fatalf("expected an invoice per paid order, got %+v", summary)
+	}
+	if seeded := counts(t, s); seeded != *summary {
+		t.Fatalf("expected the store to hold %+v, got %+v", summary, seeded)
+	}
+
+	// the open orders keep their tables occupied
+	docs, err := s.Tables().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := store.Decode[models.Table](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	occupied := 0
+	for _, table := range tables {
+		if *table.Table_status == "OCCUPIED" {
+			occupied++
+		}
+	}
+	if occupied != opts.OpenOrders {
+		t.Fatalf("expected %d occupied tables, got %d", opts.OpenOrders, occupied)
+	}
+
+	// a second run duplicates nothing, the catalog, tables and orders being
+	// kept whatever the seed; the staff users are drawn from the seed
+	before := counts(t, s)
+	another := opts
+	another.Seed, another.Staff = opts.Seed+1, 0
+	for _, again := range []Options{opts, another} {
+		summary, err := Run(ctx, s, again)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *summary != (Summary{}) {
+			t.Fatalf("seed %d: expected nothing to be created again, got %+v", again.Seed, summary)
+		}
+		if seeded := counts(t, s); seeded != before {
+			t.Fatalf("seed %d: expected the store to still hold %+v, got %+v", again.Seed, before, seeded)
+		}
+	}
+}
+
+func TestStaffPermissions(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	opts := testOptions()
+	if _, err := Run(ctx, s, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, _, err := s.Users().Page(ctx, 0, opts.Staff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := store.Decode[models.User](docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != opts.Staff {
+		t.Fatalf("expected %d users, got %d", opts.Staff, len(users))
+	}
+
+	// only the first staff user overrides prices
+	for _, user := range users {
+		first := strings.HasSuffix(*user.Email, "1@restaurant.example")
+		switch {
+		case first && (len(user.Permissions) != 1 || user.Permissions[0] != helpers.PriceOverride):
+			t.Errorf("expected %s to override prices, got %v", *user.Email, user.Permissions)
+		case !first && len(user.Permissions) != 0:
+			t.Errorf("expected %s to have no permission, got %v", *user.Email, user.Permissions)
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(opts.StaffPassword)); err != nil {
+			t.Errorf("expected %s to log in with the staff password: %v", *user.Email, err)
+		}
+	}
+}
+
+func TestRunReproducible(t *testing.T) {
+	prices := func() map[string]float64 {
+		s := store.NewMemoryStore()
+		if _, err := Run(context.Background(), s, testOptions()); err != nil {
+			t.Fatal(err)
+		}
+		docs, _, err := s.Foods().Page(context.Background(), store.FoodFilter{}, 0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		foods, err := store.Decode[models.Food](docs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prices := map[string]float64{}
+		for _, food := range foods {
+			prices[*food.Name] = *food.Price
+		}
+		return prices
+	}
+
+	first, second := prices(), prices()
+	for name, price := range first {
+		if second[name] != price {
+			t.Errorf("%s: expected the same seed to give the same price, got %v and %v", name, price, second[name])
+		}
+	}
+}
+
+func TestRunOptions(t *testing.T) {
+	opts := testOptions()
+	opts.OpenOrders = opts.Tables + 1
+	if _, err := Run(context.Background(), store.NewMemoryStore(), opts); err == nil {
+		t.Fatal("expected more open orders than tables to be rejected")
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	names := map[string]bool{}
+	menus := map[string]bool{}
+	for _, template := range catalog {
+		if menus[template.name] || template.category == "" || len(template.dishes) == 0 {
+			t.Errorf("invalid menu %+v", template)
+		}
+		menus[template.name] = true
+		for _, dish := range template.dishes {
+			if names[dish.name] || dish.price <= 0 {
+				t.Errorf("invalid dish %+v", dish)
+			}
+			names[dish.name] = true
+		}
+	}
+
+	// the orders pick their foods from these menus
+	for _, menu := range []string{"Breakfast", "Lunch", "Dinner", "Desserts", "Drinks"} {
+		if !menus[menu] {
+			t.Errorf("expected a %s menu", menu)
+		}
+	}
+	if len(firstNames) == 0 || len(lastNames) == 0 {
+		t.Error("expected names to draw the staff from")
+	}
+}
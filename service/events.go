@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"restaurant_management/events"
+	"restaurant_management/models"
+)
+
+// record writes an event of eventType about data to the outbox, in the unit
+// of work of ctx: the event is published once the change it records is
+// committed, never when it is rolled back.
+func record(ctx context.Context, eventType string, data interface{}) error {
+	event, err := events.New(eventType, data)
+	if err != nil {
+		return err
+	}
+	_, err = dataStore.Outbox().Create(ctx, event)
+	return err
+}
+
+// tableEvent returns the event of a table moving to status.
+func tableEvent(status string) string {
+	if status == "FREE" {
+		return events.TableFreed
+	}
+	return events.TableOccupied
+}
+
+// recordTableMove records the event of table moving to its status, unless
+// it already was in that status before.
+func recordTableMove(ctx context.Context, previous *string, table models.Table) error {
+	if table.Table_status == nil || (previous != nil && *previous == *table.Table_status) {
+		return nil
+	}
+	return record(ctx, tableEvent(*table.Table_status), table)
+}
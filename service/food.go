@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"math"
+	"net/http"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"sort"
+	"time"
+)
+
+// ListFoods returns a page of the foods filter selects and their number.
+func ListFoods(ctx context.Context, filter store.FoodFilter, page, recordPerPage int) ([]bson.M, int64, error) {
+	for _, allergen := range filter.AllergenFree {
+		if !contains(models.Allergens, allergen) {
+			return nil, 0, invalid(http.StatusBadRequest, "unknown allergen "+allergen)
+		}
+	}
+	for _, tag := range filter.Tags {
+		if !contains(models.DietaryTags, tag) {
+			return nil, 0, invalid(http.StatusBadRequest, "unknown tag "+tag)
+		}
+	}
+
+	startIndex, limit := pageBounds(page, recordPerPage)
+
+	foods, total, err := dataStore.Foods().Page(ctx, filter, startIndex, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing food items", zap.Error(err))
+		return nil, 0, failed(http.StatusInternalServerError, "error occurred while listing food items", err)
+	}
+	return foods, total, nil
+}
+
+func GetFood(ctx context.Context, foodId string) (models.Food, error) {
+	food, err := dataStore.Foods().Get(ctx, foodId)
+	if err != nil {
+		return food, notFound(http.StatusInternalServerError, "error occurred while fetching the food item", err)
+	}
+	return food, nil
+}
+
+// FoodsByIds returns the foods having one of foodIds, in no particular order.
+func FoodsByIds(ctx context.Context, foodIds []string) ([]models.Food, error) {
+	foods, err := dataStore.Foods().GetMany(ctx, foodIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while fetching the food items", err)
+	}
+	return foods, nil
+}
+
+func FoodsByMenus(ctx context.Context, menuIds []string) ([]models.Food, error) {
+	foods, err := dataStore.Foods().ByMenus(ctx, menuIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing the food items of the menus", err)
+	}
+	return foods, nil
+}
+
+func CreateFood(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error) {
+	if validationErr := validate.Struct(food); validationErr != nil {
+		return nil, invalid(http.StatusBadRequest, validationErr.Error())
+	}
+
+	if _, err := dataStore.Menus().Get(ctx, *food.Menu_id); err != nil {
+		return nil, invalidReference(http.StatusBadRequest, "menu not found", err)
+	}
+
+	if err := prepareModifierGroups(food.Modifier_groups); err != nil {
+		return nil, err
+	}
+
+	food.ID = primitive.NewObjectID()
+	food.Food_id = food.ID.Hex()
+	food.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	food.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	num := toFixed(*food.Price, 2)
+	food.Price = &num
+	roundPrices(food.Size_prices)
+	if err := checkSoldOutUntil(food, food.Created_at); err != nil {
+		return nil, err
+	}
+	food.Portions_sold, food.Portions_date = 0, ""
+	// thumbnails are made from the images uploaded
+	food.Food_thumbnail = nil
+	if food.Daily_limit != nil && *food.Daily_limit == 0 {
+		food.Daily_limit = nil
+	}
+
+	result, insertErr := dataStore.Foods().Create(ctx, food)
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("Food item was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "Food item was not created", insertErr)
+	}
+	return result, nil
+}
+
+// UpdateFood sets the fields of food that aren't nil.
+func UpdateFood(ctx context.Context, foodId string, food models.Food) (*mongo.UpdateResult, error) {
+	var updateObj primitive.D
+
+	if food.Name != nil {
+		updateObj = append(updateObj, bson.E{"name", food.Name})
+	}
+
+	if food.Price != nil {
+		num := toFixed(*food.Price, 2)
+		food.Price = &num
+		updateObj = append(updateObj, bson.E{"price", food.Price})
+	}
+
+	if food.Food_image != nil {
+		// the thumbnail was made from the image uploaded before
+		updateObj = append(updateObj, bson.E{"food_image", food.Food_image}, bson.E{"food_thumbnail", nil})
+	}
+
+	var details []string
+	if food.Size_prices != nil {
+		details = append(details, "Size_prices")
+		roundPrices(food.Size_prices)
+		updateObj = append(updateObj, bson.E{"size_prices", food.Size_prices})
+	}
+
+	if food.Description != nil {
+		details = append(details, "Description")
+		updateObj = append(updateObj, bson.E{"description", food.Description})
+	}
+
+	if food.Category != nil {
+		details = append(details, "Category")
+		updateObj = append(updateObj, bson.E{"category", food.Category})
+	}
+
+	if food.Tags != nil {
+		details = append(details, "Tags")
+		updateObj = append(updateObj, bson.E{"tags", food.Tags})
+	}
+
+	if food.Allergens != nil {
+		details = append(details, "Allergens")
+		updateObj = append(updateObj, bson.E{"allergens", food.Allergens})
+	}
+
+	if food.Spice_level != nil {
+		details = append(details, "Spice_level")
+		updateObj = append(updateObj, bson.E{"spice_level", food.Spice_level})
+	}
+
+	now := time.Now()
+	if err := checkSoldOutUntil(food, now); err != nil {
+		return nil, err
+	}
+	availability := food.Sold_out != nil || food.Daily_limit != nil
+	if food.Sold_out != nil {
+		// putting a food back on sale ends its sold out period
+		updateObj = append(updateObj, bson.E{"sold_out", food.Sold_out}, bson.E{"sold_out_until", food.Sold_out_until})
+	}
+
+	if food.Daily_limit != nil {
+		details = append(details, "Daily_limit")
+		if *food.Daily_limit == 0 {
+			updateObj = append(updateObj, bson.E{"daily_limit", nil})
+		} else {
+			updateObj = append(updateObj, bson.E{"daily_limit", food.Daily_limit})
+		}
+	}
+
+	if food.Modifier_groups != nil {
+		if err := prepareModifierGroups(food.Modifier_groups); err != nil {
+			return nil, err
+		}
+		updateObj = append(updateObj, bson.E{"modifier_groups", food.Modifier_groups})
+	}
+
+	if len(details) > 0 {
+		if validationErr := validate.StructPartial(food, details...); validationErr != nil {
+			return nil, invalid(http.StatusBadRequest, validationErr.Error())
+		}
+	}
+
+	if food.Menu_id != nil {
+		if _, err := dataStore.Menus().Get(ctx, *food.Menu_id); err != nil {
+			return nil, invalidReference(http.StatusInternalServerError, "menu not found", err)
+		}
+		updateObj = append(updateObj, bson.E{"menu_id", food.Menu_id})
+	}
+
+	food.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", food.Updated_at})
+
+	if !availability {
+		result, updateErr := dataStore.Foods().Update(ctx, foodId, updateObj)
+		if updateErr != nil {
+			logger.FromContext(ctx).Error("food item update failed", zap.Error(updateErr))
+			return nil, failed(http.StatusInternalServerError, "food item update failed", updateErr)
+		}
+		return result, nil
+	}
+
+	// the food is read around the update to record whether it was sold out
+	// or put back on sale
+	var result *mongo.UpdateResult
+	updateErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		previous, err := dataStore.Foods().Get(ctx, foodId)
+		if err != nil {
+			return err
+		}
+		if result, err = dataStore.Foods().Update(ctx, foodId, updateObj); err != nil {
+			return err
+		}
+		updated, err := dataStore.Foods().Get(ctx, foodId)
+		if err != nil {
+			return err
+		}
+		return recordAvailability(ctx, previous, updated, now)
+	})
+	if errors.Is(updateErr, store.ErrNotFound) {
+		return nil, notFound(http.StatusNotFound, "food item not found", updateErr)
+	}
+	if updateErr != nil {
+		logger.FromContext(ctx).Error("food item update failed", zap.Error(updateErr))
+		return nil, failed(http.StatusInternalServerError, "food item update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteFood(ctx context.Context, foodId string) (*mongo.DeleteResult, error) {
+	food, err := dataStore.Foods().Get(ctx, foodId)
+	if err != nil {
+		return nil, notFound(http.StatusBadRequest, "food item not found", err)
+	}
+
+	result, deleteErr := dataStore.Foods().Delete(ctx, foodId)
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete food item", deleteErr)
+	}
+	deleteImages(ctx, food.Food_image, food.Food_thumbnail)
+	return result, nil
+}
+
+// roundPrices rounds the prices of the sizes to the cent.
+func roundPrices(prices map[string]float64) {
+	for size, price := range prices {
+		prices[size] = toFixed(price, 2)
+	}
+}
+
+// prepareModifierGroups validates the groups, checks that their selections
+// can be made and gives an ID to the groups and the options that have none.
+// IDs are unique among the groups and among the options of a group.
+func prepareModifierGroups(groups []models.ModifierGroup) error {
+	groupIds := map[string]bool{}
+	for i := range groups {
+		group := &groups[i]
+		if validationErr := validate.Struct(group); validationErr != nil {
+			return invalid(http.StatusBadRequest, validationErr.Error())
+		}
+		if group.Max_selections > 0 && group.Max_selections < group.Min_selections {
+			return invalid(http.StatusBadRequest, "modifier group "+group.Name+" allows fewer selections than it requires")
+		}
+		if group.Min_selections > len(group.Options) {
+			return invalid(http.StatusBadRequest, "modifier group "+group.Name+" requires more selections than it has options")
+		}
+
+		if group.Group_id == "" {
+			group.Group_id = primitive.NewObjectID().Hex()
+		}
+		if groupIds[group.Group_id] {
+			return invalid(http.StatusBadRequest, "duplicate modifier group "+group.Group_id)
+		}
+		groupIds[group.Group_id] = true
+
+		optionIds := map[string]bool{}
+		for j := range group.Options {
+			option := &group.Options[j]
+			if option.Option_id == "" {
+				option.Option_id = primitive.NewObjectID().Hex()
+			}
+			if optionIds[option.Option_id] {
+				return invalid(http.StatusBadRequest, "duplicate modifier option "+option.Option_id)
+			}
+			optionIds[option.Option_id] = true
+			option.Price_delta = toFixed(option.Price_delta, 2)
+		}
+	}
+	return nil
+}
+
+// AllergenMatrix tells which allergens of the EU 14 the foods of a menu
+// contain, for the staff to answer the customers and to print.
+type AllergenMatrix struct {
+	Menu_id   string        `json:"menu_id"`
+	Name      string        `json:"name"`
+	Allergens []string      `json:"allergens"`
+	Foods     []AllergenRow `json:"foods"`
+}
+
+// AllergenRow is a food of an AllergenMatrix. Contains holds every allergen
+// of the matrix when the food Declared its allergens, it is nil otherwise.
+type AllergenRow struct {
+	Food_id  string          `json:"food_id"`
+	Name     string          `json:"name"`
+	Declared bool            `json:"declared"`
+	Contains map[string]bool `json:"contains"`
+}
+
+// MenuAllergens returns the allergen matrix of the foods of a menu, sorted by
+// name.
+func MenuAllergens(ctx context.Context, menuId string) (AllergenMatrix, error) {
+	menu, err := dataStore.Menus().Get(ctx, menuId)
+	if err != nil {
+		return AllergenMatrix{}, notFound(http.StatusNotFound, "menu not found", err)
+	}
+
+	foods, err := dataStore.Foods().ByMenus(ctx, []string{menuId})
+	if err != nil {
+		return AllergenMatrix{}, failed(http.StatusInternalServerError, "error occurred while listing the food items of the menu", err)
+	}
+	sort.Slice(foods, func(i, j int) bool { return *foods[i].Name < *foods[j].Name })
+
+	matrix := AllergenMatrix{
+		Menu_id:   menu.Menu_id,
+		Name:      menu.Name,
+		Allergens: models.Allergens,
+		Foods:     make([]AllergenRow, 0, len(foods)),
+	}
+	for _, food := range foods {
+		row := AllergenRow{Food_id: food.Food_id, Name: *food.Name}
+		if food.Allergens != nil {
+			row.Declared = true
+			row.Contains = make(map[string]bool, len(models.Allergens))
+			for _, allergen := range models.Allergens {
+				row.Contains[allergen] = contains(food.Allergens, allergen)
+			}
+		}
+		matrix.Foods = append(matrix.Foods, row)
+	}
+	return matrix, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// toFixed give a fixed number
+func toFixed(num float64, precision int) float64 {
+	output := math.Pow(10, float64(precision))
+	return math.Round(num*output) / output
+}
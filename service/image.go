@@ -0,0 +1,259 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"restaurant_management/logger"
+	"restaurant_management/storage"
+	"strings"
+	"time"
+)
+
+// ImageOptions bound the images uploaded: MaxBytes their size, MaxPixels
+// their width times their height. Their thumbnails fit in a square of
+// ThumbnailSize pixels.
+type ImageOptions struct {
+	MaxBytes      int64
+	MaxPixels     int
+	ThumbnailSize int
+}
+
+var (
+	images       storage.Storage
+	imageOptions ImageOptions
+)
+
+// UseImages sets where the images uploaded are stored, uploads are disabled
+// without it.
+func UseImages(s storage.Storage, opts ImageOptions) {
+	images = s
+	imageOptions = opts
+}
+
+// MaxUploadBytes bounds the requests uploading an image: the image, and room
+// for the rest of the multipart form.
+func MaxUploadBytes() int64 {
+	return imageOptions.MaxBytes + 1<<20
+}
+
+// ImagePath is the path the stored images are served under, followed by
+// their key.
+const ImagePath = "/images/"
+
+// Image holds the URLs of an image uploaded and of its thumbnail.
+type Image struct {
+	Image     string `json:"image"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// imageFormats are the extensions of the types of images accepted.
+var imageFormats = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// SetFoodImage stores the image read from r as the image of the food,
+// replacing the one uploaded before.
+func SetFoodImage(ctx context.Context, foodId string, r io.Reader) (Image, error) {
+	food, err := dataStore.Foods().Get(ctx, foodId)
+	if err != nil {
+		return Image{}, notFound(http.StatusNotFound, "food item not found", err)
+	}
+
+	uploaded, err := storeImage(ctx, "foods/"+foodId, r)
+	if err != nil {
+		return Image{}, err
+	}
+	updatedAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	set := bson.D{{"food_image", uploaded.Image}, {"food_thumbnail", uploaded.Thumbnail}, {"updated_at", updatedAt}}
+	if _, err := dataStore.Foods().Update(ctx, foodId, set); err != nil {
+		deleteImages(ctx, &uploaded.Image, &uploaded.Thumbnail)
+		logger.FromContext(ctx).Error("food image update failed", zap.Error(err))
+		return Image{}, failed(http.StatusInternalServerError, "food image update failed", err)
+	}
+
+	deleteImages(ctx, food.Food_image, food.Food_thumbnail)
+	return uploaded, nil
+}
+
+// SetAvatar stores the image read from r as the avatar of the user, callerId
+// being the user uploading it: a user only sets their own avatar.
+func SetAvatar(ctx context.Context, userId, callerId string, r io.Reader) (Image, error) {
+	if userId != callerId {
+		return Image{}, forbidden("users can only upload their own avatar")
+	}
+	user, err := dataStore.Users().Get(ctx, userId)
+	if err != nil {
+		return Image{}, notFound(http.StatusNotFound, "user not found", err)
+	}
+
+	uploaded, err := storeImage(ctx, "avatars/"+userId, r)
+	if err != nil {
+		return Image{}, err
+	}
+	if err := dataStore.Users().UpdateAvatar(ctx, userId, uploaded.Image, uploaded.Thumbnail); err != nil {
+		deleteImages(ctx, &uploaded.Image, &uploaded.Thumbnail)
+		logger.FromContext(ctx).Error("avatar update failed", zap.Error(err))
+		return Image{}, failed(http.StatusInternalServerError, "avatar update failed", err)
+	}
+
+	deleteImages(ctx, user.Avatar, user.Avatar_thumbnail)
+	return uploaded, nil
+}
+
+// GetImage returns the image stored under key and its content type.
+func GetImage(ctx context.Context, key string) ([]byte, string, error) {
+	missing := &Error{Kind: NotFound, Status: http.StatusNotFound, Message: "image not found"}
+	if images == nil {
+		return nil, "", missing
+	}
+	data, err := images.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", missing
+	}
+	if err != nil {
+		return nil, "", failed(http.StatusInternalServerError, "error occurred while reading the image", err)
+	}
+	return data, http.DetectContentType(data), nil
+}
+
+// storeImage checks the image read from r, then stores it and its thumbnail
+// under a new key starting with prefix.
+func storeImage(ctx context.Context, prefix string, r io.Reader) (Image, error) {
+	if images == nil {
+		return Image{}, invalid(http.StatusServiceUnavailable, "image uploads are disabled")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, imageOptions.MaxBytes+1))
+	if err != nil {
+		return Image{}, invalid(http.StatusBadRequest, "error occurred while reading the image")
+	}
+	if int64(len(data)) > imageOptions.MaxBytes {
+		return Image{}, invalid(http.StatusRequestEntityTooLarge, fmt.Sprintf("image is larger than %d bytes", imageOptions.MaxBytes))
+	}
+	contentType := http.DetectContentType(data)
+	extension, ok := imageFormats[contentType]
+	if !ok {
+		return Image{}, invalid(http.StatusUnsupportedMediaType, "image must be a JPEG, PNG or GIF, not "+contentType)
+	}
+
+	// the dimensions are checked before the pixels are decoded, a small
+	// file may hold a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, invalid(http.StatusBadRequest, "image can't be decoded")
+	}
+	if config.Width*config.Height > imageOptions.MaxPixels {
+		return Image{}, invalid(http.StatusRequestEntityTooLarge, fmt.Sprintf("image has more than %d pixels", imageOptions.MaxPixels))
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, invalid(http.StatusBadRequest, "image can't be decoded")
+	}
+	thumbnail, thumbnailExtension, err := encodeThumbnail(decoded, contentType)
+	if err != nil {
+		return Image{}, failed(http.StatusInternalServerError, "error occurred while making the thumbnail", err)
+	}
+
+	// a new key for every upload lets the images be cached forever
+	key := prefix + "/" + primitive.NewObjectID().Hex()
+	imageKey, thumbnailKey := key+"."+extension, key+"_thumbnail."+thumbnailExtension
+	if err := images.Put(ctx, imageKey, data); err != nil {
+		return Image{}, failed(http.StatusInternalServerError, "error occurred while storing the image", err)
+	}
+	if err := images.Put(ctx, thumbnailKey, thumbnail); err != nil {
+		images.Delete(ctx, imageKey)
+		return Image{}, failed(http.StatusInternalServerError, "error occurred while storing the thumbnail", err)
+	}
+	return Image{Image: ImagePath + imageKey, Thumbnail: ImagePath + thumbnailKey}, nil
+}
+
+// deleteImages deletes the stored images of urls, leaving the others, e.g.
+// the URLs set before the images were uploaded. Failures are only logged,
+// the images being replaced.
+func deleteImages(ctx context.Context, urls ...*string) {
+	for _, url := range urls {
+		if url == nil || !strings.HasPrefix(*url, ImagePath) || images == nil {
+			continue
+		}
+		if err := images.Delete(ctx, strings.TrimPrefix(*url, ImagePath)); err != nil {
+			logger.FromContext(ctx).Warn("image deletion failed", zap.String("url", *url), zap.Error(err))
+		}
+	}
+}
+
+// encodeThumbnail encodes the thumbnail of img, a JPEG for a JPEG and a PNG
+// otherwise so that transparency is kept, returning its extension.
+func encodeThumbnail(img image.Image, contentType string) ([]byte, string, error) {
+	thumbnail := resize(img, imageOptions.ThumbnailSize)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85})
+		return buf.Bytes(), "jpg", err
+	}
+	err := png.Encode(&buf, thumbnail)
+	return buf.Bytes(), "png", err
+}
+
+// resize scales img down to fit in a square of size pixels, keeping its
+// ratio. Every pixel of the result averages the pixels of img it covers.
+// Smaller images are only copied.
+func resize(img image.Image, size int) *image.NRGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	width, height := srcWidth, srcHeight
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, srcHeight*size/srcWidth
+		} else {
+			width, height = srcWidth*size/srcHeight, size
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, (y+1)*srcHeight/height
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, (x+1)*srcWidth/width
+			var r, g, b, a, n int
+			for sy := y0; sy < y1 || sy == y0; sy++ {
+				for sx := x0; sx < x1 || sx == x0; sx++ {
+					c := src.NRGBAAt(sx, sy)
+					// the colors are weighted by their opacity
+					r += int(c.R) * int(c.A)
+					g += int(c.G) * int(c.A)
+					b += int(c.B) * int(c.A)
+					a += int(c.A)
+					n++
+				}
+			}
+			if a > 0 {
+				dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / a), G: uint8(g / a), B: uint8(b / a), A: uint8(a / n)})
+			}
+		}
+	}
+	return dst
+}
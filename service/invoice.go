@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"time"
+)
+
+type InvoiceViewFormat struct {
+	Invoice_id       string
+	Payment_method   string
+	Order_id         string
+	Payment_status   *string
+	Payment_due      interface{}
+	Table_number     interface{}
+	Payment_due_date time.Time
+	Order_details    interface{}
+}
+
+func ListInvoices(ctx context.Context) ([]bson.M, error) {
+	allInvoices, err := dataStore.Invoices().All(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing invoice items", zap.Error(err))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing invoice items", err)
+	}
+	return allInvoices, nil
+}
+
+// FindInvoice returns the invoice as stored, GetInvoice adds its order to it.
+func FindInvoice(ctx context.Context, invoiceId string) (models.Invoice, error) {
+	invoice, err := dataStore.Invoices().Get(ctx, invoiceId)
+	if err != nil {
+		return invoice, notFound(http.StatusInternalServerError, "error occurred while fetching the invoice", err)
+	}
+	return invoice, nil
+}
+
+// GetInvoice returns the invoice with the amount due, table and items of its
+// order.
+func GetInvoice(ctx context.Context, invoiceId string) (InvoiceViewFormat, error) {
+	var invoiceView InvoiceViewFormat
+
+	invoice, err := dataStore.Invoices().Get(ctx, invoiceId)
+	if err != nil {
+		return invoiceView, notFound(http.StatusBadRequest, err.Error(), err)
+	}
+
+	allOrderItems, err := ItemsByOrder(invoice.Order_id, ctx)
+	if err != nil {
+		return invoiceView, failed(http.StatusBadRequest, "error occurred while get items by order", err)
+	}
+	if len(allOrderItems) == 0 {
+		return invoiceView, invalid(http.StatusBadRequest, "error occurred while get items by order")
+	}
+
+	invoiceView.Invoice_id = invoice.Invoice_id
+	invoiceView.Order_id = invoice.Order_id
+	invoiceView.Payment_method = "null"
+	if invoice.Payment_method != nil {
+		invoiceView.Payment_method = *invoice.Payment_method
+	}
+	invoiceView.Payment_status = invoice.Payment_status
+	invoiceView.Payment_due_date = invoice.Payment_due_date
+	invoiceView.Payment_due = allOrderItems[0]["payment_due"]
+	invoiceView.Table_number = allOrderItems[0]["table_number"]
+	invoiceView.Order_details = allOrderItems[0]["order_items"]
+
+	return invoiceView, nil
+}
+
+// CreateInvoice creates a PENDING invoice unless told otherwise, due the next
+// day. An invoice created as PAID settles its order.
+func CreateInvoice(ctx context.Context, invoice models.Invoice) (*mongo.InsertOneResult, error) {
+	if _, err := dataStore.Orders().Get(ctx, invoice.Order_id); err != nil {
+		return nil, invalidReference(http.StatusBadRequest, "order not found", err)
+	}
+
+	status := "PENDING"
+	if invoice.Payment_status == nil {
+		invoice.Payment_status = &status
+	}
+
+	invoice.ID = primitive.NewObjectID()
+	invoice.Invoice_id = invoice.ID.Hex()
+	invoice.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	invoice.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	invoice.Payment_due_date, _ = time.Parse(time.RFC3339, time.Now().AddDate(0, 0, 1).Format(time.RFC3339))
+
+	validationErr := validate.Struct(invoice)
+	if validationErr != nil {
+		return nil, invalid(http.StatusBadRequest, validationErr.Error())
+	}
+
+	// an invoice created as PAID settles its order in the same write
+	var result *mongo.InsertOneResult
+	insertErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = dataStore.Invoices().Create(ctx, invoice); err != nil {
+			return err
+		}
+
+		if !isPaid(invoice.Payment_status) {
+			return record(ctx, events.InvoiceCreated, invoice)
+		}
+		if err := record(ctx, events.InvoicePaid, invoice); err != nil {
+			return err
+		}
+		return settleOrder(ctx, invoice.Order_id)
+	})
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("invoice item was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "invoice item was not created", insertErr)
+	}
+
+	if isPaid(invoice.Payment_status) {
+		recordInvoicePaid(ctx, invoice.Order_id)
+	}
+
+	return result, nil
+}
+
+// UpdateInvoice sets the payment method and status of the invoice when they
+// aren't nil. Paying an invoice settles its order.
+func UpdateInvoice(ctx context.Context, invoiceId string, invoice models.Invoice) (*mongo.UpdateResult, error) {
+	var updateObj primitive.D
+
+	if invoice.Payment_method != nil {
+		updateObj = append(updateObj, bson.E{"payment_method", invoice.Payment_method})
+	}
+
+	if invoice.Payment_status != nil {
+		if err := validate.Var(*invoice.Payment_status, "eq=PENDING|eq=PAID"); err != nil {
+			return nil, invalid(http.StatusBadRequest, err.Error())
+		}
+		updateObj = append(updateObj, bson.E{"payment_status", invoice.Payment_status})
+	}
+
+	invoice.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", invoice.Updated_at})
+
+	// paying an invoice settles its order and frees the table atomically
+	var result *mongo.UpdateResult
+	var paidOrderId string
+	updateErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := dataStore.Invoices().Get(ctx, invoiceId)
+		if err != nil {
+			return err
+		}
+
+		if result, err = dataStore.Invoices().Update(ctx, invoiceId, updateObj); err != nil {
+			return err
+		}
+
+		updated, err := dataStore.Invoices().Get(ctx, invoiceId)
+		if err != nil {
+			return err
+		}
+		if !isPaid(invoice.Payment_status) || isPaid(current.Payment_status) {
+			return record(ctx, events.InvoiceUpdated, updated)
+		}
+		paidOrderId = current.Order_id
+		if err := record(ctx, events.InvoicePaid, updated); err != nil {
+			return err
+		}
+		return settleOrder(ctx, current.Order_id)
+	})
+	if updateErr != nil {
+		return nil, notFound(http.StatusBadRequest, "invoice update failed", updateErr)
+	}
+
+	if paidOrderId != "" {
+		recordInvoicePaid(ctx, paidOrderId)
+	}
+
+	return result, nil
+}
+
+func isPaid(status *string) bool {
+	return status != nil && *status == "PAID"
+}
+
+// recordInvoicePaid counts the invoice and the amount due on its order in
+// the business metrics.
+func recordInvoicePaid(ctx context.Context, orderId string) {
+	var amount float64
+	if allOrderItems, err := ItemsByOrder(orderId, ctx); err == nil && len(allOrderItems) > 0 {
+		amount, _ = allOrderItems[0]["payment_due"].(float64)
+	}
+	metrics.InvoicePaid(amount)
+}
+
+func DeleteInvoice(ctx context.Context, invoiceId string) (*mongo.DeleteResult, error) {
+	if _, err := dataStore.Invoices().Get(ctx, invoiceId); err != nil {
+		return nil, notFound(http.StatusBadRequest, "invoice not found", err)
+	}
+
+	result, deleteErr := dataStore.Invoices().Delete(ctx, invoiceId)
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete invoice", deleteErr)
+	}
+	return result, nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"strings"
+	"time"
+	_ "time/tzdata" // the time zones of the menus, wherever the API runs
+)
+
+func ListMenus(ctx context.Context) ([]bson.M, error) {
+	allMenus, err := dataStore.Menus().All(ctx)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, err.Error(), err)
+	}
+	return allMenus, nil
+}
+
+func GetMenu(ctx context.Context, menuId string) (models.Menu, error) {
+	menu, err := dataStore.Menus().Get(ctx, menuId)
+	if err != nil {
+		return menu, notFound(http.StatusInternalServerError, "Menu item not found", err)
+	}
+	return menu, nil
+}
+
+// ActiveMenus returns the menus that can be ordered from at t, see
+// menuActive.
+func ActiveMenus(ctx context.Context, at time.Time) ([]models.Menu, error) {
+	allMenus, err := dataStore.Menus().All(ctx)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing the menus", err)
+	}
+	menus, err := store.Decode[models.Menu](allMenus)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing the menus", err)
+	}
+
+	active := []models.Menu{}
+	for _, menu := range menus {
+		if menuActive(menu, at) {
+			active = append(active, menu)
+		}
+	}
+	return active, nil
+}
+
+// MenusByIds returns the menus having one of menuIds, in no particular order.
+func MenusByIds(ctx context.Context, menuIds []string) ([]models.Menu, error) {
+	menus, err := dataStore.Menus().GetMany(ctx, menuIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while fetching the menus", err)
+	}
+	return menus, nil
+}
+
+func CreateMenu(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error) {
+	if validateErr := validate.Struct(menu); validateErr != nil {
+		return nil, invalid(http.StatusBadRequest, validateErr.Error())
+	}
+
+	if (menu.Start_date == nil) != (menu.End_date == nil) || menu.Start_date != nil && !inTimeSpan(*menu.Start_date, *menu.End_date) {
+		return nil, invalid(http.StatusBadRequest, "kindly retype the time")
+	}
+	if menu.Availability != nil {
+		if err := checkAvailability(*menu.Availability); err != nil {
+			return nil, err
+		}
+	}
+
+	menu.ID = primitive.NewObjectID()
+	menu.Menu_id = menu.ID.Hex()
+	menu.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	menu.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	result, insertErr := dataStore.Menus().Create(ctx, menu)
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("Menu item was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "Menu item was not created", insertErr)
+	}
+	return result, nil
+}
+
+// inTimeSpan tells whether a menu can be ordered from between start and end,
+// which must not have passed.
+func inTimeSpan(start, end time.Time) bool {
+	return end.After(start) && end.After(time.Now())
+}
+
+// checkAvailability validates the schedule of a menu.
+func checkAvailability(availability models.Availability) error {
+	if err := validate.Struct(availability); err != nil {
+		return invalid(http.StatusBadRequest, err.Error())
+	}
+	for _, exception := range availability.Exceptions {
+		if (exception.Start == "") != (exception.End == "") {
+			return invalid(http.StatusBadRequest, "an exception opening the menu needs a start and an end")
+		}
+	}
+	return nil
+}
+
+// menuActive tells whether menu can be ordered from at t: t is within its
+// dates, if any, and one of the windows its availability opens on the day of
+// t, or on the day before for the windows ending after midnight, contains t.
+func menuActive(menu models.Menu, t time.Time) bool {
+	if menu.Start_date != nil && t.Before(*menu.Start_date) {
+		return false
+	}
+	if menu.End_date != nil && !t.Before(*menu.End_date) {
+		return false
+	}
+	if menu.Availability == nil {
+		return true
+	}
+
+	location := time.UTC
+	if menu.Availability.Time_zone != "" {
+		var err error
+		if location, err = time.LoadLocation(menu.Availability.Time_zone); err != nil {
+			return false
+		}
+	}
+	t = t.In(location)
+
+	for _, day := range []time.Time{t.AddDate(0, 0, -1), t} {
+		for _, w := range openings(*menu.Availability, day) {
+			if !t.Before(w.start) && t.Before(w.end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type opening struct {
+	start, end time.Time
+}
+
+// openings returns the windows availability opens on the day of day, in the
+// location of day. The exceptions of the day replace the rules.
+func openings(availability models.Availability, day time.Time) []opening {
+	year, month, date := day.Date()
+	at := func(clock string, days int) time.Time {
+		c, _ := time.Parse("15:04", clock)
+		return time.Date(year, month, date+days, c.Hour(), c.Minute(), 0, 0, day.Location())
+	}
+	open := func(start, end string) opening {
+		w := opening{start: at(start, 0), end: at(end, 0)}
+		if !w.end.After(w.start) {
+			w.end = at(end, 1)
+		}
+		return w
+	}
+
+	var windows []opening
+	exceptional := false
+	for _, exception := range availability.Exceptions {
+		if exception.Date != day.Format("2006-01-02") {
+			continue
+		}
+		exceptional = true
+		if exception.Start != "" {
+			windows = append(windows, open(exception.Start, exception.End))
+		}
+	}
+	if exceptional {
+		return windows
+	}
+
+	if len(availability.Rules) == 0 {
+		return []opening{{start: at("00:00", 0), end: at("00:00", 1)}}
+	}
+	weekday := strings.ToUpper(day.Weekday().String()[:3])
+	for _, rule := range availability.Rules {
+		for _, d := range rule.Days {
+			if d == weekday {
+				windows = append(windows, open(rule.Start, rule.End))
+				break
+			}
+		}
+	}
+	return windows
+}
+
+// UpdateMenu sets the fields of menu that aren't empty, the dates only
+// together. The availability replaces the one of the menu.
+func UpdateMenu(ctx context.Context, menuId string, menu models.Menu) (*mongo.UpdateResult, error) {
+	var menuObj primitive.D
+	if menu.Start_date != nil && menu.End_date != nil {
+		if !inTimeSpan(*menu.Start_date, *menu.End_date) {
+			return nil, invalid(http.StatusBadRequest, "kindly retype the time")
+		}
+		menuObj = append(menuObj, bson.E{"start_date", menu.Start_date})
+		menuObj = append(menuObj, bson.E{"end_date", menu.End_date})
+	}
+
+	if menu.Name != "" {
+		menuObj = append(menuObj, bson.E{"name", menu.Name})
+	}
+
+	if menu.Category != "" {
+		menuObj = append(menuObj, bson.E{"category", menu.Category})
+	}
+
+	if menu.Availability != nil {
+		if err := checkAvailability(*menu.Availability); err != nil {
+			return nil, err
+		}
+		menuObj = append(menuObj, bson.E{"availability", menu.Availability})
+	}
+
+	menu.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	menuObj = append(menuObj, bson.E{"updated_at", menu.Updated_at})
+
+	result, updateErr := dataStore.Menus().Update(ctx, menuId, menuObj)
+	if updateErr != nil {
+		logger.FromContext(ctx).Error("menu item update failed", zap.Error(updateErr))
+		return nil, failed(http.StatusInternalServerError, "menu item update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteMenu(ctx context.Context, menuId string) (*mongo.DeleteResult, error) {
+	if _, err := dataStore.Menus().Get(ctx, menuId); err != nil {
+		return nil, notFound(http.StatusBadRequest, "menu item not found", err)
+	}
+
+	result, deleteErr := dataStore.Menus().Delete(ctx, menuId)
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete menu item", deleteErr)
+	}
+	return result, nil
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"time"
+)
+
+func ListOrders(ctx context.Context, page, recordPerPage int) ([]bson.M, int64, error) {
+	startIndex, limit := pageBounds(page, recordPerPage)
+
+	orders, total, err := dataStore.Orders().Page(ctx, startIndex, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing order items", zap.Error(err))
+		return nil, 0, failed(http.StatusInternalServerError, "error occurred while listing order items", err)
+	}
+	return orders, total, nil
+}
+
+func GetOrder(ctx context.Context, orderId string) (models.Order, error) {
+	order, err := dataStore.Orders().Get(ctx, orderId)
+	if err != nil {
+		return order, notFound(http.StatusInternalServerError, "order not found", err)
+	}
+	return order, nil
+}
+
+// OrdersByIds returns the orders having one of orderIds, in no particular
+// order.
+func OrdersByIds(ctx context.Context, orderIds []string) ([]models.Order, error) {
+	orders, err := dataStore.Orders().GetMany(ctx, orderIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while fetching the orders", err)
+	}
+	return orders, nil
+}
+
+// CreateOrder opens an order and occupies its table.
+func CreateOrder(ctx context.Context, order models.Order) (*mongo.InsertOneResult, error) {
+	if validateErr := validate.Struct(order); validateErr != nil {
+		return nil, invalid(http.StatusBadRequest, validateErr.Error())
+	}
+
+	if !checkOrderDate(order.Order_date) {
+		return nil, invalid(http.StatusBadRequest, "kindly retype the time")
+	}
+
+	if _, err := dataStore.Tables().Get(ctx, *order.Table_id); err != nil {
+		return nil, invalidReference(http.StatusBadRequest, "table not found", err)
+	}
+
+	status := "OPEN"
+	order.Order_status = &status
+	order.ID = primitive.NewObjectID()
+	order.Order_id = order.ID.Hex()
+	order.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	order.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	var result *mongo.InsertOneResult
+	insertErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = dataStore.Orders().Create(ctx, order); err != nil {
+			return err
+		}
+		if err := record(ctx, events.OrderPlaced, order); err != nil {
+			return err
+		}
+		return setTableStatus(ctx, *order.Table_id, "OCCUPIED")
+	})
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("order was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "order was not created", insertErr)
+	}
+	metrics.OrderCreated()
+
+	return result, nil
+}
+
+func checkOrderDate(date time.Time) bool {
+	return date.After(time.Now())
+}
+
+// UpdateOrder moves the order to another date, and to another table when
+// Table_id is set. An open order occupies its new table and frees the old one.
+func UpdateOrder(ctx context.Context, orderId string, order models.Order) (*mongo.UpdateResult, error) {
+	var updateObj primitive.D
+
+	if order.Table_id != nil {
+		if _, errTable := dataStore.Tables().Get(ctx, *order.Table_id); errTable != nil {
+			return nil, invalidReference(http.StatusBadRequest, "table not found", errTable)
+		}
+		updateObj = append(updateObj, bson.E{"table_id", order.Table_id})
+	}
+
+	if !checkOrderDate(order.Order_date) {
+		return nil, invalid(http.StatusBadRequest, "kindly retype the time")
+	}
+	updateObj = append(updateObj, bson.E{"order_date", order.Order_date})
+
+	order.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", order.Updated_at})
+
+	// an open order moved to another table occupies it and frees the one it
+	// leaves, in the unit of work of the move
+	var result *mongo.UpdateResult
+	updateErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := dataStore.Orders().Get(ctx, orderId)
+		if err != nil {
+			return notFound(http.StatusNotFound, "order not found", err)
+		}
+
+		if result, err = dataStore.Orders().Update(ctx, orderId, updateObj); err != nil {
+			return err
+		}
+		updated, err := dataStore.Orders().Get(ctx, orderId)
+		if err != nil {
+			return err
+		}
+		if err := record(ctx, events.OrderUpdated, updated); err != nil {
+			return err
+		}
+
+		moved := order.Table_id != nil && (current.Table_id == nil || *current.Table_id != *order.Table_id)
+		if !moved || isPaid(current.Order_status) {
+			return nil
+		}
+		if err := setTableStatus(ctx, *order.Table_id, "OCCUPIED"); err != nil {
+			return err
+		}
+		if current.Table_id == nil {
+			return nil
+		}
+		return setTableStatus(ctx, *current.Table_id, "FREE")
+	})
+	var serviceErr *Error
+	if errors.As(updateErr, &serviceErr) {
+		return nil, updateErr
+	}
+	if updateErr != nil {
+		logger.FromContext(ctx).Error("order item update failed", zap.Error(updateErr))
+		return nil, failed(http.StatusInternalServerError, "order item update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteOrder(ctx context.Context, orderId string) (*mongo.DeleteResult, error) {
+	order, err := dataStore.Orders().Get(ctx, orderId)
+	if err != nil {
+		return nil, notFound(http.StatusBadRequest, "order item not found", err)
+	}
+
+	var result *mongo.DeleteResult
+	deleteErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = dataStore.Orders().Delete(ctx, orderId); err != nil {
+			return err
+		}
+		return record(ctx, events.OrderDeleted, order)
+	})
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete order item", deleteErr)
+	}
+	return result, nil
+}
+
+// createOrderForOrderItem stores an open order for the items being ordered,
+// keeping the order ID when the caller already assigned one.
+func createOrderForOrderItem(ctx context.Context, order models.Order) (models.Order, error) {
+	status := "OPEN"
+	order.Order_status = &status
+	order.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	order.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	if order.ID.IsZero() {
+		order.ID = primitive.NewObjectID()
+		order.Order_id = order.ID.Hex()
+	}
+
+	_, insertErr := dataStore.Orders().Create(ctx, order)
+	if insertErr != nil {
+		return order, insertErr
+	}
+
+	return order, nil
+}
+
+// settleOrder marks an order as paid and frees its table. It is meant to run
+// in the same unit of work as the invoice becoming PAID.
+func settleOrder(ctx context.Context, orderId string) error {
+	order, err := dataStore.Orders().Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	_, err = dataStore.Orders().Update(ctx, orderId, bson.D{
+		{"order_status", "PAID"},
+		{"updated_at", updatedAt},
+	})
+	if err != nil {
+		return err
+	}
+
+	if order.Table_id == nil {
+		return nil
+	}
+	return setTableStatus(ctx, *order.Table_id, "FREE")
+}
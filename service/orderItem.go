@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/helpers"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"time"
+)
+
+var tracer = otel.Tracer("restaurant_management/service")
+
+type OrderItemPack struct {
+	Table_id    *string            `json:"table_id" validate:"required"`
+	Order_items []models.OrderItem `json:"order_items"  validate:"required"`
+}
+
+func ListOrderItems(ctx context.Context) ([]bson.M, error) {
+	orderItems, findErr := dataStore.OrderItems().All(ctx)
+	if findErr != nil {
+		logger.FromContext(ctx).Error("error occurred while listing ordered items", zap.Error(findErr))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing ordered items", findErr)
+	}
+	return orderItems, nil
+}
+
+// OrderItemsByOrder returns the summary of the items of an order, see
+// ItemsByOrder.
+func OrderItemsByOrder(ctx context.Context, orderId string) ([]bson.M, error) {
+	allOrderItems, err := ItemsByOrder(orderId, ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing order items by order ID", zap.Error(err))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing order items by order ID", err)
+	}
+	return allOrderItems, nil
+}
+
+func GetOrderItem(ctx context.Context, orderItemId string) (models.OrderItem, error) {
+	orderItem, err := dataStore.OrderItems().Get(ctx, orderItemId)
+	if err != nil {
+		return orderItem, notFound(http.StatusInternalServerError, "error occurred while fetching the order item", err)
+	}
+	return orderItem, nil
+}
+
+func OrderItemsByOrders(ctx context.Context, orderIds []string) ([]models.OrderItem, error) {
+	orderItems, err := dataStore.OrderItems().ByOrders(ctx, orderIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing order items by order ID", err)
+	}
+	return orderItems, nil
+}
+
+// CreateOrderItems opens an order on the table of the pack with its items
+// and occupies the table. The unit prices are computed from the foods, only
+// callers with the price_override permission can set them.
+func CreateOrderItems(ctx context.Context, orderItemPack OrderItemPack) (*mongo.InsertManyResult, error) {
+	var order models.Order
+
+	order.Order_date, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	if orderItemPack.Table_id == nil {
+		return nil, invalid(http.StatusBadRequest, "Error select table")
+	}
+	order.Table_id = orderItemPack.Table_id
+
+	if _, err := dataStore.Tables().Get(ctx, *order.Table_id); err != nil {
+		return nil, invalidReference(http.StatusBadRequest, "table not found", err)
+	}
+
+	order.ID = primitive.NewObjectID()
+	order.Order_id = order.ID.Hex()
+
+	var orderItemsToBeInserted []models.OrderItem
+	overridePrice := helpers.HasPermission(ctx, helpers.PriceOverride)
+
+	for _, orderItem := range orderItemPack.Order_items {
+		orderItem.Order_id = order.Order_id
+		validationErr := validate.Struct(orderItem)
+		if validationErr != nil {
+			return nil, invalid(http.StatusBadRequest, validationErr.Error())
+		}
+		if orderItem.Unit_price != nil && !overridePrice {
+			return nil, forbidden(priceOverrideMessage)
+		}
+
+		orderItem.ID = primitive.NewObjectID()
+		orderItem.Order_item_id = orderItem.ID.Hex()
+		orderItem.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+		orderItem.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+		orderItemsToBeInserted = append(orderItemsToBeInserted, orderItem)
+	}
+
+	foods, err := orderedFoods(ctx, orderItemsToBeInserted)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrderable(ctx, foods, order.Order_date); err != nil {
+		return nil, err
+	}
+	if err := checkInStock(foods, order.Order_date); err != nil {
+		return nil, err
+	}
+	if err := checkPortions(orderItemsToBeInserted, foods, order.Order_date); err != nil {
+		return nil, err
+	}
+	for i := range orderItemsToBeInserted {
+		orderItem := &orderItemsToBeInserted[i]
+		food := foods[*orderItem.Food_id]
+		if err := selectModifiers(orderItem, food); err != nil {
+			return nil, err
+		}
+		orderItem.Unit_price = unitPrice(*orderItem, food)
+	}
+
+	// the order, its items and the table status are written together so a
+	// failing insert never leaves an order without items behind. The
+	// portions are taken first, the order is only written once they are.
+	var result *mongo.InsertManyResult
+	txErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := takePortions(ctx, orderItemsToBeInserted, foods, order.Order_date); err != nil {
+			return err
+		}
+
+		created, err := createOrderForOrderItem(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		if result, err = dataStore.OrderItems().CreateMany(ctx, orderItemsToBeInserted); err != nil {
+			return err
+		}
+
+		if err := record(ctx, events.OrderPlaced, created); err != nil {
+			return err
+		}
+		for _, orderItem := range orderItemsToBeInserted {
+			if err := record(ctx, events.ItemAdded, orderItem); err != nil {
+				return err
+			}
+		}
+
+		return setTableStatus(ctx, *order.Table_id, "OCCUPIED")
+	})
+	var serviceErr *Error
+	if errors.As(txErr, &serviceErr) {
+		return nil, txErr
+	}
+	if txErr != nil {
+		logger.FromContext(ctx).Error("order items insert failed", zap.Error(txErr))
+		return nil, failed(http.StatusInternalServerError, "order items insert failed", txErr)
+	}
+
+	metrics.OrderCreated()
+	for _, orderItem := range orderItemsToBeInserted {
+		metrics.ItemSold(*orderItem.Food_id)
+	}
+
+	return result, nil
+}
+
+// orderedFoods returns the foods of the order items by ID, rejecting the
+// items whose food is missing.
+func orderedFoods(ctx context.Context, orderItems []models.OrderItem) (map[string]models.Food, error) {
+	foodIds := make([]string, 0, len(orderItems))
+	for _, orderItem := range orderItems {
+		foodIds = append(foodIds, *orderItem.Food_id)
+	}
+	foods, err := dataStore.Foods().GetMany(ctx, foodIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while fetching the food items", err)
+	}
+
+	foodsById := make(map[string]models.Food, len(foods))
+	for _, food := range foods {
+		foodsById[food.Food_id] = food
+	}
+	for _, foodId := range foodIds {
+		if _, ok := foodsById[foodId]; !ok {
+			return nil, invalid(http.StatusBadRequest, "food not found")
+		}
+	}
+	return foodsById, nil
+}
+
+// checkOrderable rejects the foods that belong to a menu that isn't active
+// at t.
+func checkOrderable(ctx context.Context, foods map[string]models.Food, at time.Time) error {
+	menuIds := make([]string, 0, len(foods))
+	for _, food := range foods {
+		menuIds = append(menuIds, *food.Menu_id)
+	}
+	menus, err := dataStore.Menus().GetMany(ctx, menuIds)
+	if err != nil {
+		return failed(http.StatusInternalServerError, "error occurred while fetching the menus", err)
+	}
+	active := make(map[string]bool, len(menus))
+	for _, menu := range menus {
+		active[menu.Menu_id] = menuActive(menu, at)
+	}
+
+	for _, food := range foods {
+		if !active[*food.Menu_id] {
+			return invalid(http.StatusBadRequest, *food.Name+" is not available at this time")
+		}
+	}
+	return nil
+}
+
+// selectModifiers checks the modifiers selected for orderItem against the
+// modifier groups of its food and copies their names and price deltas.
+func selectModifiers(orderItem *models.OrderItem, food models.Food) error {
+	selections := make(map[string]int, len(food.Modifier_groups))
+	selected := map[string]bool{}
+	for i := range orderItem.Modifiers {
+		modifier := &orderItem.Modifiers[i]
+		group, option, ok := modifierOption(food, modifier.Group_id, modifier.Option_id)
+		if !ok {
+			return invalid(http.StatusBadRequest, *food.Name+" has no modifier "+modifier.Group_id+"/"+modifier.Option_id)
+		}
+		key := group.Group_id + "/" + option.Option_id
+		if selected[key] {
+			return invalid(http.StatusBadRequest, option.Name+" is selected twice")
+		}
+		selected[key] = true
+
+		modifier.Group_name = group.Name
+		modifier.Name = option.Name
+		modifier.Price_delta = option.Price_delta
+		selections[group.Group_id]++
+	}
+
+	for _, group := range food.Modifier_groups {
+		min := group.Min_selections
+		if group.Required && min == 0 {
+			min = 1
+		}
+		if n := selections[group.Group_id]; n < min {
+			return invalid(http.StatusBadRequest, fmt.Sprintf("%s needs at least %d %s", *food.Name, min, group.Name))
+		} else if group.Max_selections > 0 && n > group.Max_selections {
+			return invalid(http.StatusBadRequest, fmt.Sprintf("%s allows at most %d %s", *food.Name, group.Max_selections, group.Name))
+		}
+	}
+	return nil
+}
+
+// priceOverrideMessage rejects the unit prices set without the
+// price_override permission.
+const priceOverrideMessage = "unit_price can only be set with the " + helpers.PriceOverride + " permission"
+
+// unitPrice returns the price of orderItem: the price of its size, or of the
+// food when the size has none, with the price deltas of its modifiers. A
+// unit price set on orderItem overrides it.
+func unitPrice(orderItem models.OrderItem, food models.Food) *float64 {
+	price := *food.Price
+	if sizePrice, ok := food.Size_prices[*orderItem.Quantity]; ok {
+		price = sizePrice
+	}
+	for _, modifier := range orderItem.Modifiers {
+		price += modifier.Price_delta
+	}
+	if orderItem.Unit_price != nil {
+		price = *orderItem.Unit_price
+	}
+	price = toFixed(price, 2)
+	return &price
+}
+
+func modifierOption(food models.Food, groupId, optionId string) (models.ModifierGroup, models.ModifierOption, bool) {
+	for _, group := range food.Modifier_groups {
+		if group.Group_id != groupId {
+			continue
+		}
+		for _, option := range group.Options {
+			if option.Option_id == optionId {
+				return group, option, true
+			}
+		}
+	}
+	return models.ModifierGroup{}, models.ModifierOption{}, false
+}
+
+// UpdateOrderItem sets the fields of orderItem that aren't nil, its
+// modifiers replacing the selected ones. Changing the size, the food or the
+// modifiers prices the item again, unless a caller with the price_override
+// permission sets the unit price. A new food has to be
+// orderable and in stock like the foods of a new order, a portion of it
+// being taken.
+func UpdateOrderItem(ctx context.Context, orderItemId string, orderItem models.OrderItem) (*mongo.UpdateResult, error) {
+	var updateObj primitive.D
+	var swapped map[string]models.Food
+	now := time.Now()
+
+	if orderItem.Unit_price != nil && !helpers.HasPermission(ctx, helpers.PriceOverride) {
+		return nil, forbidden(priceOverrideMessage)
+	}
+	var fields []string
+	if orderItem.Quantity != nil {
+		fields = append(fields, "Quantity")
+	}
+	if orderItem.Unit_price != nil {
+		fields = append(fields, "Unit_price")
+	}
+	if len(fields) > 0 {
+		if validationErr := validate.StructPartial(orderItem, fields...); validationErr != nil {
+			return nil, invalid(http.StatusBadRequest, validationErr.Error())
+		}
+	}
+
+	if orderItem.Quantity != nil || orderItem.Unit_price != nil || orderItem.Food_id != nil || orderItem.Modifiers != nil {
+		current, err := dataStore.OrderItems().Get(ctx, orderItemId)
+		if err != nil {
+			return nil, notFound(http.StatusNotFound, "order item not found", err)
+		}
+		if orderItem.Quantity != nil {
+			current.Quantity = orderItem.Quantity
+			updateObj = append(updateObj, bson.E{"quantity", orderItem.Quantity})
+		}
+		changed := orderItem.Food_id != nil && *orderItem.Food_id != *current.Food_id
+		if orderItem.Food_id != nil {
+			current.Food_id = orderItem.Food_id
+		}
+		if orderItem.Modifiers != nil {
+			current.Modifiers = orderItem.Modifiers
+		}
+
+		food, err := dataStore.Foods().Get(ctx, *current.Food_id)
+		if err != nil {
+			return nil, invalidReference(http.StatusInternalServerError, "food not found", err)
+		}
+		if changed {
+			swapped = map[string]models.Food{food.Food_id: food}
+			if err := checkOrderable(ctx, swapped, now); err != nil {
+				return nil, err
+			}
+			if err := checkInStock(swapped, now); err != nil {
+				return nil, err
+			}
+			if err := checkPortions([]models.OrderItem{current}, swapped, now); err != nil {
+				return nil, err
+			}
+		}
+		if orderItem.Food_id != nil || orderItem.Modifiers != nil {
+			// the modifiers selected must be options of the food
+			if err := selectModifiers(&current, food); err != nil {
+				return nil, err
+			}
+			updateObj = append(updateObj, bson.E{"food_id", current.Food_id}, bson.E{"modifiers", current.Modifiers})
+		}
+
+		current.Unit_price = orderItem.Unit_price
+		updateObj = append(updateObj, bson.E{"unit_price", unitPrice(current, food)})
+	}
+
+	orderItem.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", orderItem.Updated_at})
+
+	// the portion of a new food is taken before the update, it is given back
+	// when the update fails
+	var result *mongo.UpdateResult
+	updateErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		if swapped != nil {
+			if err := takePortions(ctx, []models.OrderItem{{Food_id: orderItem.Food_id}}, swapped, now); err != nil {
+				return err
+			}
+		}
+		var err error
+		if result, err = dataStore.OrderItems().Update(ctx, orderItemId, updateObj); err != nil {
+			return err
+		}
+		updated, err := dataStore.OrderItems().Get(ctx, orderItemId)
+		if err != nil {
+			return err
+		}
+		return record(ctx, events.ItemUpdated, updated)
+	})
+	var serviceErr *Error
+	if errors.As(updateErr, &serviceErr) {
+		return nil, updateErr
+	}
+	if updateErr != nil {
+		logger.FromContext(ctx).Error("order item update failed", zap.Error(updateErr))
+		return nil, failed(http.StatusInternalServerError, "order item update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteOrderItem(ctx context.Context, orderItemId string) (*mongo.DeleteResult, error) {
+	orderItem, err := dataStore.OrderItems().Get(ctx, orderItemId)
+	if err != nil {
+		return nil, notFound(http.StatusBadRequest, "order item not found", err)
+	}
+
+	var result *mongo.DeleteResult
+	deleteErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = dataStore.OrderItems().Delete(ctx, orderItemId); err != nil {
+			return err
+		}
+		return record(ctx, events.ItemDeleted, orderItem)
+	})
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete order item", deleteErr)
+	}
+	return result, nil
+}
+
+func ItemsByOrder(OderId string, ctx context.Context) (orderItems []primitive.M, err error) {
+	ctx, span := tracer.Start(ctx, "ItemsByOrder", trace.WithAttributes(attribute.String("order_id", OderId)))
+	defer span.End()
+
+	orderItems, err = dataStore.OrderItems().ItemsByOrder(ctx, OderId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return orderItems, err
+}
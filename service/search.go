@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"restaurant_management/store"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchResult is a food or a menu found by Search, the higher its Score the
+// better the match. Menu_id and Menu_name are the menu of a food.
+type SearchResult struct {
+	Type      string  `json:"type"`
+	Id        string  `json:"id"`
+	Name      string  `json:"name"`
+	Menu_id   string  `json:"menu_id,omitempty"`
+	Menu_name string  `json:"menu_name,omitempty"`
+	Score     float64 `json:"score"`
+}
+
+// The bounds of a search.
+const (
+	DefaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxSearchLength    = 100
+)
+
+// Search returns the limit foods and menus best matching query, only those
+// of kind, "food" or "menu", when it isn't empty. The names, tags and
+// descriptions of the foods and the names and categories of the menus are
+// searched, tolerating typos and the last words being typed.
+func Search(ctx context.Context, query, kind string, limit int) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	switch {
+	case query == "":
+		return nil, invalid(http.StatusBadRequest, "q is required")
+	case utf8.RuneCountInString(query) > maxSearchLength:
+		return nil, invalid(http.StatusBadRequest, "q is longer than 100 characters")
+	case kind != "" && kind != store.FoodHit && kind != store.MenuHit:
+		return nil, invalid(http.StatusBadRequest, "type must be food or menu")
+	case limit < 1 || limit > maxSearchLimit:
+		return nil, invalid(http.StatusBadRequest, "limit must be between 1 and 50")
+	}
+
+	var hits []store.SearchHit
+	if kind != store.MenuHit {
+		foods, err := dataStore.Foods().Search(ctx, query, limit)
+		if err != nil {
+			return nil, failed(http.StatusInternalServerError, "error occurred while searching the foods", err)
+		}
+		hits = append(hits, foods...)
+	}
+	if kind != store.FoodHit {
+		menus, err := dataStore.Menus().Search(ctx, query, limit)
+		if err != nil {
+			return nil, failed(http.StatusInternalServerError, "error occurred while searching the menus", err)
+		}
+		hits = append(hits, menus...)
+	}
+	// the hits of both are ranked alike, a menu first on a tie
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Kind == store.MenuHit && hits[j].Kind == store.FoodHit
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	menuNames, err := searchedMenuNames(ctx, hits)
+	if err != nil {
+		return nil, err
+	}
+	results := []SearchResult{}
+	for _, hit := range hits {
+		results = append(results, SearchResult{
+			Type:      hit.Kind,
+			Id:        hit.ID,
+			Name:      hit.Name,
+			Menu_id:   hit.Menu_id,
+			Menu_name: menuNames[hit.Menu_id],
+			Score:     toFixed(hit.Score, 2),
+		})
+	}
+	return results, nil
+}
+
+// searchedMenuNames returns the names of the menus of the foods of hits, by
+// ID.
+func searchedMenuNames(ctx context.Context, hits []store.SearchHit) (map[string]string, error) {
+	var menuIds []string
+	for _, hit := range hits {
+		if hit.Menu_id != "" {
+			menuIds = append(menuIds, hit.Menu_id)
+		}
+	}
+	names := map[string]string{}
+	if len(menuIds) == 0 {
+		return names, nil
+	}
+
+	menus, err := dataStore.Menus().GetMany(ctx, menuIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing the menus of the foods", err)
+	}
+	for _, menu := range menus {
+		names[menu.Menu_id] = menu.Name
+	}
+	return names, nil
+}
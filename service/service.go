@@ -0,0 +1,95 @@
+// Package service holds the operations on foods, menus, tables, orders,
+// order items and invoices shared by the REST and gRPC APIs: validation,
+// timestamps and the writes that have to happen together.
+package service
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"restaurant_management/store"
+)
+
+var dataStore store.Store
+
+var validate = validator.New()
+
+// UseStore sets the storage backend the operations read from and write to.
+func UseStore(s store.Store) {
+	dataStore = s
+}
+
+// Kind tells why an operation failed, whatever API it was called through.
+type Kind int
+
+const (
+	Internal Kind = iota
+	Invalid
+	NotFound
+	Forbidden
+)
+
+// Error is a failed operation. The REST API answers Status with Message, the
+// gRPC API maps Kind to a status code.
+type Error struct {
+	Kind    Kind
+	Status  int
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func invalid(status int, msg string) error {
+	return &Error{Kind: Invalid, Status: status, Message: msg}
+}
+
+// forbidden reports an operation the caller lacks permission for.
+func forbidden(msg string) error {
+	return &Error{Kind: Forbidden, Status: http.StatusForbidden, Message: msg}
+}
+
+// notFound reports a document that couldn't be read, err being the error of
+// the store: only a missing document is NotFound.
+func notFound(status int, msg string, err error) error {
+	kind := Internal
+	if errors.Is(err, store.ErrNotFound) {
+		kind = NotFound
+	}
+	return &Error{Kind: kind, Status: status, Message: msg, Err: err}
+}
+
+// invalidReference reports a document referencing another one that couldn't
+// be read.
+func invalidReference(status int, msg string, err error) error {
+	kind := Internal
+	if errors.Is(err, store.ErrNotFound) {
+		kind = Invalid
+	}
+	return &Error{Kind: kind, Status: status, Message: msg, Err: err}
+}
+
+func failed(status int, msg string, err error) error {
+	return &Error{Kind: Internal, Status: status, Message: msg, Err: err}
+}
+
+// pageBounds returns the index of the first document of page, pages holding 2
+// documents when recordPerPage is lower than 1.
+func pageBounds(page, recordPerPage int) (startIndex, limit int) {
+	if recordPerPage < 1 {
+		recordPerPage = 2
+	}
+	if page < 1 {
+		page = 1
+	}
+	return (page - 1) * recordPerPage, recordPerPage
+}
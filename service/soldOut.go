@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"sort"
+	"time"
+)
+
+// SoldOutFood is an entry of the 86 list: a food sold out, or counted
+// because it has a daily limit.
+type SoldOutFood struct {
+	Food_id string `json:"food_id"`
+	Name    string `json:"name"`
+	Menu_id string `json:"menu_id"`
+	// Sold_out tells whether the food can't be ordered now.
+	Sold_out bool `json:"sold_out"`
+	// Sold_out_until is when a food sold out goes back on sale, nil when it
+	// is sold out until further notice.
+	Sold_out_until *time.Time `json:"sold_out_until"`
+	Daily_limit    *int       `json:"daily_limit"`
+	// Portions_left are the portions left today, nil without a daily limit.
+	Portions_left *int `json:"portions_left"`
+}
+
+// SoldOutFoods returns the 86 list at now: the foods sold out first, then
+// those with a daily limit, by name.
+func SoldOutFoods(ctx context.Context, now time.Time) ([]SoldOutFood, error) {
+	foods, err := dataStore.Foods().Limited(ctx)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing the sold out foods", err)
+	}
+
+	list := []SoldOutFood{}
+	for _, food := range foods {
+		entry := SoldOutFood{Food_id: food.Food_id, Daily_limit: food.Daily_limit}
+		if food.Name != nil {
+			entry.Name = *food.Name
+		}
+		if food.Menu_id != nil {
+			entry.Menu_id = *food.Menu_id
+		}
+		if left, limited := portionsLeft(food, now); limited {
+			entry.Portions_left = &left
+		}
+		entry.Sold_out, entry.Sold_out_until = soldOut(food, now)
+		if entry.Sold_out || entry.Portions_left != nil {
+			list = append(list, entry)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Sold_out != list[j].Sold_out {
+			return list[i].Sold_out
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// soldOut reports whether food can't be ordered at now, and until when: the
+// end of its sold out period, or the next day once its daily limit is sold.
+func soldOut(food models.Food, now time.Time) (bool, *time.Time) {
+	if food.Sold_out != nil && *food.Sold_out && (food.Sold_out_until == nil || now.Before(*food.Sold_out_until)) {
+		return true, food.Sold_out_until
+	}
+	if left, limited := portionsLeft(food, now); limited && left == 0 {
+		year, month, date := now.Local().Date()
+		tomorrow := time.Date(year, month, date+1, 0, 0, 0, 0, time.Local)
+		return true, &tomorrow
+	}
+	return false, nil
+}
+
+// portionsLeft returns the portions of food left on the day of now, limited
+// being false when it has no daily limit.
+func portionsLeft(food models.Food, now time.Time) (left int, limited bool) {
+	if food.Daily_limit == nil {
+		return 0, false
+	}
+	left = *food.Daily_limit
+	if food.Portions_date == day(now) {
+		left -= food.Portions_sold
+	}
+	if left < 0 {
+		left = 0
+	}
+	return left, true
+}
+
+// day returns the date of t in the time zone of the restaurant, the one of
+// the server, the daily limits being counted from midnight to midnight.
+func day(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+// checkSoldOutUntil checks the sold out period of food at now: only a food
+// sold out has one, and it ends later.
+func checkSoldOutUntil(food models.Food, now time.Time) error {
+	if food.Sold_out_until == nil {
+		return nil
+	}
+	if food.Sold_out == nil || !*food.Sold_out {
+		return invalid(http.StatusBadRequest, "sold_out_until needs sold_out")
+	}
+	if !food.Sold_out_until.After(now) {
+		return invalid(http.StatusBadRequest, "sold_out_until must be in the future")
+	}
+	return nil
+}
+
+// checkInStock rejects the foods sold out at t.
+func checkInStock(foods map[string]models.Food, t time.Time) error {
+	for _, food := range foods {
+		if out, _ := soldOut(food, t); out {
+			return invalid(http.StatusBadRequest, *food.Name+" is sold out")
+		}
+	}
+	return nil
+}
+
+// portionsOf adds up the portions of the order items, one per item, for the
+// foods with a daily limit. foodIds lists them in the order of the items.
+func portionsOf(orderItems []models.OrderItem, foods map[string]models.Food) (foodIds []string, portions map[string]int) {
+	portions = map[string]int{}
+	for _, orderItem := range orderItems {
+		if foods[*orderItem.Food_id].Daily_limit == nil {
+			continue
+		}
+		if portions[*orderItem.Food_id] == 0 {
+			foodIds = append(foodIds, *orderItem.Food_id)
+		}
+		portions[*orderItem.Food_id]++
+	}
+	return foodIds, portions
+}
+
+// checkPortions rejects the order items asking more portions of a food than
+// it has left on the day of t, before anything is written.
+func checkPortions(orderItems []models.OrderItem, foods map[string]models.Food, t time.Time) error {
+	foodIds, portions := portionsOf(orderItems, foods)
+	for _, foodId := range foodIds {
+		food := foods[foodId]
+		if left, _ := portionsLeft(food, t); portions[foodId] > left {
+			return invalid(http.StatusBadRequest, fmt.Sprintf("%s has %d portions left", *food.Name, left))
+		}
+	}
+	return nil
+}
+
+// takePortions counts the portions of the order items sold on the day of t
+// for the foods with a daily limit, and records the foods that sell their
+// last portion. It is the first write of the unit of work writing the items:
+// the portions are given back when they aren't written, and without
+// transactions a food sold out meanwhile leaves nothing else behind.
+func takePortions(ctx context.Context, orderItems []models.OrderItem, foods map[string]models.Food, t time.Time) error {
+	foodIds, portions := portionsOf(orderItems, foods)
+	for _, foodId := range foodIds {
+		food := foods[foodId]
+		left, ok, err := dataStore.Foods().TakePortions(ctx, foodId, day(t), portions[foodId])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			left, _ = portionsLeft(food, t)
+			return invalid(http.StatusBadRequest, fmt.Sprintf("%s has %d portions left", *food.Name, left))
+		}
+		if left == 0 {
+			food.Portions_sold = *food.Daily_limit
+			food.Portions_date = day(t)
+			if err := record(ctx, events.FoodSoldOut, food); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordAvailability records the event of food being sold out or restored
+// at now, when it changed since previous.
+func recordAvailability(ctx context.Context, previous, food models.Food, now time.Time) error {
+	wasOut, _ := soldOut(previous, now)
+	out, _ := soldOut(food, now)
+	switch {
+	case out && !wasOut:
+		return record(ctx, events.FoodSoldOut, food)
+	case wasOut && !out:
+		return record(ctx, events.FoodRestored, food)
+	}
+	return nil
+}
+
+// RestoreFoods puts back on sale the foods whose sold out period ended by
+// now, and starts a new day for those that sold their daily limit on an
+// earlier one, recording the foods restored.
+func RestoreFoods(ctx context.Context, now time.Time) error {
+	foods, err := dataStore.Foods().Limited(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, food := range foods {
+		expired := food.Sold_out != nil && *food.Sold_out && food.Sold_out_until != nil && !now.Before(*food.Sold_out_until)
+		// the portions of an earlier day were all sold
+		newDay := food.Daily_limit != nil && food.Portions_date != "" && food.Portions_date != day(now) && food.Portions_sold >= *food.Daily_limit
+		if !expired && !newDay {
+			continue
+		}
+
+		err := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+			if expired {
+				restored := false
+				food.Sold_out = &restored
+				food.Sold_out_until = nil
+				if _, err := dataStore.Foods().Update(ctx, food.Food_id, bson.D{{"sold_out", false}, {"sold_out_until", nil}}); err != nil {
+					return err
+				}
+			}
+			if newDay {
+				// taking no portion starts the day without losing the
+				// portions of the orders placed meanwhile
+				if _, _, err := dataStore.Foods().TakePortions(ctx, food.Food_id, day(now), 0); err != nil {
+					return err
+				}
+				food.Portions_sold = 0
+				food.Portions_date = day(now)
+			}
+			// the food was sold out until now, it may still be out of
+			// portions or sold out for another reason
+			if out, _ := soldOut(food, now); out {
+				return nil
+			}
+			return record(ctx, events.FoodRestored, food)
+		})
+		if err != nil {
+			return fmt.Errorf("restore food %s: %w", food.Food_id, err)
+		}
+	}
+	return nil
+}
+
+// RunFoodRestorer restores the foods every interval until ctx is done.
+func RunFoodRestorer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := RestoreFoods(ctx, now); err != nil {
+				logger.FromContext(ctx).Error("restoring the sold out foods failed", zap.Error(err))
+			}
+		}
+	}
+}
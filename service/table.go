@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"time"
+)
+
+func ListTables(ctx context.Context) ([]bson.M, error) {
+	allTables, err := dataStore.Tables().All(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing table", zap.Error(err))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing table", err)
+	}
+	return allTables, nil
+}
+
+func GetTable(ctx context.Context, tableId string) (models.Table, error) {
+	table, err := dataStore.Tables().Get(ctx, tableId)
+	if err != nil {
+		return table, notFound(http.StatusInternalServerError, "error occurred while fetching the table", err)
+	}
+	return table, nil
+}
+
+// TablesByIds returns the tables having one of tableIds, in no particular
+// order.
+func TablesByIds(ctx context.Context, tableIds []string) ([]models.Table, error) {
+	tables, err := dataStore.Tables().GetMany(ctx, tableIds)
+	if err != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while fetching the tables", err)
+	}
+	return tables, nil
+}
+
+// CreateTable creates a FREE table unless told otherwise.
+func CreateTable(ctx context.Context, table models.Table) (*mongo.InsertOneResult, error) {
+	if validationErr := validate.Struct(table); validationErr != nil {
+		return nil, invalid(http.StatusBadRequest, validationErr.Error())
+	}
+
+	status := "FREE"
+	if table.Table_status == nil {
+		table.Table_status = &status
+	}
+
+	table.ID = primitive.NewObjectID()
+	table.Table_id = table.ID.Hex()
+	table.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	table.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	result, insertErr := dataStore.Tables().Create(ctx, table)
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("table was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "table was not created", insertErr)
+	}
+	return result, nil
+}
+
+// UpdateTable sets the fields of table that aren't nil.
+func UpdateTable(ctx context.Context, tableId string, table models.Table) (*mongo.UpdateResult, error) {
+	var updateObj primitive.D
+
+	if table.Number_of_guests != nil {
+		updateObj = append(updateObj, bson.E{"number_of_guests", table.Number_of_guests})
+	}
+
+	if table.Table_number != nil {
+		updateObj = append(updateObj, bson.E{"table_number", table.Table_number})
+	}
+
+	if table.Table_status != nil {
+		if err := validate.Var(*table.Table_status, "eq=FREE|eq=OCCUPIED"); err != nil {
+			return nil, invalid(http.StatusBadRequest, err.Error())
+		}
+		updateObj = append(updateObj, bson.E{"table_status", table.Table_status})
+	}
+
+	table.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", table.Updated_at})
+
+	// moving the table to another status records the event of the move
+	var result *mongo.UpdateResult
+	updateErr := dataStore.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := dataStore.Tables().Get(ctx, tableId)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+
+		if result, err = dataStore.Tables().Update(ctx, tableId, updateObj); err != nil {
+			return err
+		}
+
+		if table.Table_status == nil || current.Table_id == "" {
+			return nil
+		}
+		moved, err := dataStore.Tables().Get(ctx, tableId)
+		if err != nil {
+			return err
+		}
+		return recordTableMove(ctx, current.Table_status, moved)
+	})
+	if updateErr != nil {
+		return nil, failed(http.StatusBadRequest, "table update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteTable(ctx context.Context, tableId string) (*mongo.DeleteResult, error) {
+	if _, err := dataStore.Tables().Get(ctx, tableId); err != nil {
+		return nil, notFound(http.StatusBadRequest, "table not found", err)
+	}
+
+	result, deleteErr := dataStore.Tables().Delete(ctx, tableId)
+	if deleteErr != nil {
+		return nil, failed(http.StatusBadRequest, "error occurred while delete table", deleteErr)
+	}
+	return result, nil
+}
+
+// OpenTables counts the tables currently occupied.
+func OpenTables(ctx context.Context) (int, error) {
+	allTables, err := dataStore.Tables().All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	open := 0
+	for _, table := range allTables {
+		if table["table_status"] == "OCCUPIED" {
+			open++
+		}
+	}
+	return open, nil
+}
+
+// setTableStatus moves a table to status, recording the event of the move in
+// the unit of work of ctx.
+func setTableStatus(ctx context.Context, tableId, status string) error {
+	table, err := dataStore.Tables().Get(ctx, tableId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	_, err = dataStore.Tables().Update(ctx, tableId, bson.D{
+		{"table_status", status},
+		{"updated_at", updatedAt},
+	})
+	if err != nil {
+		return err
+	}
+
+	previous := table.Table_status
+	table.Table_status = &status
+	table.Updated_at = updatedAt
+	return recordTableMove(ctx, previous, table)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/http"
+	"restaurant_management/helpers"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"time"
+)
+
+// Webhooks sends the deliveries of the events again and checks the URLs they
+// are sent to, see webhook.Dispatcher.
+type Webhooks interface {
+	Redeliver(ctx context.Context, deliveryId string) (models.WebhookDelivery, error)
+	CheckURL(ctx context.Context, url string) error
+}
+
+var webhooks Webhooks
+
+// UseWebhooks sets what redelivers the events, nothing does without it.
+func UseWebhooks(w Webhooks) {
+	webhooks = w
+}
+
+// manageWebhooksMessage rejects the callers without the manage_webhooks
+// permission.
+const manageWebhooksMessage = "webhooks can only be managed with the " + helpers.ManageWebhooks + " permission"
+
+// checkManageWebhooks rejects the callers that can't manage the webhooks.
+func checkManageWebhooks(ctx context.Context) error {
+	if !helpers.HasPermission(ctx, helpers.ManageWebhooks) {
+		return forbidden(manageWebhooksMessage)
+	}
+	return nil
+}
+
+// checkURL rejects the URLs the dispatcher won't send to, there is nothing
+// to check without it.
+func checkURL(ctx context.Context, url string) error {
+	if webhooks == nil {
+		return nil
+	}
+	if err := webhooks.CheckURL(ctx, url); err != nil {
+		return invalid(http.StatusBadRequest, "url: "+err.Error())
+	}
+	return nil
+}
+
+// withoutSecret hides the secret of a webhook document, it is only written.
+func withoutSecret(doc bson.M) bson.M {
+	delete(doc, "secret")
+	return doc
+}
+
+func ListWebhooks(ctx context.Context) ([]bson.M, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+	allWebhooks, err := dataStore.Webhooks().All(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing webhooks", zap.Error(err))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing webhooks", err)
+	}
+	for _, webhook := range allWebhooks {
+		withoutSecret(webhook)
+	}
+	return allWebhooks, nil
+}
+
+func GetWebhook(ctx context.Context, webhookId string) (models.Webhook, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return models.Webhook{}, err
+	}
+	webhook, err := dataStore.Webhooks().Get(ctx, webhookId)
+	if err != nil {
+		return webhook, notFound(http.StatusNotFound, "webhook not found", err)
+	}
+	webhook.Secret = nil
+	return webhook, nil
+}
+
+// CreateWebhook subscribes a URL to events, active unless told otherwise.
+func CreateWebhook(ctx context.Context, webhook models.Webhook) (*mongo.InsertOneResult, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+	if validationErr := validate.Struct(webhook); validationErr != nil {
+		return nil, invalid(http.StatusBadRequest, validationErr.Error())
+	}
+	if err := checkURL(ctx, *webhook.Url); err != nil {
+		return nil, err
+	}
+
+	active := true
+	if webhook.Active == nil {
+		webhook.Active = &active
+	}
+
+	webhook.ID = primitive.NewObjectID()
+	webhook.Webhook_id = webhook.ID.Hex()
+	webhook.Created_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	webhook.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	result, insertErr := dataStore.Webhooks().Create(ctx, webhook)
+	if insertErr != nil {
+		logger.FromContext(ctx).Error("webhook was not created", zap.Error(insertErr))
+		return nil, failed(http.StatusInternalServerError, "webhook was not created", insertErr)
+	}
+	return result, nil
+}
+
+// UpdateWebhook sets the fields of webhook that aren't empty.
+func UpdateWebhook(ctx context.Context, webhookId string, webhook models.Webhook) (*mongo.UpdateResult, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+	var updateObj primitive.D
+
+	if webhook.Url != nil {
+		if err := validate.Var(*webhook.Url, "url,startswith=http"); err != nil {
+			return nil, invalid(http.StatusBadRequest, err.Error())
+		}
+		if err := checkURL(ctx, *webhook.Url); err != nil {
+			return nil, err
+		}
+		updateObj = append(updateObj, bson.E{"url", webhook.Url})
+	}
+
+	if webhook.Events != nil {
+		if err := validate.StructPartial(webhook, "Events"); err != nil {
+			return nil, invalid(http.StatusBadRequest, err.Error())
+		}
+		updateObj = append(updateObj, bson.E{"events", webhook.Events})
+	}
+
+	if webhook.Secret != nil {
+		if err := validate.StructPartial(webhook, "Secret"); err != nil {
+			return nil, invalid(http.StatusBadRequest, err.Error())
+		}
+		updateObj = append(updateObj, bson.E{"secret", webhook.Secret})
+	}
+
+	if webhook.Active != nil {
+		updateObj = append(updateObj, bson.E{"active", webhook.Active})
+	}
+
+	webhook.Updated_at, _ = time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	updateObj = append(updateObj, bson.E{"updated_at", webhook.Updated_at})
+
+	if _, err := dataStore.Webhooks().Get(ctx, webhookId); err != nil {
+		return nil, notFound(http.StatusNotFound, "webhook not found", err)
+	}
+
+	result, updateErr := dataStore.Webhooks().Update(ctx, webhookId, updateObj)
+	if updateErr != nil {
+		logger.FromContext(ctx).Error("webhook update failed", zap.Error(updateErr))
+		return nil, failed(http.StatusInternalServerError, "webhook update failed", updateErr)
+	}
+	return result, nil
+}
+
+func DeleteWebhook(ctx context.Context, webhookId string) (*mongo.DeleteResult, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := dataStore.Webhooks().Get(ctx, webhookId); err != nil {
+		return nil, notFound(http.StatusNotFound, "webhook not found", err)
+	}
+
+	result, deleteErr := dataStore.Webhooks().Delete(ctx, webhookId)
+	if deleteErr != nil {
+		return nil, failed(http.StatusInternalServerError, "error occurred while delete webhook", deleteErr)
+	}
+	return result, nil
+}
+
+// WebhookDeliveries returns the last limit deliveries of a webhook, the
+// latest first.
+func WebhookDeliveries(ctx context.Context, webhookId string, limit int) ([]models.WebhookDelivery, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := dataStore.Webhooks().Get(ctx, webhookId); err != nil {
+		return nil, notFound(http.StatusNotFound, "webhook not found", err)
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+	deliveries, err := dataStore.WebhookDeliveries().ByWebhook(ctx, webhookId, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("error occurred while listing webhook deliveries", zap.Error(err))
+		return nil, failed(http.StatusInternalServerError, "error occurred while listing webhook deliveries", err)
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhook sends the event of a delivery of the webhook again.
+func RedeliverWebhook(ctx context.Context, webhookId, deliveryId string) (models.WebhookDelivery, error) {
+	if err := checkManageWebhooks(ctx); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	if webhooks == nil {
+		return models.WebhookDelivery{}, invalid(http.StatusServiceUnavailable, "webhooks are disabled")
+	}
+
+	delivery, err := dataStore.WebhookDeliveries().Get(ctx, deliveryId)
+	if err == nil && delivery.Webhook_id != webhookId {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		return delivery, notFound(http.StatusNotFound, "webhook delivery not found", err)
+	}
+
+	redelivery, err := webhooks.Redeliver(ctx, deliveryId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return redelivery, notFound(http.StatusNotFound, "webhook delivery not found", err)
+		}
+		logger.FromContext(ctx).Error("webhook redelivery failed", zap.Error(err))
+		return redelivery, failed(http.StatusInternalServerError, "webhook redelivery failed", err)
+	}
+	return redelivery, nil
+}
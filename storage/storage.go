@@ -0,0 +1,24 @@
+// Package storage keeps the files uploaded to the API: the images of the
+// foods and the avatars of the users.
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Get when nothing is stored under the key.
+var ErrNotFound = errors.New("file not found")
+
+// Storage is implemented on the local filesystem by Local. A shared backend,
+// e.g. an S3-compatible bucket, lets several instances of the API serve the
+// same files.
+type Storage interface {
+	// Put stores data under key, a slash separated path, replacing what it
+	// held.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under key, ErrNotFound when there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes what key holds, deleting a missing key does nothing.
+	Delete(ctx context.Context, key string) error
+}
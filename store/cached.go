@@ -0,0 +1,299 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"net/url"
+	"restaurant_management/cache"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// cachedStore reads menus and foods through a cache. Every write to one of
+// them invalidates the whole namespace: entries are keyed by a version of the
+// namespace and a write replaces the version, so that lists and pages never
+// outlive the documents they hold. Entries left behind expire with their ttl.
+type cachedStore struct {
+	Store
+	menus namespace
+	foods namespace
+}
+
+// NewCachedStore wraps s so that menu and food reads go through c.
+func NewCachedStore(s Store, c cache.Cache, ttl time.Duration) Store {
+	return &cachedStore{
+		Store: s,
+		menus: namespace{cache: c, name: "menus", ttl: ttl},
+		foods: namespace{cache: c, name: "foods", ttl: ttl},
+	}
+}
+
+func (s *cachedStore) Menus() MenuStore {
+	return cachedMenus{s.Store.Menus(), s.menus}
+}
+
+func (s *cachedStore) Foods() FoodStore {
+	return cachedFoods{s.Store.Foods(), s.foods}
+}
+
+// WithTransaction invalidates again the namespaces written by fn once it is
+// over, reads made by other requests while it ran may have cached documents
+// it was changing.
+func (s *cachedStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return s.Store.WithTransaction(ctx, fn)
+	}
+
+	written := &writtenNamespaces{}
+	err := s.Store.WithTransaction(context.WithValue(ctx, writtenKey{}, written), fn)
+	for _, n := range written.list() {
+		n.invalidate(ctx)
+	}
+	return err
+}
+
+type writtenKey struct{}
+
+type writtenNamespaces struct {
+	mu         sync.Mutex
+	namespaces []namespace
+}
+
+func (w *writtenNamespaces) add(n namespace) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, written := range w.namespaces {
+		if written.name == n.name {
+			return
+		}
+	}
+	w.namespaces = append(w.namespaces, n)
+}
+
+func (w *writtenNamespaces) list() []namespace {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.namespaces
+}
+
+type namespace struct {
+	cache cache.Cache
+	name  string
+	ttl   time.Duration
+}
+
+func (n namespace) versionKey() string {
+	return n.name + ":version"
+}
+
+// key returns the cache key of parts in the current version of the namespace.
+func (n namespace) key(ctx context.Context, parts ...string) (string, error) {
+	version, err := n.cache.Get(ctx, n.versionKey())
+	if errors.Is(err, cache.ErrMiss) {
+		version, err = n.newVersion(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	key := n.name + ":" + string(version)
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key, nil
+}
+
+func (n namespace) newVersion(ctx context.Context) ([]byte, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	version := []byte(hex.EncodeToString(b))
+	return version, n.cache.Set(ctx, n.versionKey(), version, 0)
+}
+
+func (n namespace) invalidate(ctx context.Context) {
+	if written, ok := ctx.Value(writtenKey{}).(*writtenNamespaces); ok {
+		written.add(n)
+	}
+	if _, err := n.newVersion(ctx); err != nil {
+		logger.FromContext(ctx).Warn("cache invalidation failed", zap.String("namespace", n.name), zap.Error(err))
+	}
+}
+
+// read returns the cached value of parts, or calls load and caches what it
+// returns. Reads inside a unit of work bypass the cache, they must see its
+// writes. The cache failing only costs a trip to the database.
+func read[T any](ctx context.Context, n namespace, load func() (T, error), parts ...string) (T, error) {
+	if inTransaction(ctx) {
+		return load()
+	}
+
+	key, err := n.key(ctx, parts...)
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache unavailable", zap.String("namespace", n.name), zap.Error(err))
+		return load()
+	}
+
+	var value T
+	data, err := n.cache.Get(ctx, key)
+	if err == nil {
+		if err = bson.Unmarshal(data, &value); err == nil {
+			metrics.CacheLookup(n.name, true)
+			return value, nil
+		}
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		logger.FromContext(ctx).Warn("cache read failed", zap.String("key", key), zap.Error(err))
+	}
+	metrics.CacheLookup(n.name, false)
+
+	value, err = load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err = bson.Marshal(value)
+	if err == nil {
+		err = n.cache.Set(ctx, key, data, n.ttl)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache write failed", zap.String("key", key), zap.Error(err))
+	}
+	return value, nil
+}
+
+// cachedList is how lists are cached, bson documents can't be arrays.
+type cachedList struct {
+	Docs  []bson.M `bson:"docs"`
+	Total int64    `bson:"total"`
+}
+
+// cachedHits are the hits of a search, the searches of autocompletes
+// repeating as the words are typed.
+type cachedHits struct {
+	Hits []SearchHit `bson:"hits"`
+}
+
+type cachedMenus struct {
+	MenuStore
+	namespace
+}
+
+func (m cachedMenus) All(ctx context.Context) ([]bson.M, error) {
+	list, err := read(ctx, m.namespace, func() (cachedList, error) {
+		docs, err := m.MenuStore.All(ctx)
+		return cachedList{Docs: docs}, err
+	}, "all")
+	return list.Docs, err
+}
+
+func (m cachedMenus) Get(ctx context.Context, menuId string) (models.Menu, error) {
+	return read(ctx, m.namespace, func() (models.Menu, error) {
+		return m.MenuStore.Get(ctx, menuId)
+	}, "id", menuId)
+}
+
+func (m cachedMenus) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	hits, err := read(ctx, m.namespace, func() (cachedHits, error) {
+		hits, err := m.MenuStore.Search(ctx, query, limit)
+		return cachedHits{Hits: hits}, err
+	}, "search", strconv.Itoa(limit), query)
+	return hits.Hits, err
+}
+
+func (m cachedMenus) Create(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error) {
+	defer m.invalidate(ctx)
+	return m.MenuStore.Create(ctx, menu)
+}
+
+func (m cachedMenus) Update(ctx context.Context, menuId string, set bson.D) (*mongo.UpdateResult, error) {
+	defer m.invalidate(ctx)
+	return m.MenuStore.Update(ctx, menuId, set)
+}
+
+func (m cachedMenus) Delete(ctx context.Context, menuId string) (*mongo.DeleteResult, error) {
+	defer m.invalidate(ctx)
+	return m.MenuStore.Delete(ctx, menuId)
+}
+
+// key identifies the foods f selects, the same filters having the same key.
+func (f FoodFilter) key() string {
+	query := url.Values{}
+	if f.Category != "" {
+		query.Set("category", f.Category)
+	}
+	query["tag"] = f.Tags
+	query["allergen_free"] = f.AllergenFree
+	if f.MaxSpiceLevel != nil {
+		query.Set("max_spice_level", strconv.Itoa(*f.MaxSpiceLevel))
+	}
+	return query.Encode()
+}
+
+type cachedFoods struct {
+	FoodStore
+	namespace
+}
+
+func (f cachedFoods) Page(ctx context.Context, filter FoodFilter, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	list, err := read(ctx, f.namespace, func() (cachedList, error) {
+		docs, total, err := f.FoodStore.Page(ctx, filter, startIndex, recordPerPage)
+		return cachedList{Docs: docs, Total: total}, err
+	}, "page", strconv.Itoa(startIndex), strconv.Itoa(recordPerPage), filter.key())
+	return list.Docs, list.Total, err
+}
+
+func (f cachedFoods) Get(ctx context.Context, foodId string) (models.Food, error) {
+	return read(ctx, f.namespace, func() (models.Food, error) {
+		return f.FoodStore.Get(ctx, foodId)
+	}, "id", foodId)
+}
+
+func (f cachedFoods) Create(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error) {
+	defer f.invalidate(ctx)
+	return f.FoodStore.Create(ctx, food)
+}
+
+func (f cachedFoods) Update(ctx context.Context, foodId string, set bson.D) (*mongo.UpdateResult, error) {
+	defer f.invalidate(ctx)
+	return f.FoodStore.Update(ctx, foodId, set)
+}
+
+func (f cachedFoods) Delete(ctx context.Context, foodId string) (*mongo.DeleteResult, error) {
+	defer f.invalidate(ctx)
+	return f.FoodStore.Delete(ctx, foodId)
+}
+
+func (f cachedFoods) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	hits, err := read(ctx, f.namespace, func() (cachedHits, error) {
+		hits, err := f.FoodStore.Search(ctx, query, limit)
+		return cachedHits{Hits: hits}, err
+	}, "search", strconv.Itoa(limit), query)
+	return hits.Hits, err
+}
+
+// TakePortions only invalidates the foods when portions were counted: foods
+// without a daily limit count none, and taking none only changes the count of
+// another day. The pages hold the portions sold, the whole namespace goes.
+func (f cachedFoods) TakePortions(ctx context.Context, foodId, day string, n int) (int, bool, error) {
+	food, readErr := f.Get(ctx, foodId)
+	left, ok, err := f.FoodStore.TakePortions(ctx, foodId, day, n)
+	if err != nil || !ok {
+		return left, ok, err
+	}
+	if readErr == nil && (food.Daily_limit == nil || n == 0 && food.Portions_date == day) {
+		return left, ok, nil
+	}
+	f.invalidate(ctx)
+	return left, ok, nil
+}
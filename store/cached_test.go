@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"restaurant_management/cache"
+	"restaurant_management/models"
+	"testing"
+	"time"
+)
+
+func TestCachedTakePortions(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(100)
+	foods := NewCachedStore(NewMemoryStore(), c, time.Minute).Foods()
+
+	create := func(name string, dailyLimit *int) string {
+		t.Helper()
+		food := models.Food{ID: primitive.NewObjectID(), Name: &name, Daily_limit: dailyLimit}
+		food.Food_id = food.ID.Hex()
+		if _, err := foods.Create(ctx, food); err != nil {
+			t.Fatal(err)
+		}
+		return food.Food_id
+	}
+	two := 2
+	unlimited, limited := create("Bread", nil), create("Special", &two)
+
+	version := func() string {
+		v, _ := c.Get(ctx, "foods:version")
+		return string(v)
+	}
+
+	for _, test := range []struct {
+		name        string
+		foodId, day string
+		n           int
+		ok          bool
+		invalidated bool
+	}{
+		{"no daily limit", unlimited, "2026-10-19", 1, true, false},
+		{"portion taken", limited, "2026-10-19", 1, true, true},
+		{"none taken", limited, "2026-10-19", 0, true, false},
+		{"none taken on a new day", limited, "2026-10-20", 0, true, true},
+		{"too many", limited, "2026-10-20", 3, false, false},
+		{"missing food", "missing", "2026-10-20", 1, false, false},
+		{"last portion", limited, "2026-10-20", 2, true, true},
+	} {
+		// cache the food like a read would
+		foods.Get(ctx, test.foodId)
+		before := version()
+
+		_, ok, err := foods.TakePortions(ctx, test.foodId, test.day, test.n)
+		if err != nil || ok != test.ok {
+			t.Fatalf("%s: expected ok to be %v, got %v, %v", test.name, test.ok, ok, err)
+		}
+		if invalidated := version() != before; invalidated != test.invalidated {
+			t.Errorf("%s: expected the invalidation to be %v", test.name, test.invalidated)
+		}
+	}
+
+	food, err := foods.Get(ctx, limited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if food.Portions_sold != 2 || food.Portions_date != "2026-10-20" {
+		t.Fatalf("expected the portions sold to be read again, got %d on %q", food.Portions_sold, food.Portions_date)
+	}
+}
@@ -0,0 +1,825 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"restaurant_management/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryStore keeps every collection in process. Documents are stored in
+// their BSON form so that reads return exactly what Mongo would return.
+// Stored documents are never modified in place, updates replace them.
+type memoryStore struct {
+	mu          sync.RWMutex
+	txMu        sync.Mutex
+	collections map[string][]bson.M
+}
+
+// NewMemoryStore returns an empty Store that lives in memory, for tests and
+// local development without a database.
+func NewMemoryStore() Store {
+	return &memoryStore{collections: map[string][]bson.M{}}
+}
+
+func (s *memoryStore) Users() UserStore {
+	return memoryUsers{memoryCollection{s, "user", "user_id"}}
+}
+
+func (s *memoryStore) Foods() FoodStore {
+	return memoryFoods{memoryCollection{s, "food", "food_id"}}
+}
+
+func (s *memoryStore) Menus() MenuStore {
+	return memoryMenus{memoryCollection{s, "menu", "menu_id"}}
+}
+
+func (s *memoryStore) Tables() TableStore {
+	return memoryTables{memoryCollection{s, "table", "table_id"}}
+}
+
+func (s *memoryStore) Orders() OrderStore {
+	return memoryOrders{memoryCollection{s, "order", "order_id"}}
+}
+
+func (s *memoryStore) OrderItems() OrderItemStore {
+	return memoryOrderItems{memoryCollection{s, "orderItem", "order_item_id"}}
+}
+
+func (s *memoryStore) Invoices() InvoiceStore {
+	return memoryInvoices{memoryCollection{s, "invoice", "invoice_id"}}
+}
+
+func (s *memoryStore) Webhooks() WebhookStore {
+	return memoryWebhooks{memoryCollection{s, "webhook", "webhook_id"}}
+}
+
+func (s *memoryStore) WebhookDeliveries() WebhookDeliveryStore {
+	return memoryWebhookDeliveries{memoryCollection{s, "webhookDelivery", "delivery_id"}}
+}
+
+func (s *memoryStore) Outbox() OutboxStore {
+	return memoryOutbox{memoryCollection{s, "outbox", "event_id"}}
+}
+
+// WithTransaction serializes units of work and restores the collections as
+// they were before fn when fn fails. Writes made outside of a unit of work
+// while one is running are lost if it rolls back.
+func (s *memoryStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	snapshot := make(map[string][]bson.M, len(s.collections))
+	for name, docs := range s.collections {
+		snapshot[name] = append([]bson.M(nil), docs...)
+	}
+	s.mu.RUnlock()
+
+	if err := fn(withinTransaction(ctx)); err != nil {
+		s.mu.Lock()
+		s.collections = snapshot
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// toDocument converts v into the document Mongo would store for it.
+func toDocument(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// clone deep copies a stored document so callers can't alter the store.
+func clone(doc bson.M) bson.M {
+	copied, err := toDocument(doc)
+	if err != nil {
+		panic(err)
+	}
+	return copied
+}
+
+type memoryCollection struct {
+	store   *memoryStore
+	name    string
+	idField string
+}
+
+func (m memoryCollection) page(startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.pageMatching(func(bson.M) bool { return true }, startIndex, recordPerPage)
+}
+
+// pageMatching returns a page of the documents matching match and their
+// number.
+func (m memoryCollection) pageMatching(match func(doc bson.M) bool, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	docs := m.find(match)
+	total := int64(len(docs))
+
+	end := startIndex + recordPerPage
+	if startIndex > len(docs) {
+		startIndex = len(docs)
+	}
+	if end > len(docs) {
+		end = len(docs)
+	}
+
+	page := append([]bson.M{}, docs[startIndex:end]...)
+	return page, total, nil
+}
+
+func (m memoryCollection) all() ([]bson.M, error) {
+	return m.find(func(bson.M) bool { return true }), nil
+}
+
+// find returns copies of the documents matching match, in insertion order.
+func (m memoryCollection) find(match func(doc bson.M) bool) []bson.M {
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	var docs []bson.M
+	for _, doc := range m.store.collections[m.name] {
+		if match(doc) {
+			docs = append(docs, clone(doc))
+		}
+	}
+	return docs
+}
+
+func (m memoryCollection) findOne(match func(doc bson.M) bool, v interface{}) error {
+	docs := m.find(match)
+	if len(docs) == 0 {
+		return ErrNotFound
+	}
+
+	data, err := bson.Marshal(docs[0])
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, v)
+}
+
+func (m memoryCollection) get(id string, v interface{}) error {
+	return m.findOne(func(doc bson.M) bool { return doc[m.idField] == id }, v)
+}
+
+// in returns the documents whose field is one of values, decoded into T.
+func in[T any](m memoryCollection, field string, values []string) ([]T, error) {
+	wanted := make(map[interface{}]bool, len(values))
+	for _, value := range values {
+		wanted[value] = true
+	}
+	return Decode[T](m.find(func(doc bson.M) bool { return wanted[doc[field]] }))
+}
+
+func (m memoryCollection) count(field string, value interface{}) int64 {
+	return int64(len(m.find(func(doc bson.M) bool { return doc[field] == value })))
+}
+
+func (m memoryCollection) insert(values ...interface{}) ([]interface{}, error) {
+	docs := make([]bson.M, 0, len(values))
+	ids := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		doc, err := toDocument(v)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+		ids = append(ids, doc["_id"])
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	m.store.collections[m.name] = append(m.store.collections[m.name], docs...)
+	return ids, nil
+}
+
+func (m memoryCollection) insertOne(v interface{}) (*mongo.InsertOneResult, error) {
+	ids, err := m.insert(v)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertOneResult{InsertedID: ids[0]}, nil
+}
+
+func (m memoryCollection) update(id string, set bson.D) (*mongo.UpdateResult, error) {
+	values, err := toDocument(set)
+	if err != nil {
+		return nil, err
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	docs := m.store.collections[m.name]
+	for i, doc := range docs {
+		if doc[m.idField] != id {
+			continue
+		}
+
+		updated := make(bson.M, len(doc)+len(values))
+		for key, value := range doc {
+			updated[key] = value
+		}
+		for key, value := range values {
+			updated[key] = value
+		}
+		docs[i] = updated
+		return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+	}
+	return &mongo.UpdateResult{}, nil
+}
+
+func (m memoryCollection) delete(id string) (*mongo.DeleteResult, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	docs := m.store.collections[m.name]
+	for i, doc := range docs {
+		if doc[m.idField] == id {
+			m.store.collections[m.name] = append(docs[:i:i], docs[i+1:]...)
+			return &mongo.DeleteResult{DeletedCount: 1}, nil
+		}
+	}
+	return &mongo.DeleteResult{}, nil
+}
+
+type memoryUsers struct{ memoryCollection }
+
+func (m memoryUsers) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.page(startIndex, recordPerPage)
+}
+
+func (m memoryUsers) Get(ctx context.Context, userId string) (user models.User, err error) {
+	err = m.get(userId, &user)
+	return user, err
+}
+
+func (m memoryUsers) GetByEmail(ctx context.Context, email string) (user models.User, err error) {
+	err = m.findOne(func(doc bson.M) bool { return doc["email"] == email }, &user)
+	return user, err
+}
+
+func (m memoryUsers) CountByEmail(ctx context.Context, email string) (int64, error) {
+	return m.count("email", email), nil
+}
+
+func (m memoryUsers) CountByPhone(ctx context.Context, phone string) (int64, error) {
+	return m.count("phone", phone), nil
+}
+
+func (m memoryUsers) Create(ctx context.Context, user models.User) (*mongo.InsertOneResult, error) {
+	return m.insertOne(user)
+}
+
+func (m memoryUsers) UpdateTokens(ctx context.Context, userId, token, refreshToken string) error {
+	_, err := m.update(userId, bson.D{
+		{"token", token},
+		{"refresh_token", refreshToken},
+		{"updated_at", now()},
+	})
+	return err
+}
+
+func (m memoryUsers) UpdateAvatar(ctx context.Context, userId, avatar, thumbnail string) error {
+	_, err := m.update(userId, bson.D{
+		{"avatar", avatar},
+		{"avatar_thumbnail", thumbnail},
+		{"updated_at", now()},
+	})
+	return err
+}
+
+type memoryFoods struct{ memoryCollection }
+
+func (m memoryFoods) Page(ctx context.Context, filter FoodFilter, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.pageMatching(func(doc bson.M) bool {
+		foods, err := Decode[models.Food]([]bson.M{doc})
+		return err == nil && filter.matches(foods[0])
+	}, startIndex, recordPerPage)
+}
+
+// matches tells whether filter selects food.
+func (f FoodFilter) matches(food models.Food) bool {
+	if f.Category != "" && (food.Category == nil || *food.Category != f.Category) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !contains(food.Tags, tag) {
+			return false
+		}
+	}
+	if len(f.AllergenFree) > 0 {
+		if food.Allergens == nil {
+			return false
+		}
+		for _, allergen := range f.AllergenFree {
+			if contains(food.Allergens, allergen) {
+				return false
+			}
+		}
+	}
+	if f.MaxSpiceLevel != nil && (food.Spice_level == nil || *food.Spice_level > *f.MaxSpiceLevel) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m memoryFoods) Get(ctx context.Context, foodId string) (food models.Food, err error) {
+	err = m.get(foodId, &food)
+	return food, err
+}
+
+func (m memoryFoods) GetMany(ctx context.Context, foodIds []string) ([]models.Food, error) {
+	return in[models.Food](m.memoryCollection, m.idField, foodIds)
+}
+
+func (m memoryFoods) ByMenus(ctx context.Context, menuIds []string) ([]models.Food, error) {
+	return in[models.Food](m.memoryCollection, "menu_id", menuIds)
+}
+
+func (m memoryFoods) Create(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error) {
+	return m.insertOne(food)
+}
+
+func (m memoryFoods) Update(ctx context.Context, foodId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(foodId, set)
+}
+
+func (m memoryFoods) Delete(ctx context.Context, foodId string) (*mongo.DeleteResult, error) {
+	return m.delete(foodId)
+}
+
+func (m memoryFoods) Limited(ctx context.Context) ([]models.Food, error) {
+	// the updates may hold pointers, the foods are filtered once decoded
+	docs, _ := m.all()
+	foods, err := Decode[models.Food](docs)
+	if err != nil {
+		return nil, err
+	}
+	limited := []models.Food{}
+	for _, food := range foods {
+		if (food.Sold_out != nil && *food.Sold_out) || food.Daily_limit != nil {
+			limited = append(limited, food)
+		}
+	}
+	return limited, nil
+}
+
+func (m memoryFoods) TakePortions(ctx context.Context, foodId, day string, n int) (int, bool, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	for i, doc := range m.store.collections[m.name] {
+		if doc[m.idField] != foodId {
+			continue
+		}
+		foods, err := Decode[models.Food]([]bson.M{doc})
+		if err != nil {
+			return 0, false, err
+		}
+		food := foods[0]
+		if food.Daily_limit == nil {
+			return 0, true, nil
+		}
+
+		sold := n
+		if food.Portions_date == day {
+			sold += food.Portions_sold
+		}
+		if sold > *food.Daily_limit {
+			return *food.Daily_limit - sold + n, false, nil
+		}
+
+		updated := clone(doc)
+		updated["portions_sold"] = int64(sold)
+		updated["portions_date"] = day
+		m.store.collections[m.name][i] = updated
+		return *food.Daily_limit - sold, true, nil
+	}
+	return 0, false, nil
+}
+
+func (m memoryFoods) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	docs, _ := m.all()
+	foods, err := Decode[models.Food](docs)
+	if err != nil {
+		return nil, err
+	}
+	return rankFoods(foods, query, nil, limit), nil
+}
+
+type memoryMenus struct{ memoryCollection }
+
+func (m memoryMenus) All(ctx context.Context) ([]bson.M, error) {
+	return m.all()
+}
+
+func (m memoryMenus) Get(ctx context.Context, menuId string) (menu models.Menu, err error) {
+	err = m.get(menuId, &menu)
+	return menu, err
+}
+
+func (m memoryMenus) GetMany(ctx context.Context, menuIds []string) ([]models.Menu, error) {
+	return in[models.Menu](m.memoryCollection, m.idField, menuIds)
+}
+
+func (m memoryMenus) Create(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error) {
+	return m.insertOne(menu)
+}
+
+func (m memoryMenus) Update(ctx context.Context, menuId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(menuId, set)
+}
+
+func (m memoryMenus) Delete(ctx context.Context, menuId string) (*mongo.DeleteResult, error) {
+	return m.delete(menuId)
+}
+
+func (m memoryMenus) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	docs, _ := m.all()
+	menus, err := Decode[models.Menu](docs)
+	if err != nil {
+		return nil, err
+	}
+	return rankMenus(menus, query, nil, limit), nil
+}
+
+type memoryTables struct{ memoryCollection }
+
+func (m memoryTables) All(ctx context.Context) ([]bson.M, error) {
+	return m.all()
+}
+
+func (m memoryTables) Get(ctx context.Context, tableId string) (table models.Table, err error) {
+	err = m.get(tableId, &table)
+	return table, err
+}
+
+func (m memoryTables) GetMany(ctx context.Context, tableIds []string) ([]models.Table, error) {
+	return in[models.Table](m.memoryCollection, m.idField, tableIds)
+}
+
+func (m memoryTables) Create(ctx context.Context, table models.Table) (*mongo.InsertOneResult, error) {
+	return m.insertOne(table)
+}
+
+func (m memoryTables) Update(ctx context.Context, tableId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(tableId, set)
+}
+
+func (m memoryTables) Delete(ctx context.Context, tableId string) (*mongo.DeleteResult, error) {
+	return m.delete(tableId)
+}
+
+type memoryOrders struct{ memoryCollection }
+
+func (m memoryOrders) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.page(startIndex, recordPerPage)
+}
+
+func (m memoryOrders) Get(ctx context.Context, orderId string) (order models.Order, err error) {
+	err = m.get(orderId, &order)
+	return order, err
+}
+
+func (m memoryOrders) GetMany(ctx context.Context, orderIds []string) ([]models.Order, error) {
+	return in[models.Order](m.memoryCollection, m.idField, orderIds)
+}
+
+func (m memoryOrders) Create(ctx context.Context, order models.Order) (*mongo.InsertOneResult, error) {
+	return m.insertOne(order)
+}
+
+func (m memoryOrders) Update(ctx context.Context, orderId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(orderId, set)
+}
+
+func (m memoryOrders) Delete(ctx context.Context, orderId string) (*mongo.DeleteResult, error) {
+	return m.delete(orderId)
+}
+
+type memoryOrderItems struct{ memoryCollection }
+
+func (m memoryOrderItems) All(ctx context.Context) ([]bson.M, error) {
+	return m.all()
+}
+
+func (m memoryOrderItems) Get(ctx context.Context, orderItemId string) (orderItem models.OrderItem, err error) {
+	err = m.get(orderItemId, &orderItem)
+	return orderItem, err
+}
+
+func (m memoryOrderItems) ByOrders(ctx context.Context, orderIds []string) ([]models.OrderItem, error) {
+	return in[models.OrderItem](m.memoryCollection, "order_id", orderIds)
+}
+
+func (m memoryOrderItems) CreateMany(ctx context.Context, orderItems []models.OrderItem) (*mongo.InsertManyResult, error) {
+	values := make([]interface{}, 0, len(orderItems))
+	for _, orderItem := range orderItems {
+		values = append(values, orderItem)
+	}
+
+	ids, err := m.insert(values...)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertManyResult{InsertedIDs: ids}, nil
+}
+
+func (m memoryOrderItems) Update(ctx context.Context, orderItemId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(orderItemId, set)
+}
+
+func (m memoryOrderItems) Delete(ctx context.Context, orderItemId string) (*mongo.DeleteResult, error) {
+	return m.delete(orderItemId)
+}
+
+// ItemsByOrder mirrors the aggregation pipeline of the Mongo store: every
+// item is joined with its food, order and table, decorated with the same
+// fields and grouped by order and table.
+func (m memoryOrderItems) ItemsByOrder(ctx context.Context, orderId string) ([]bson.M, error) {
+	foods := memoryCollection{m.store, "food", "food_id"}
+	orders := memoryCollection{m.store, "order", "order_id"}
+	tables := memoryCollection{m.store, "table", "table_id"}
+
+	lookup := func(from memoryCollection, field string, value interface{}) bson.M {
+		if value == nil {
+			return nil
+		}
+		docs := from.find(func(doc bson.M) bool { return doc[field] == value })
+		if len(docs) == 0 {
+			return nil
+		}
+		return docs[0]
+	}
+
+	var items []joinedItem
+	for _, item := range m.find(func(doc bson.M) bool { return doc["order_id"] == orderId }) {
+		order := lookup(orders, "order_id", item["order_id"])
+		items = append(items, joinedItem{
+			item:  item,
+			food:  lookup(foods, "food_id", item["food_id"]),
+			order: order,
+			table: lookup(tables, "table_id", order["table_id"]),
+		})
+	}
+	return summarizeOrder(items), nil
+}
+
+// joinedItem is an order item with the food, order and table it refers to,
+// nil when missing.
+type joinedItem struct {
+	item, food, order, table bson.M
+}
+
+// summarizeOrder decorates the joined items and groups them by order and
+// table, like the stages following the lookups of the aggregation pipeline of
+// the Mongo store.
+func summarizeOrder(items []joinedItem) []bson.M {
+	type group struct {
+		tableNumber interface{}
+		paymentDue  float64
+		totalCount  int32
+		orderItems  primitive.A
+	}
+	var groups []*group
+	byKey := map[string]*group{}
+
+	for _, joined := range items {
+		item, food, order, table := joined.item, joined.food, joined.order, joined.table
+
+		embed(item, "food", food)
+		embed(item, "order", order)
+		embed(item, "table", table)
+
+		addField(item, "amount", item, "unit_price")
+		addField(item, "food_name", food, "name")
+		addField(item, "food_image", food, "food_image")
+		addField(item, "table_number", table, "table_number")
+		addField(item, "table_id", table, "table_id")
+		addField(item, "order_id", order, "order_id")
+		addField(item, "price", item, "unit_price")
+		item["quantity"] = int32(1)
+
+		key := fmt.Sprintf("%v|%v|%v", item["order_id"], item["table_id"], item["table_number"])
+		g, ok := byKey[key]
+		if !ok {
+			g = &group{tableNumber: item["table_number"], orderItems: primitive.A{}}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+
+		if amount, ok := item["amount"].(float64); ok {
+			g.paymentDue += amount
+		}
+		g.totalCount++
+		g.orderItems = append(g.orderItems, item)
+	}
+
+	var orderItems []bson.M
+	for _, g := range groups {
+		summary := bson.M{
+			"payment_due": g.paymentDue,
+			"total_count": g.totalCount,
+			"order_items": g.orderItems,
+		}
+		if g.tableNumber != nil {
+			summary["table_number"] = g.tableNumber
+		}
+		orderItems = append(orderItems, summary)
+	}
+	return orderItems
+}
+
+// embed sets doc[field] to the joined document, like $unwind with
+// preserveNullAndEmptyArrays leaves the field out when nothing matched.
+func embed(doc bson.M, field string, joined bson.M) {
+	if joined != nil {
+		doc[field] = joined
+	}
+}
+
+// addField copies from[key] into doc[field], like $addFields drops the field
+// when the referenced path is missing.
+func addField(doc bson.M, field string, from bson.M, key string) {
+	value, ok := from[key]
+	if !ok {
+		delete(doc, field)
+		return
+	}
+	doc[field] = value
+}
+
+type memoryInvoices struct{ memoryCollection }
+
+func (m memoryInvoices) All(ctx context.Context) ([]bson.M, error) {
+	return m.all()
+}
+
+func (m memoryInvoices) Get(ctx context.Context, invoiceId string) (invoice models.Invoice, err error) {
+	err = m.get(invoiceId, &invoice)
+	return invoice, err
+}
+
+func (m memoryInvoices) Create(ctx context.Context, invoice models.Invoice) (*mongo.InsertOneResult, error) {
+	return m.insertOne(invoice)
+}
+
+func (m memoryInvoices) Update(ctx context.Context, invoiceId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(invoiceId, set)
+}
+
+func (m memoryInvoices) Delete(ctx context.Context, invoiceId string) (*mongo.DeleteResult, error) {
+	return m.delete(invoiceId)
+}
+
+type memoryWebhooks struct{ memoryCollection }
+
+func (m memoryWebhooks) All(ctx context.Context) ([]bson.M, error) {
+	return m.all()
+}
+
+func (m memoryWebhooks) Get(ctx context.Context, webhookId string) (webhook models.Webhook, err error) {
+	err = m.get(webhookId, &webhook)
+	return webhook, err
+}
+
+func (m memoryWebhooks) Subscribed(ctx context.Context, event string) ([]models.Webhook, error) {
+	return Decode[models.Webhook](m.find(func(doc bson.M) bool {
+		if doc["active"] == false {
+			return false
+		}
+		events, _ := doc["events"].(primitive.A)
+		for _, e := range events {
+			if e == event {
+				return true
+			}
+		}
+		return false
+	}))
+}
+
+func (m memoryWebhooks) Create(ctx context.Context, webhook models.Webhook) (*mongo.InsertOneResult, error) {
+	return m.insertOne(webhook)
+}
+
+func (m memoryWebhooks) Update(ctx context.Context, webhookId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(webhookId, set)
+}
+
+func (m memoryWebhooks) Delete(ctx context.Context, webhookId string) (*mongo.DeleteResult, error) {
+	return m.delete(webhookId)
+}
+
+type memoryWebhookDeliveries struct{ memoryCollection }
+
+func (m memoryWebhookDeliveries) ByWebhook(ctx context.Context, webhookId string, limit int) ([]models.WebhookDelivery, error) {
+	deliveries, err := Decode[models.WebhookDelivery](m.find(func(doc bson.M) bool { return doc["webhook_id"] == webhookId }))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].Created_at.Equal(deliveries[j].Created_at) {
+			return deliveries[i].Created_at.After(deliveries[j].Created_at)
+		}
+		return deliveries[i].Delivery_id > deliveries[j].Delivery_id
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (m memoryWebhookDeliveries) Get(ctx context.Context, deliveryId string) (delivery models.WebhookDelivery, err error) {
+	err = m.get(deliveryId, &delivery)
+	return delivery, err
+}
+
+func (m memoryWebhookDeliveries) Create(ctx context.Context, delivery models.WebhookDelivery) (*mongo.InsertOneResult, error) {
+	return m.insertOne(delivery)
+}
+
+func (m memoryWebhookDeliveries) Update(ctx context.Context, deliveryId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(deliveryId, set)
+}
+
+func (m memoryWebhookDeliveries) Due(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	deliveries, err := Decode[models.WebhookDelivery](m.find(func(doc bson.M) bool { return doc["status"] == "PENDING" }))
+	if err != nil {
+		return nil, err
+	}
+
+	due := deliveries[:0]
+	for _, delivery := range deliveries {
+		if !delivery.Next_attempt_at.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Next_attempt_at.Before(due[j].Next_attempt_at)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+type memoryOutbox struct{ memoryCollection }
+
+func (m memoryOutbox) Create(ctx context.Context, event models.OutboxEvent) (*mongo.InsertOneResult, error) {
+	return m.insertOne(event)
+}
+
+func (m memoryOutbox) Update(ctx context.Context, eventId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(eventId, set)
+}
+
+func (m memoryOutbox) Due(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	events, err := Decode[models.OutboxEvent](m.find(func(doc bson.M) bool { return doc["status"] == "PENDING" }))
+	if err != nil {
+		return nil, err
+	}
+
+	due := events[:0]
+	for _, event := range events {
+		if !event.Next_attempt_at.After(now) {
+			due = append(due, event)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Event_id < due[j].Event_id
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
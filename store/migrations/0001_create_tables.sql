@@ -0,0 +1,133 @@
+-- Every collection of the Mongo store is a table whose columns are named
+-- after the fields of its documents, "_id" being "id", the hex of the
+-- ObjectID. Like in Mongo, references between tables aren't enforced: the
+-- services check them.
+
+CREATE TABLE users (
+    id            char(24)    PRIMARY KEY,
+    first_name    text,
+    last_name     text,
+    password      text,
+    email         text,
+    avatar        text,
+    phone         text,
+    token         text,
+    refresh_token text,
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL,
+    user_id       text        NOT NULL UNIQUE
+);
+CREATE INDEX users_email ON users (email);
+CREATE INDEX users_phone ON users (phone);
+
+CREATE TABLE menus (
+    id         char(24)    PRIMARY KEY,
+    name       text        NOT NULL,
+    category   text        NOT NULL,
+    start_date timestamptz,
+    end_date   timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    menu_id    text        NOT NULL UNIQUE
+);
+
+CREATE TABLE foods (
+    id         char(24)         PRIMARY KEY,
+    name       text,
+    price      double precision,
+    food_image text,
+    created_at timestamptz      NOT NULL,
+    updated_at timestamptz      NOT NULL,
+    food_id    text             NOT NULL UNIQUE,
+    menu_id    text
+);
+CREATE INDEX foods_menu_id ON foods (menu_id);
+
+CREATE TABLE tables (
+    id               char(24)    PRIMARY KEY,
+    number_of_guests integer,
+    table_number     integer,
+    table_status     text,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL,
+    table_id         text        NOT NULL UNIQUE
+);
+
+CREATE TABLE orders (
+    id           char(24)    PRIMARY KEY,
+    order_date   timestamptz NOT NULL,
+    order_status text,
+    created_at   timestamptz NOT NULL,
+    updated_at   timestamptz NOT NULL,
+    order_id     text        NOT NULL UNIQUE,
+    table_id     text
+);
+CREATE INDEX orders_table_id ON orders (table_id);
+
+CREATE TABLE order_items (
+    id            char(24)         PRIMARY KEY,
+    quantity      text,
+    unit_price    double precision,
+    created_at    timestamptz      NOT NULL,
+    updated_at    timestamptz      NOT NULL,
+    food_id       text,
+    order_item_id text             NOT NULL UNIQUE,
+    order_id      text             NOT NULL
+);
+CREATE INDEX order_items_order_id ON order_items (order_id);
+
+CREATE TABLE invoices (
+    id               char(24)    PRIMARY KEY,
+    invoice_id       text        NOT NULL UNIQUE,
+    order_id         text        NOT NULL,
+    payment_method   text,
+    payment_status   text,
+    payment_due_date timestamptz NOT NULL,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL
+);
+CREATE INDEX invoices_order_id ON invoices (order_id);
+
+CREATE TABLE webhooks (
+    id         char(24)    PRIMARY KEY,
+    url        text,
+    events     text[],
+    secret     text,
+    active     boolean,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    webhook_id text        NOT NULL UNIQUE
+);
+
+CREATE TABLE webhook_deliveries (
+    id              char(24)    PRIMARY KEY,
+    delivery_id     text        NOT NULL UNIQUE,
+    webhook_id      text        NOT NULL,
+    event_id        text        NOT NULL,
+    event           text        NOT NULL,
+    payload         text        NOT NULL,
+    status          text        NOT NULL,
+    attempts        integer     NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    response_status integer     NOT NULL,
+    last_error      text        NOT NULL,
+    redelivery_of   text        NOT NULL,
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL
+);
+CREATE INDEX webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, created_at DESC, id DESC);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+
+CREATE TABLE outbox (
+    id              char(24)    PRIMARY KEY,
+    event_id        text        NOT NULL UNIQUE,
+    type            text        NOT NULL,
+    payload         text        NOT NULL,
+    status          text        NOT NULL,
+    attempts        integer     NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      text        NOT NULL,
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL
+);
+CREATE INDEX outbox_due ON outbox (id) WHERE status = 'PENDING';
@@ -0,0 +1,4 @@
+-- The recurring schedule of a menu is an embedded document in Mongo, a JSON
+-- document here.
+
+ALTER TABLE menus ADD COLUMN availability jsonb;
@@ -0,0 +1,13 @@
+-- The description, category, dietary tags, allergens and spice level of the
+-- foods. NULL allergens were not declared, unlike an empty array.
+
+ALTER TABLE foods
+    ADD COLUMN description text,
+    ADD COLUMN category    text,
+    ADD COLUMN tags        text[],
+    ADD COLUMN allergens   text[],
+    ADD COLUMN spice_level integer;
+
+CREATE INDEX foods_category ON foods (category);
+CREATE INDEX foods_tags ON foods USING gin (tags);
+CREATE INDEX foods_allergens ON foods USING gin (allergens);
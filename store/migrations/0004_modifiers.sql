@@ -0,0 +1,5 @@
+-- The modifier groups of the foods and the modifiers selected for the order
+-- items, embedded documents in Mongo.
+
+ALTER TABLE foods ADD COLUMN modifier_groups jsonb;
+ALTER TABLE order_items ADD COLUMN modifiers jsonb;
@@ -0,0 +1,5 @@
+-- The prices of the portion sizes of the foods, by size, and the permissions
+-- of the users.
+
+ALTER TABLE foods ADD COLUMN size_prices jsonb;
+ALTER TABLE users ADD COLUMN permissions text[];
@@ -0,0 +1,11 @@
+-- The availability of the foods: sold out, until a time when set, or once
+-- their daily limit of portions is sold.
+
+ALTER TABLE foods
+    ADD COLUMN sold_out       boolean,
+    ADD COLUMN sold_out_until timestamptz,
+    ADD COLUMN daily_limit    integer,
+    ADD COLUMN portions_sold  integer NOT NULL DEFAULT 0,
+    ADD COLUMN portions_date  text    NOT NULL DEFAULT '';
+
+CREATE INDEX foods_limited ON foods (id) WHERE sold_out OR daily_limit IS NOT NULL;
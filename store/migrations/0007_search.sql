@@ -0,0 +1,8 @@
+-- The text searched of the foods and the menus, matching the expressions of
+-- foodSearchVector and menuSearchVector so that the searches use them.
+
+CREATE INDEX foods_search ON foods
+    USING gin (to_tsvector('english', coalesce(name, '') || ' ' || coalesce(description, '')));
+
+CREATE INDEX menus_search ON menus
+    USING gin (to_tsvector('english', name || ' ' || category));
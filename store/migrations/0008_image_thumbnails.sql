@@ -0,0 +1,6 @@
+-- The thumbnails of the images uploaded for the foods and of the avatars of
+-- the users.
+
+ALTER TABLE foods ADD COLUMN food_thumbnail text;
+
+ALTER TABLE users ADD COLUMN avatar_thumbnail text;
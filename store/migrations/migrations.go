@@ -0,0 +1,8 @@
+// Package migrations holds the migrations of the PostgreSQL schema of the
+// store, applied in the order of their names by store.MigratePostgres.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
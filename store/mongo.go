@@ -0,0 +1,726 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+	"restaurant_management/database"
+	"restaurant_management/logger"
+	"restaurant_management/models"
+	"sync"
+	"time"
+)
+
+type mongoStore struct {
+	client *mongo.Client
+
+	users      mongoUsers
+	foods      mongoFoods
+	menus      mongoMenus
+	tables     mongoTables
+	orders     mongoOrders
+	orderItems mongoOrderItems
+	invoices   mongoInvoices
+	webhooks   mongoWebhooks
+	deliveries mongoWebhookDeliveries
+	outbox     mongoOutbox
+
+	txOnce      sync.Once
+	txSupported bool
+}
+
+// NewMongoStore returns a Store backed by the restaurant_management database
+// of client.
+func NewMongoStore(client *mongo.Client) Store {
+	return &mongoStore{
+		client:     client,
+		users:      mongoUsers{newMongoCollection(client, "user", "user_id")},
+		foods:      mongoFoods{newMongoCollection(client, "food", "food_id")},
+		menus:      mongoMenus{newMongoCollection(client, "menu", "menu_id")},
+		tables:     mongoTables{newMongoCollection(client, "table", "table_id")},
+		orders:     mongoOrders{newMongoCollection(client, "order", "order_id")},
+		orderItems: mongoOrderItems{newMongoCollection(client, "orderItem", "order_item_id")},
+		invoices:   mongoInvoices{newMongoCollection(client, "invoice", "invoice_id")},
+		webhooks:   mongoWebhooks{newMongoCollection(client, "webhook", "webhook_id")},
+		deliveries: mongoWebhookDeliveries{newMongoCollection(client, "webhookDelivery", "delivery_id")},
+		outbox:     mongoOutbox{newMongoCollection(client, "outbox", "event_id")},
+	}
+}
+
+func (s *mongoStore) Users() UserStore           { return s.users }
+func (s *mongoStore) Foods() FoodStore           { return s.foods }
+func (s *mongoStore) Menus() MenuStore           { return s.menus }
+func (s *mongoStore) Tables() TableStore         { return s.tables }
+func (s *mongoStore) Orders() OrderStore         { return s.orders }
+func (s *mongoStore) OrderItems() OrderItemStore { return s.orderItems }
+func (s *mongoStore) Invoices() InvoiceStore     { return s.invoices }
+func (s *mongoStore) Webhooks() WebhookStore     { return s.webhooks }
+
+func (s *mongoStore) WebhookDeliveries() WebhookDeliveryStore { return s.deliveries }
+func (s *mongoStore) Outbox() OutboxStore                     { return s.outbox }
+
+// WithTransaction runs fn inside a Mongo session transaction. Transactions
+// need a replica set or a sharded cluster: Open refuses a standalone server
+// unless MONGODB_ALLOW_STANDALONE is set, fn then runs without one and a
+// warning is logged once. The writes made before fn fails are kept, which is
+// why the service validates everything before its first write.
+func (s *mongoStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	if !s.supportsTransactions(ctx) {
+		return fn(withinTransaction(ctx))
+	}
+
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(withinTransaction(sc))
+	})
+	return err
+}
+
+func (s *mongoStore) supportsTransactions(ctx context.Context) bool {
+	s.txOnce.Do(func() {
+		var err error
+		s.txSupported, err = mongoTransactions(ctx, s.client)
+		if err != nil {
+			logger.L().Warn("unable to detect transaction support", zap.Error(err))
+			return
+		}
+		if !s.txSupported {
+			logger.L().Warn("mongo server is standalone, multi-document writes will run without transactions")
+		}
+	})
+	return s.txSupported
+}
+
+// mongoTransactions reports whether the server of client is a member of a
+// replica set or a mongos, the deployments running transactions.
+func mongoTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{"hello", 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	_, replicaSet := hello["setName"]
+	return replicaSet || hello["msg"] == "isdbgrid", nil
+}
+
+type mongoCollection struct {
+	collection *mongo.Collection
+	idField    string
+}
+
+func newMongoCollection(client *mongo.Client, name, idField string) mongoCollection {
+	return mongoCollection{
+		collection: database.OpenCollection(client, name),
+		idField:    idField,
+	}
+}
+
+// textMatches returns the IDs of the documents the text index of the
+// collection matches with a word of query.
+func (m mongoCollection) textMatches(ctx context.Context, query string) (map[string]bool, error) {
+	cursor, err := m.collection.Find(ctx, bson.D{{"$text", bson.D{{"$search", query}}}}, options.Find().SetProjection(bson.D{{m.idField, 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	matches := map[string]bool{}
+	for _, doc := range docs {
+		if id, ok := doc[m.idField].(string); ok {
+			matches[id] = true
+		}
+	}
+	return matches, nil
+}
+
+func (m mongoCollection) page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.pageMatching(ctx, bson.D{{}}, startIndex, recordPerPage)
+}
+
+// pageMatching returns a page of the documents matching filter and their
+// number.
+func (m mongoCollection) pageMatching(ctx context.Context, filter bson.D, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	matchStage := bson.D{{"$match", filter}}
+	groupStage := bson.D{
+		{"$group", bson.D{
+			{"_id", bson.D{{"_id", "null"}}},
+			{"total_count", bson.D{{"$sum", 1}}},
+			{"data", bson.D{{"$push", "$$ROOT"}}},
+		}}}
+	projectStage := bson.D{
+		{"$project", bson.D{
+			{"_id", 0},
+			{"total_count", 1},
+			{"data", bson.D{{"$slice", []interface{}{"$data", startIndex, recordPerPage}}}},
+		}}}
+
+	cursor, err := m.collection.Aggregate(ctx, mongo.Pipeline{
+		matchStage, groupStage, projectStage,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []struct {
+		Total_count int64
+		Data        []bson.M
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	if len(result) == 0 {
+		return []bson.M{}, 0, nil
+	}
+	return result[0].Data, result[0].Total_count, nil
+}
+
+func (m mongoCollection) all(ctx context.Context) ([]bson.M, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m mongoCollection) get(ctx context.Context, id string, v interface{}) error {
+	err := m.collection.FindOne(ctx, bson.D{{m.idField, id}}).Decode(v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// in decodes into v the documents whose field is one of values.
+func (m mongoCollection) in(ctx context.Context, field string, values []string, v interface{}) error {
+	cursor, err := m.collection.Find(ctx, bson.D{{field, bson.D{{"$in", values}}}})
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, v)
+}
+
+func (m mongoCollection) update(ctx context.Context, id string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.collection.UpdateOne(ctx, bson.D{{m.idField, id}}, bson.D{{"$set", set}})
+}
+
+func (m mongoCollection) delete(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	return m.collection.DeleteOne(ctx, bson.D{{m.idField, id}})
+}
+
+type mongoUsers struct{ mongoCollection }
+
+func (m mongoUsers) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.page(ctx, startIndex, recordPerPage)
+}
+
+func (m mongoUsers) Get(ctx context.Context, userId string) (user models.User, err error) {
+	err = m.get(ctx, userId, &user)
+	return user, err
+}
+
+func (m mongoUsers) GetByEmail(ctx context.Context, email string) (user models.User, err error) {
+	err = m.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = ErrNotFound
+	}
+	return user, err
+}
+
+func (m mongoUsers) CountByEmail(ctx context.Context, email string) (int64, error) {
+	return m.collection.CountDocuments(ctx, bson.D{{"email", email}})
+}
+
+func (m mongoUsers) CountByPhone(ctx context.Context, phone string) (int64, error) {
+	return m.collection.CountDocuments(ctx, bson.D{{"phone", phone}})
+}
+
+func (m mongoUsers) Create(ctx context.Context, user models.User) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, user)
+}
+
+func (m mongoUsers) UpdateTokens(ctx context.Context, userId, token, refreshToken string) error {
+	var updateObj bson.D
+	updateObj = append(updateObj, bson.E{"token", token})
+	updateObj = append(updateObj, bson.E{"refresh_token", refreshToken})
+	updateObj = append(updateObj, bson.E{"updated_at", now()})
+
+	_, err := m.update(ctx, userId, updateObj)
+	return err
+}
+
+func (m mongoUsers) UpdateAvatar(ctx context.Context, userId, avatar, thumbnail string) error {
+	_, err := m.update(ctx, userId, bson.D{
+		{"avatar", avatar},
+		{"avatar_thumbnail", thumbnail},
+		{"updated_at", now()},
+	})
+	return err
+}
+
+type mongoFoods struct{ mongoCollection }
+
+func (m mongoFoods) Page(ctx context.Context, filter FoodFilter, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	match := bson.D{}
+	if filter.Category != "" {
+		match = append(match, bson.E{"category", filter.Category})
+	}
+	if len(filter.Tags) > 0 {
+		match = append(match, bson.E{"tags", bson.D{{"$all", filter.Tags}}})
+	}
+	if len(filter.AllergenFree) > 0 {
+		match = append(match, bson.E{"allergens", bson.D{{"$ne", nil}, {"$nin", filter.AllergenFree}}})
+	}
+	if filter.MaxSpiceLevel != nil {
+		match = append(match, bson.E{"spice_level", bson.D{{"$lte", *filter.MaxSpiceLevel}}})
+	}
+	return m.pageMatching(ctx, match, startIndex, recordPerPage)
+}
+
+func (m mongoFoods) Get(ctx context.Context, foodId string) (food models.Food, err error) {
+	err = m.get(ctx, foodId, &food)
+	return food, err
+}
+
+func (m mongoFoods) GetMany(ctx context.Context, foodIds []string) (foods []models.Food, err error) {
+	err = m.in(ctx, m.idField, foodIds, &foods)
+	return foods, err
+}
+
+func (m mongoFoods) ByMenus(ctx context.Context, menuIds []string) (foods []models.Food, err error) {
+	err = m.in(ctx, "menu_id", menuIds, &foods)
+	return foods, err
+}
+
+func (m mongoFoods) Create(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, food)
+}
+
+func (m mongoFoods) Update(ctx context.Context, foodId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, foodId, set)
+}
+
+func (m mongoFoods) Delete(ctx context.Context, foodId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, foodId)
+}
+
+func (m mongoFoods) Limited(ctx context.Context) (foods []models.Food, err error) {
+	cursor, err := m.collection.Find(ctx, bson.D{{"$or", bson.A{
+		bson.D{{"sold_out", true}},
+		bson.D{{"daily_limit", bson.D{{"$ne", nil}}}},
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &foods)
+	return foods, err
+}
+
+// TakePortions counts the portions with a single update, matching the food
+// only when there are enough left, so that concurrent orders can't sell more
+// than the limit.
+func (m mongoFoods) TakePortions(ctx context.Context, foodId, day string, n int) (int, bool, error) {
+	// the portions sold on day, those of an earlier day don't count
+	sold := bson.D{{"$add", bson.A{
+		bson.D{{"$cond", bson.A{bson.D{{"$eq", bson.A{"$portions_date", day}}}, "$portions_sold", 0}}},
+		n,
+	}}}
+	unlimited := bson.D{{"$eq", bson.A{bson.D{{"$ifNull", bson.A{"$daily_limit", nil}}}, nil}}}
+	filter := bson.D{
+		{m.idField, foodId},
+		{"$expr", bson.D{{"$or", bson.A{unlimited, bson.D{{"$lte", bson.A{sold, "$daily_limit"}}}}}}},
+	}
+	update := mongo.Pipeline{{{"$set", bson.D{
+		{"portions_sold", bson.D{{"$cond", bson.A{unlimited, "$portions_sold", sold}}}},
+		{"portions_date", bson.D{{"$cond", bson.A{unlimited, "$portions_date", day}}}},
+	}}}}
+
+	var food models.Food
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&food)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if food.Daily_limit == nil {
+		return 0, true, nil
+	}
+	return *food.Daily_limit - food.Portions_sold, true, nil
+}
+
+// Search ranks the foods on the words searched. The foods of a restaurant
+// are few enough to be ranked whole, the text index only adding those
+// matching a stem of a word, e.g. "burgers" for "burger".
+func (m mongoFoods) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	stemmed, err := m.textMatches(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := bson.D{{"food_id", 1}, {"name", 1}, {"tags", 1}, {"description", 1}, {"menu_id", 1}}
+	cursor, err := m.collection.Find(ctx, bson.D{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	var foods []models.Food
+	if err := cursor.All(ctx, &foods); err != nil {
+		return nil, err
+	}
+	return rankFoods(foods, query, stemmed, limit), nil
+}
+
+type mongoMenus struct{ mongoCollection }
+
+func (m mongoMenus) All(ctx context.Context) ([]bson.M, error) {
+	return m.all(ctx)
+}
+
+func (m mongoMenus) Get(ctx context.Context, menuId string) (menu models.Menu, err error) {
+	err = m.get(ctx, menuId, &menu)
+	return menu, err
+}
+
+func (m mongoMenus) GetMany(ctx context.Context, menuIds []string) (menus []models.Menu, err error) {
+	err = m.in(ctx, m.idField, menuIds, &menus)
+	return menus, err
+}
+
+func (m mongoMenus) Create(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, menu)
+}
+
+func (m mongoMenus) Update(ctx context.Context, menuId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, menuId, set)
+}
+
+func (m mongoMenus) Delete(ctx context.Context, menuId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, menuId)
+}
+
+// Search ranks the menus like mongoFoods.Search.
+func (m mongoMenus) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	stemmed, err := m.textMatches(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var menus []models.Menu
+	cursor, err := m.collection.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &menus); err != nil {
+		return nil, err
+	}
+	return rankMenus(menus, query, stemmed, limit), nil
+}
+
+type mongoTables struct{ mongoCollection }
+
+func (m mongoTables) All(ctx context.Context) ([]bson.M, error) {
+	return m.all(ctx)
+}
+
+func (m mongoTables) Get(ctx context.Context, tableId string) (table models.Table, err error) {
+	err = m.get(ctx, tableId, &table)
+	return table, err
+}
+
+func (m mongoTables) GetMany(ctx context.Context, tableIds []string) (tables []models.Table, err error) {
+	err = m.in(ctx, m.idField, tableIds, &tables)
+	return tables, err
+}
+
+func (m mongoTables) Create(ctx context.Context, table models.Table) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, table)
+}
+
+func (m mongoTables) Update(ctx context.Context, tableId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, tableId, set)
+}
+
+func (m mongoTables) Delete(ctx context.Context, tableId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, tableId)
+}
+
+type mongoOrders struct{ mongoCollection }
+
+func (m mongoOrders) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return m.page(ctx, startIndex, recordPerPage)
+}
+
+func (m mongoOrders) Get(ctx context.Context, orderId string) (order models.Order, err error) {
+	err = m.get(ctx, orderId, &order)
+	return order, err
+}
+
+func (m mongoOrders) GetMany(ctx context.Context, orderIds []string) (orders []models.Order, err error) {
+	err = m.in(ctx, m.idField, orderIds, &orders)
+	return orders, err
+}
+
+func (m mongoOrders) Create(ctx context.Context, order models.Order) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, order)
+}
+
+func (m mongoOrders) Update(ctx context.Context, orderId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, orderId, set)
+}
+
+func (m mongoOrders) Delete(ctx context.Context, orderId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, orderId)
+}
+
+type mongoOrderItems struct{ mongoCollection }
+
+func (m mongoOrderItems) All(ctx context.Context) ([]bson.M, error) {
+	return m.all(ctx)
+}
+
+func (m mongoOrderItems) Get(ctx context.Context, orderItemId string) (orderItem models.OrderItem, err error) {
+	err = m.get(ctx, orderItemId, &orderItem)
+	return orderItem, err
+}
+
+func (m mongoOrderItems) ByOrders(ctx context.Context, orderIds []string) (orderItems []models.OrderItem, err error) {
+	err = m.in(ctx, "order_id", orderIds, &orderItems)
+	return orderItems, err
+}
+
+func (m mongoOrderItems) CreateMany(ctx context.Context, orderItems []models.OrderItem) (*mongo.InsertManyResult, error) {
+	docs := make([]interface{}, 0, len(orderItems))
+	for _, orderItem := range orderItems {
+		docs = append(docs, orderItem)
+	}
+	return m.collection.InsertMany(ctx, docs)
+}
+
+func (m mongoOrderItems) Update(ctx context.Context, orderItemId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, orderItemId, set)
+}
+
+func (m mongoOrderItems) Delete(ctx context.Context, orderItemId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, orderItemId)
+}
+
+func (m mongoOrderItems) ItemsByOrder(ctx context.Context, orderId string) (orderItems []bson.M, err error) {
+	matchStage := bson.D{{"$match", bson.D{{"order_id", orderId}}}}
+	lookupFoodStage := bson.D{{"$lookup",
+		bson.D{{"from", "food"},
+			{"localField", "food_id"},
+			{"foreignField", "food_id"},
+			{"as", "food"},
+		}},
+	}
+	unwindFoodStage := bson.D{{"$unwind", bson.D{
+		{"path", "$food"},
+		{"preserveNullAndEmptyArrays", true},
+	}}}
+
+	lookupOrderStage := bson.D{{"$lookup",
+		bson.D{{"from", "order"},
+			{"localField", "order_id"},
+			{"foreignField", "order_id"},
+			{"as", "order"},
+		}}}
+	unwindOrderStage := bson.D{{"$unwind",
+		bson.D{{"path", "$order"},
+			{"preserveNullAndEmptyArrays", true},
+		}}}
+
+	lookupTableStage := bson.D{{"$lookup",
+		bson.D{{"from", "table"},
+			{"localField", "order.table_id"},
+			{"foreignField", "table_id"},
+			{"as", "table"},
+		}}}
+	unwindTableStage := bson.D{{"$unwind",
+		bson.D{{"path", "$table"},
+			{"preserveNullAndEmptyArrays", true},
+		}}}
+
+	//addFiledStage := bson.D{{"$addFields", bson.D{{"amount", "$food.price"}}}}
+	addFiledStage := bson.D{
+		{"$addFields", bson.D{
+			{"amount", "$unit_price"},
+			{"food_name", "$food.name"},
+			{"food_image", "$food.food_image"},
+			{"table_number", "$table.table_number"},
+			{"table_id", "$table.table_id"},
+			{"order_id", "$order.order_id"},
+			{"price", "$unit_price"},
+			{"quantity", 1},
+		}}}
+
+	groupStage := bson.D{{"$group",
+		bson.D{{"_id",
+			bson.D{{"order_id", "$order_id"},
+				{"table_id", "$table_id"},
+				{"table_number", "$table_number"},
+			}},
+			{"payment_due", bson.D{{"$sum", "$amount"}}},
+			{"total_count", bson.D{{"$sum", 1}}},
+			{"order_items", bson.D{{"$push", "$$ROOT"}}},
+		}}}
+
+	projectStage2 := bson.D{{"$project", bson.D{
+		{"_id", 0},
+		{"payment_due", 1},
+		{"total_count", 1},
+		{"table_number", "$_id.table_number"},
+		{"order_items", 1},
+	}}}
+
+	cursor, err := m.collection.Aggregate(ctx, mongo.Pipeline{
+		matchStage,
+		lookupFoodStage,
+		unwindFoodStage,
+		lookupOrderStage,
+		unwindOrderStage,
+		lookupTableStage,
+		unwindTableStage,
+		addFiledStage,
+		groupStage,
+		projectStage2,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &orderItems); err != nil {
+		return nil, err
+	}
+
+	return orderItems, nil
+}
+
+type mongoInvoices struct{ mongoCollection }
+
+func (m mongoInvoices) All(ctx context.Context) ([]bson.M, error) {
+	return m.all(ctx)
+}
+
+func (m mongoInvoices) Get(ctx context.Context, invoiceId string) (invoice models.Invoice, err error) {
+	err = m.get(ctx, invoiceId, &invoice)
+	return invoice, err
+}
+
+func (m mongoInvoices) Create(ctx context.Context, invoice models.Invoice) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, invoice)
+}
+
+func (m mongoInvoices) Update(ctx context.Context, invoiceId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, invoiceId, set)
+}
+
+func (m mongoInvoices) Delete(ctx context.Context, invoiceId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, invoiceId)
+}
+
+type mongoWebhooks struct{ mongoCollection }
+
+func (m mongoWebhooks) All(ctx context.Context) ([]bson.M, error) {
+	return m.all(ctx)
+}
+
+func (m mongoWebhooks) Get(ctx context.Context, webhookId string) (webhook models.Webhook, err error) {
+	err = m.get(ctx, webhookId, &webhook)
+	return webhook, err
+}
+
+func (m mongoWebhooks) Subscribed(ctx context.Context, event string) (webhooks []models.Webhook, err error) {
+	cursor, err := m.collection.Find(ctx, bson.D{{"events", event}, {"active", bson.D{{"$ne", false}}}})
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &webhooks)
+	return webhooks, err
+}
+
+func (m mongoWebhooks) Create(ctx context.Context, webhook models.Webhook) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, webhook)
+}
+
+func (m mongoWebhooks) Update(ctx context.Context, webhookId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, webhookId, set)
+}
+
+func (m mongoWebhooks) Delete(ctx context.Context, webhookId string) (*mongo.DeleteResult, error) {
+	return m.delete(ctx, webhookId)
+}
+
+type mongoWebhookDeliveries struct{ mongoCollection }
+
+func (m mongoWebhookDeliveries) ByWebhook(ctx context.Context, webhookId string, limit int) (deliveries []models.WebhookDelivery, err error) {
+	opts := options.Find().SetSort(bson.D{{"created_at", -1}, {"_id", -1}}).SetLimit(int64(limit))
+	cursor, err := m.collection.Find(ctx, bson.D{{"webhook_id", webhookId}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &deliveries)
+	return deliveries, err
+}
+
+func (m mongoWebhookDeliveries) Get(ctx context.Context, deliveryId string) (delivery models.WebhookDelivery, err error) {
+	err = m.get(ctx, deliveryId, &delivery)
+	return delivery, err
+}
+
+func (m mongoWebhookDeliveries) Create(ctx context.Context, delivery models.WebhookDelivery) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, delivery)
+}
+
+func (m mongoWebhookDeliveries) Update(ctx context.Context, deliveryId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, deliveryId, set)
+}
+
+func (m mongoWebhookDeliveries) Due(ctx context.Context, now time.Time, limit int) (deliveries []models.WebhookDelivery, err error) {
+	opts := options.Find().SetSort(bson.D{{"next_attempt_at", 1}}).SetLimit(int64(limit))
+	cursor, err := m.collection.Find(ctx, bson.D{{"status", "PENDING"}, {"next_attempt_at", bson.D{{"$lte", now}}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &deliveries)
+	return deliveries, err
+}
+
+type mongoOutbox struct{ mongoCollection }
+
+func (m mongoOutbox) Create(ctx context.Context, event models.OutboxEvent) (*mongo.InsertOneResult, error) {
+	return m.collection.InsertOne(ctx, event)
+}
+
+func (m mongoOutbox) Update(ctx context.Context, eventId string, set bson.D) (*mongo.UpdateResult, error) {
+	return m.update(ctx, eventId, set)
+}
+
+func (m mongoOutbox) Due(ctx context.Context, now time.Time, limit int) (events []models.OutboxEvent, err error) {
+	opts := options.Find().SetSort(bson.D{{"_id", 1}}).SetLimit(int64(limit))
+	cursor, err := m.collection.Find(ctx, bson.D{{"status", "PENDING"}, {"next_attempt_at", bson.D{{"$lte", now}}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &events)
+	return events, err
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"restaurant_management/database"
+)
+
+// mongoIndexes are the indexes of the collections, by collection. Creating
+// an index that exists does nothing.
+var mongoIndexes = map[string][]mongo.IndexModel{
+	// the text indexes match the stems of the words searched, weighted like
+	// the fields ranked by rankFoods and rankMenus
+	"food": {{
+		Keys: bson.D{{"name", "text"}, {"tags", "text"}, {"description", "text"}},
+		Options: options.Index().
+			SetName("food_search").
+			SetWeights(bson.D{{"name", nameWeight}, {"tags", tagWeight}, {"description", descriptionWeight}}),
+	}},
+	"menu": {{
+		Keys: bson.D{{"name", "text"}, {"category", "text"}},
+		Options: options.Index().
+			SetName("menu_search").
+			SetWeights(bson.D{{"name", nameWeight}, {"category", descriptionWeight}}),
+	}},
+}
+
+// CreateMongoIndexes creates the indexes of the collections of the
+// restaurant_management database of client.
+func CreateMongoIndexes(ctx context.Context, client *mongo.Client) error {
+	for name, indexes := range mongoIndexes {
+		if _, err := database.OpenCollection(client, name).Indexes().CreateMany(ctx, indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"restaurant_management/config"
+	"restaurant_management/database"
+)
+
+// Open returns the store of the database selected by cfg, bringing the
+// PostgreSQL schema up to date first when cfg asks to. A standalone Mongo
+// server is refused unless cfg allows it, its units of work can't be rolled
+// back.
+func Open(ctx context.Context, cfg config.Database) (Store, error) {
+	switch cfg.Driver {
+	case "mongo":
+		transactions, err := mongoTransactions(ctx, database.Client)
+		if err != nil {
+			return nil, fmt.Errorf("detecting mongo transactions: %w", err)
+		}
+		if !transactions && !cfg.AllowStandalone {
+			return nil, errors.New("mongo server is standalone, transactions need a replica set or a sharded cluster; set MONGODB_ALLOW_STANDALONE=true to run without them")
+		}
+		if err := CreateMongoIndexes(ctx, database.Client); err != nil {
+			return nil, fmt.Errorf("indexing mongo: %w", err)
+		}
+		return NewMongoStore(database.Client), nil
+
+	case "postgres":
+		pool, err := database.ConnectPostgres(ctx, cfg.PostgresURL)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Migrate {
+			if err := MigratePostgres(ctx, pool); err != nil {
+				pool.Close()
+				return nil, fmt.Errorf("migrating postgres: %w", err)
+			}
+		}
+		return NewPostgresStore(pool), nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q, expected mongo or postgres", cfg.Driver)
+}
@@ -0,0 +1,850 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"reflect"
+	"restaurant_management/models"
+	"strings"
+	"time"
+)
+
+// postgresStore keeps every collection in a table of a PostgreSQL database
+// whose schema is created by MigratePostgres. Rows are converted to and from
+// the documents Mongo would store, so reads return exactly what the Mongo
+// store returns.
+type postgresStore struct {
+	pool *pgxpool.Pool
+
+	users      postgresUsers
+	foods      postgresFoods
+	menus      postgresMenus
+	tables     postgresTables
+	orders     postgresOrders
+	orderItems postgresOrderItems
+	invoices   postgresInvoices
+	webhooks   postgresWebhooks
+	deliveries postgresWebhookDeliveries
+	outbox     postgresOutbox
+}
+
+// NewPostgresStore returns a Store backed by the database of pool, migrated
+// by MigratePostgres.
+func NewPostgresStore(pool *pgxpool.Pool) Store {
+	s := &postgresStore{pool: pool}
+	s.users = postgresUsers{newPostgresTable(s, "users", "user_id", models.User{})}
+	s.foods = postgresFoods{newPostgresTable(s, "foods", "food_id", models.Food{})}
+	s.menus = postgresMenus{newPostgresTable(s, "menus", "menu_id", models.Menu{})}
+	s.tables = postgresTables{newPostgresTable(s, "tables", "table_id", models.Table{})}
+	s.orders = postgresOrders{newPostgresTable(s, "orders", "order_id", models.Order{})}
+	s.orderItems = postgresOrderItems{newPostgresTable(s, "order_items", "order_item_id", models.OrderItem{})}
+	s.invoices = postgresInvoices{newPostgresTable(s, "invoices", "invoice_id", models.Invoice{})}
+	s.webhooks = postgresWebhooks{newPostgresTable(s, "webhooks", "webhook_id", models.Webhook{})}
+	s.deliveries = postgresWebhookDeliveries{newPostgresTable(s, "webhook_deliveries", "delivery_id", models.WebhookDelivery{})}
+	s.outbox = postgresOutbox{newPostgresTable(s, "outbox", "event_id", models.OutboxEvent{})}
+	return s
+}
+
+func (s *postgresStore) Users() UserStore           { return s.users }
+func (s *postgresStore) Foods() FoodStore           { return s.foods }
+func (s *postgresStore) Menus() MenuStore           { return s.menus }
+func (s *postgresStore) Tables() TableStore         { return s.tables }
+func (s *postgresStore) Orders() OrderStore         { return s.orders }
+func (s *postgresStore) OrderItems() OrderItemStore { return s.orderItems }
+func (s *postgresStore) Invoices() InvoiceStore     { return s.invoices }
+func (s *postgresStore) Webhooks() WebhookStore     { return s.webhooks }
+
+func (s *postgresStore) WebhookDeliveries() WebhookDeliveryStore { return s.deliveries }
+func (s *postgresStore) Outbox() OutboxStore                     { return s.outbox }
+
+type postgresTxKey struct{}
+
+// WithTransaction runs fn inside a PostgreSQL transaction, which the queries
+// made with the context fn receives go through.
+func (s *postgresStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		return fn(withinTransaction(context.WithValue(ctx, postgresTxKey{}, tx)))
+	})
+}
+
+type postgresQuerier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// db returns the transaction ctx belongs to, the pool outside of one.
+func (s *postgresStore) db(ctx context.Context) postgresQuerier {
+	if tx, ok := ctx.Value(postgresTxKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return s.pool
+}
+
+type postgresTable struct {
+	store    *postgresStore
+	name     string
+	idColumn string
+	columns  []string
+}
+
+func newPostgresTable(s *postgresStore, name, idColumn string, model interface{}) postgresTable {
+	return postgresTable{
+		store:    s,
+		name:     name,
+		idColumn: idColumn,
+		columns:  columnsOf(reflect.TypeOf(model)),
+	}
+}
+
+// columnsOf returns the columns of the table of model: the fields of its
+// documents, "_id" being "id".
+func columnsOf(model reflect.Type) []string {
+	columns := make([]string, 0, model.NumField())
+	for i := 0; i < model.NumField(); i++ {
+		f := model.Field(i)
+		name := strings.ToLower(f.Name)
+		if tag := strings.Split(f.Tag.Get("bson"), ",")[0]; tag != "" {
+			name = tag
+		}
+		columns = append(columns, column(name))
+	}
+	return columns
+}
+
+func column(field string) string {
+	if field == "_id" {
+		return "id"
+	}
+	return field
+}
+
+func field(column string) string {
+	if column == "id" {
+		return "_id"
+	}
+	return column
+}
+
+// columnList returns the columns of the table qualified by alias, ready to be
+// selected.
+func (t postgresTable) columnList(alias string) string {
+	columns := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		columns[i] = alias + "." + pgx.Identifier{c}.Sanitize()
+	}
+	return strings.Join(columns, ", ")
+}
+
+func (t postgresTable) has(column string) bool {
+	for _, c := range t.columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the documents of the rows selected by clauses, the WHERE,
+// ORDER BY and LIMIT clauses of the query.
+func (t postgresTable) find(ctx context.Context, clauses string, args ...interface{}) ([]bson.M, error) {
+	sql := "SELECT " + t.columnList(t.name) + " FROM " + t.name + " " + clauses
+	rows, err := t.store.db(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []bson.M
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, document(t.columns, values))
+	}
+	return docs, rows.Err()
+}
+
+// textMatches returns the IDs of the rows whose vector, a tsvector
+// expression, matches every word of query.
+func (t postgresTable) textMatches(ctx context.Context, vector, query string) (map[string]bool, error) {
+	sql := "SELECT " + pgx.Identifier{t.idColumn}.Sanitize() + " FROM " + t.name + " WHERE " + vector + " @@ plainto_tsquery('english', $1)"
+	rows, err := t.store.db(ctx).Query(ctx, sql, query)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	matches := map[string]bool{}
+	for _, id := range ids {
+		matches[id] = true
+	}
+	return matches, nil
+}
+
+func (t postgresTable) findOne(ctx context.Context, v interface{}, where string, args ...interface{}) error {
+	docs, err := t.find(ctx, "WHERE "+where+" ORDER BY id LIMIT 1", args...)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return ErrNotFound
+	}
+
+	data, err := bson.Marshal(docs[0])
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, v)
+}
+
+func (t postgresTable) get(ctx context.Context, id string, v interface{}) error {
+	return t.findOne(ctx, v, pgx.Identifier{t.idColumn}.Sanitize()+" = $1", id)
+}
+
+func (t postgresTable) all(ctx context.Context) ([]bson.M, error) {
+	return t.find(ctx, "ORDER BY id")
+}
+
+func (t postgresTable) page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return t.pageWhere(ctx, "", nil, startIndex, recordPerPage)
+}
+
+// pageWhere returns a page of the rows matching where, all of them when
+// where is empty, and their number.
+func (t postgresTable) pageWhere(ctx context.Context, where string, args []interface{}, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	var total int64
+	if err := t.count(ctx, &total, where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	clauses := fmt.Sprintf("ORDER BY id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	if where != "" {
+		clauses = "WHERE " + where + " " + clauses
+	}
+	docs, err := t.find(ctx, clauses, append(args, recordPerPage, startIndex)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if docs == nil {
+		docs = []bson.M{}
+	}
+	return docs, total, nil
+}
+
+// count counts the rows matching where, all of them when where is empty.
+func (t postgresTable) count(ctx context.Context, total *int64, where string, args ...interface{}) error {
+	sql := "SELECT count(*) FROM " + t.name
+	if where != "" {
+		sql += " WHERE " + where
+	}
+
+	rows, err := t.store.db(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	*total, err = pgx.CollectOneRow(rows, pgx.RowTo[int64])
+	return err
+}
+
+// postgresIn returns the documents whose column is one of values, decoded
+// into T.
+func postgresIn[T any](ctx context.Context, t postgresTable, column string, values []string) ([]T, error) {
+	docs, err := t.find(ctx, "WHERE "+pgx.Identifier{column}.Sanitize()+" = ANY($1) ORDER BY id", values)
+	if err != nil {
+		return nil, err
+	}
+	return Decode[T](docs)
+}
+
+// postgresFind returns the documents of the rows selected by clauses decoded
+// into T.
+func postgresFind[T any](ctx context.Context, t postgresTable, clauses string, args ...interface{}) ([]T, error) {
+	docs, err := t.find(ctx, clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	return Decode[T](docs)
+}
+
+func (t postgresTable) insert(ctx context.Context, values ...interface{}) ([]interface{}, error) {
+	columns := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		columns[i] = pgx.Identifier{c}.Sanitize()
+	}
+
+	var (
+		rows []string
+		args []interface{}
+		ids  []interface{}
+	)
+	for _, v := range values {
+		doc, err := toDocument(v)
+		if err != nil {
+			return nil, err
+		}
+
+		params := make([]string, len(t.columns))
+		for i, c := range t.columns {
+			args = append(args, argument(doc[field(c)]))
+			params[i] = fmt.Sprintf("$%d", len(args))
+		}
+		rows = append(rows, "("+strings.Join(params, ", ")+")")
+		ids = append(ids, doc["_id"])
+	}
+
+	sql := "INSERT INTO " + t.name + " (" + strings.Join(columns, ", ") + ") VALUES " + strings.Join(rows, ", ")
+	if _, err := t.store.db(ctx).Exec(ctx, sql, args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (t postgresTable) insertOne(ctx context.Context, v interface{}) (*mongo.InsertOneResult, error) {
+	ids, err := t.insert(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertOneResult{InsertedID: ids[0]}, nil
+}
+
+func (t postgresTable) update(ctx context.Context, id string, set bson.D) (*mongo.UpdateResult, error) {
+	values, err := toDocument(set)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		assignments []string
+		args        []interface{}
+	)
+	for _, e := range set {
+		c := column(e.Key)
+		if !t.has(c) || c == "id" {
+			return nil, fmt.Errorf("%s has no field %q to set", t.name, e.Key)
+		}
+		args = append(args, argument(values[e.Key]))
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", pgx.Identifier{c}.Sanitize(), len(args)))
+	}
+	args = append(args, id)
+
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s = $%d", t.name, strings.Join(assignments, ", "), pgx.Identifier{t.idColumn}.Sanitize(), len(args))
+	tag, err := t.store.db(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.UpdateResult{MatchedCount: tag.RowsAffected(), ModifiedCount: tag.RowsAffected()}, nil
+}
+
+func (t postgresTable) delete(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	sql := "DELETE FROM " + t.name + " WHERE " + pgx.Identifier{t.idColumn}.Sanitize() + " = $1"
+	tag, err := t.store.db(ctx).Exec(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.DeleteResult{DeletedCount: tag.RowsAffected()}, nil
+}
+
+// document converts the values of a row into the document Mongo would store.
+func document(columns []string, values []interface{}) bson.M {
+	doc := make(bson.M, len(columns))
+	for i, c := range columns {
+		switch v := values[i].(type) {
+		case time.Time:
+			doc[field(c)] = primitive.NewDateTimeFromTime(v)
+		case []interface{}, map[string]interface{}:
+			doc[field(c)] = embedded(v)
+		case string:
+			if c == "id" {
+				id, _ := primitive.ObjectIDFromHex(v)
+				doc[field(c)] = id
+				continue
+			}
+			doc[field(c)] = v
+		default:
+			doc[field(c)] = v
+		}
+	}
+	return doc
+}
+
+// embedded converts the arrays and the objects of a JSON value into the
+// arrays and the documents Mongo would embed.
+func embedded(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make(primitive.A, len(v))
+		for i, e := range v {
+			values[i] = embedded(e)
+		}
+		return values
+	case map[string]interface{}:
+		doc := make(bson.M, len(v))
+		for key, e := range v {
+			doc[key] = embedded(e)
+		}
+		return doc
+	}
+	return value
+}
+
+// argument converts a value of a document into a query argument.
+func argument(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.ObjectID:
+		return v.Hex()
+	case primitive.DateTime:
+		return v.Time()
+	case primitive.A:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return v
+			}
+			values = append(values, s)
+		}
+		return values
+	}
+	return value
+}
+
+type postgresUsers struct{ postgresTable }
+
+func (p postgresUsers) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return p.page(ctx, startIndex, recordPerPage)
+}
+
+func (p postgresUsers) Get(ctx context.Context, userId string) (user models.User, err error) {
+	err = p.get(ctx, userId, &user)
+	return user, err
+}
+
+func (p postgresUsers) GetByEmail(ctx context.Context, email string) (user models.User, err error) {
+	err = p.findOne(ctx, &user, "email = $1", email)
+	return user, err
+}
+
+func (p postgresUsers) CountByEmail(ctx context.Context, email string) (count int64, err error) {
+	err = p.count(ctx, &count, "email = $1", email)
+	return count, err
+}
+
+func (p postgresUsers) CountByPhone(ctx context.Context, phone string) (count int64, err error) {
+	err = p.count(ctx, &count, "phone = $1", phone)
+	return count, err
+}
+
+func (p postgresUsers) Create(ctx context.Context, user models.User) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, user)
+}
+
+func (p postgresUsers) UpdateTokens(ctx context.Context, userId, token, refreshToken string) error {
+	_, err := p.update(ctx, userId, bson.D{
+		{"token", token},
+		{"refresh_token", refreshToken},
+		{"updated_at", now()},
+	})
+	return err
+}
+
+func (p postgresUsers) UpdateAvatar(ctx context.Context, userId, avatar, thumbnail string) error {
+	_, err := p.update(ctx, userId, bson.D{
+		{"avatar", avatar},
+		{"avatar_thumbnail", thumbnail},
+		{"updated_at", now()},
+	})
+	return err
+}
+
+type postgresFoods struct{ postgresTable }
+
+func (p postgresFoods) Page(ctx context.Context, filter FoodFilter, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(args)))
+	}
+	if len(filter.AllergenFree) > 0 {
+		args = append(args, filter.AllergenFree)
+		conditions = append(conditions, fmt.Sprintf("allergens IS NOT NULL AND NOT allergens && $%d", len(args)))
+	}
+	if filter.MaxSpiceLevel != nil {
+		args = append(args, *filter.MaxSpiceLevel)
+		conditions = append(conditions, fmt.Sprintf("spice_level <= $%d", len(args)))
+	}
+	return p.pageWhere(ctx, strings.Join(conditions, " AND "), args, startIndex, recordPerPage)
+}
+
+func (p postgresFoods) Get(ctx context.Context, foodId string) (food models.Food, err error) {
+	err = p.get(ctx, foodId, &food)
+	return food, err
+}
+
+func (p postgresFoods) GetMany(ctx context.Context, foodIds []string) ([]models.Food, error) {
+	return postgresIn[models.Food](ctx, p.postgresTable, p.idColumn, foodIds)
+}
+
+func (p postgresFoods) ByMenus(ctx context.Context, menuIds []string) ([]models.Food, error) {
+	return postgresIn[models.Food](ctx, p.postgresTable, "menu_id", menuIds)
+}
+
+func (p postgresFoods) Create(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, food)
+}
+
+func (p postgresFoods) Update(ctx context.Context, foodId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, foodId, set)
+}
+
+func (p postgresFoods) Delete(ctx context.Context, foodId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, foodId)
+}
+
+func (p postgresFoods) Limited(ctx context.Context) ([]models.Food, error) {
+	docs, err := p.find(ctx, "WHERE sold_out OR daily_limit IS NOT NULL ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	return Decode[models.Food](docs)
+}
+
+// TakePortions counts the portions with a single update, matching the food
+// only when there are enough left, so that concurrent orders can't sell more
+// than the limit.
+func (p postgresFoods) TakePortions(ctx context.Context, foodId, day string, n int) (int, bool, error) {
+	// the portions sold on day, those of an earlier day don't count
+	const sold = "CASE WHEN portions_date = $2 THEN coalesce(portions_sold, 0) ELSE 0 END + $3"
+	sql := `UPDATE foods SET
+		portions_sold = CASE WHEN daily_limit IS NULL THEN portions_sold ELSE ` + sold + ` END,
+		portions_date = CASE WHEN daily_limit IS NULL THEN portions_date ELSE $2 END
+	WHERE food_id = $1 AND (daily_limit IS NULL OR ` + sold + ` <= daily_limit)
+	RETURNING coalesce(daily_limit - portions_sold, 0)`
+
+	rows, err := p.store.db(ctx).Query(ctx, sql, foodId, day, n)
+	if err != nil {
+		return 0, false, err
+	}
+	left, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil || len(left) == 0 {
+		return 0, false, err
+	}
+	return left[0], true, nil
+}
+
+// foodSearchVector is the text searched of a food, indexed by foods_search.
+const foodSearchVector = "to_tsvector('english', coalesce(name, '') || ' ' || coalesce(description, ''))"
+
+// Search ranks the foods like mongoFoods.Search, the foods_search index
+// matching the stems of the words.
+func (p postgresFoods) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	stemmed, err := p.textMatches(ctx, foodSearchVector, query)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := p.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foods, err := Decode[models.Food](docs)
+	if err != nil {
+		return nil, err
+	}
+	return rankFoods(foods, query, stemmed, limit), nil
+}
+
+type postgresMenus struct{ postgresTable }
+
+func (p postgresMenus) All(ctx context.Context) ([]bson.M, error) {
+	return p.all(ctx)
+}
+
+func (p postgresMenus) Get(ctx context.Context, menuId string) (menu models.Menu, err error) {
+	err = p.get(ctx, menuId, &menu)
+	return menu, err
+}
+
+func (p postgresMenus) GetMany(ctx context.Context, menuIds []string) ([]models.Menu, error) {
+	return postgresIn[models.Menu](ctx, p.postgresTable, p.idColumn, menuIds)
+}
+
+func (p postgresMenus) Create(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, menu)
+}
+
+func (p postgresMenus) Update(ctx context.Context, menuId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, menuId, set)
+}
+
+func (p postgresMenus) Delete(ctx context.Context, menuId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, menuId)
+}
+
+// menuSearchVector is the text searched of a menu, indexed by menus_search.
+const menuSearchVector = "to_tsvector('english', name || ' ' || category)"
+
+// Search ranks the menus like postgresFoods.Search.
+func (p postgresMenus) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	stemmed, err := p.textMatches(ctx, menuSearchVector, query)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := p.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	menus, err := Decode[models.Menu](docs)
+	if err != nil {
+		return nil, err
+	}
+	return rankMenus(menus, query, stemmed, limit), nil
+}
+
+type postgresTables struct{ postgresTable }
+
+func (p postgresTables) All(ctx context.Context) ([]bson.M, error) {
+	return p.all(ctx)
+}
+
+func (p postgresTables) Get(ctx context.Context, tableId string) (table models.Table, err error) {
+	err = p.get(ctx, tableId, &table)
+	return table, err
+}
+
+func (p postgresTables) GetMany(ctx context.Context, tableIds []string) ([]models.Table, error) {
+	return postgresIn[models.Table](ctx, p.postgresTable, p.idColumn, tableIds)
+}
+
+func (p postgresTables) Create(ctx context.Context, table models.Table) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, table)
+}
+
+func (p postgresTables) Update(ctx context.Context, tableId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, tableId, set)
+}
+
+func (p postgresTables) Delete(ctx context.Context, tableId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, tableId)
+}
+
+type postgresOrders struct{ postgresTable }
+
+func (p postgresOrders) Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error) {
+	return p.page(ctx, startIndex, recordPerPage)
+}
+
+func (p postgresOrders) Get(ctx context.Context, orderId string) (order models.Order, err error) {
+	err = p.get(ctx, orderId, &order)
+	return order, err
+}
+
+func (p postgresOrders) GetMany(ctx context.Context, orderIds []string) ([]models.Order, error) {
+	return postgresIn[models.Order](ctx, p.postgresTable, p.idColumn, orderIds)
+}
+
+func (p postgresOrders) Create(ctx context.Context, order models.Order) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, order)
+}
+
+func (p postgresOrders) Update(ctx context.Context, orderId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, orderId, set)
+}
+
+func (p postgresOrders) Delete(ctx context.Context, orderId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, orderId)
+}
+
+type postgresOrderItems struct{ postgresTable }
+
+func (p postgresOrderItems) All(ctx context.Context) ([]bson.M, error) {
+	return p.all(ctx)
+}
+
+func (p postgresOrderItems) Get(ctx context.Context, orderItemId string) (orderItem models.OrderItem, err error) {
+	err = p.get(ctx, orderItemId, &orderItem)
+	return orderItem, err
+}
+
+func (p postgresOrderItems) ByOrders(ctx context.Context, orderIds []string) ([]models.OrderItem, error) {
+	return postgresIn[models.OrderItem](ctx, p.postgresTable, "order_id", orderIds)
+}
+
+func (p postgresOrderItems) CreateMany(ctx context.Context, orderItems []models.OrderItem) (*mongo.InsertManyResult, error) {
+	values := make([]interface{}, 0, len(orderItems))
+	for _, orderItem := range orderItems {
+		values = append(values, orderItem)
+	}
+
+	ids, err := p.insert(ctx, values...)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertManyResult{InsertedIDs: ids}, nil
+}
+
+func (p postgresOrderItems) Update(ctx context.Context, orderItemId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, orderItemId, set)
+}
+
+func (p postgresOrderItems) Delete(ctx context.Context, orderItemId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, orderItemId)
+}
+
+// ItemsByOrder joins the items of the order with their food, order and table
+// in a single query, then decorates and groups them like the aggregation
+// pipeline of the Mongo store.
+func (p postgresOrderItems) ItemsByOrder(ctx context.Context, orderId string) ([]bson.M, error) {
+	foods, orders, tables := p.store.foods.postgresTable, p.store.orders.postgresTable, p.store.tables.postgresTable
+
+	sql := "SELECT " + strings.Join([]string{
+		p.columnList("i"), foods.columnList("f"), orders.columnList("o"), tables.columnList("t"),
+	}, ", ") + `
+		FROM order_items i
+		LEFT JOIN foods f ON f.food_id = i.food_id
+		LEFT JOIN orders o ON o.order_id = i.order_id
+		LEFT JOIN tables t ON t.table_id = o.table_id
+		WHERE i.order_id = $1
+		ORDER BY i.id`
+
+	rows, err := p.store.db(ctx).Query(ctx, sql, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []joinedItem
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+
+		// the columns of every table follow those of the previous one, a
+		// table that didn't join has a NULL id
+		joined := func(t postgresTable) bson.M {
+			row := values[:len(t.columns)]
+			values = values[len(t.columns):]
+			if row[0] == nil {
+				return nil
+			}
+			return document(t.columns, row)
+		}
+		items = append(items, joinedItem{
+			item:  joined(p.postgresTable),
+			food:  joined(foods),
+			order: joined(orders),
+			table: joined(tables),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return summarizeOrder(items), nil
+}
+
+type postgresInvoices struct{ postgresTable }
+
+func (p postgresInvoices) All(ctx context.Context) ([]bson.M, error) {
+	return p.all(ctx)
+}
+
+func (p postgresInvoices) Get(ctx context.Context, invoiceId string) (invoice models.Invoice, err error) {
+	err = p.get(ctx, invoiceId, &invoice)
+	return invoice, err
+}
+
+func (p postgresInvoices) Create(ctx context.Context, invoice models.Invoice) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, invoice)
+}
+
+func (p postgresInvoices) Update(ctx context.Context, invoiceId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, invoiceId, set)
+}
+
+func (p postgresInvoices) Delete(ctx context.Context, invoiceId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, invoiceId)
+}
+
+type postgresWebhooks struct{ postgresTable }
+
+func (p postgresWebhooks) All(ctx context.Context) ([]bson.M, error) {
+	return p.all(ctx)
+}
+
+func (p postgresWebhooks) Get(ctx context.Context, webhookId string) (webhook models.Webhook, err error) {
+	err = p.get(ctx, webhookId, &webhook)
+	return webhook, err
+}
+
+func (p postgresWebhooks) Subscribed(ctx context.Context, event string) ([]models.Webhook, error) {
+	return postgresFind[models.Webhook](ctx, p.postgresTable, "WHERE $1 = ANY(events) AND active IS DISTINCT FROM false ORDER BY id", event)
+}
+
+func (p postgresWebhooks) Create(ctx context.Context, webhook models.Webhook) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, webhook)
+}
+
+func (p postgresWebhooks) Update(ctx context.Context, webhookId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, webhookId, set)
+}
+
+func (p postgresWebhooks) Delete(ctx context.Context, webhookId string) (*mongo.DeleteResult, error) {
+	return p.delete(ctx, webhookId)
+}
+
+type postgresWebhookDeliveries struct{ postgresTable }
+
+func (p postgresWebhookDeliveries) ByWebhook(ctx context.Context, webhookId string, limit int) ([]models.WebhookDelivery, error) {
+	return postgresFind[models.WebhookDelivery](ctx, p.postgresTable, "WHERE webhook_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2", webhookId, limit)
+}
+
+func (p postgresWebhookDeliveries) Get(ctx context.Context, deliveryId string) (delivery models.WebhookDelivery, err error) {
+	err = p.get(ctx, deliveryId, &delivery)
+	return delivery, err
+}
+
+func (p postgresWebhookDeliveries) Create(ctx context.Context, delivery models.WebhookDelivery) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, delivery)
+}
+
+func (p postgresWebhookDeliveries) Update(ctx context.Context, deliveryId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, deliveryId, set)
+}
+
+func (p postgresWebhookDeliveries) Due(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	return postgresFind[models.WebhookDelivery](ctx, p.postgresTable, "WHERE status = 'PENDING' AND next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $2", now, limit)
+}
+
+type postgresOutbox struct{ postgresTable }
+
+func (p postgresOutbox) Create(ctx context.Context, event models.OutboxEvent) (*mongo.InsertOneResult, error) {
+	return p.insertOne(ctx, event)
+}
+
+func (p postgresOutbox) Update(ctx context.Context, eventId string, set bson.D) (*mongo.UpdateResult, error) {
+	return p.update(ctx, eventId, set)
+}
+
+func (p postgresOutbox) Due(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	return postgresFind[models.OutboxEvent](ctx, p.postgresTable, "WHERE status = 'PENDING' AND next_attempt_at <= $1 ORDER BY id LIMIT $2", now, limit)
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"restaurant_management/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// The kinds of SearchHit.
+const (
+	FoodHit = "food"
+	MenuHit = "menu"
+)
+
+// SearchHit is a food or a menu matching a search, the higher its Score the
+// better the match. Menu_id is the menu of a food.
+type SearchHit struct {
+	Kind    string
+	ID      string
+	Name    string
+	Menu_id string
+	Score   float64
+}
+
+// The weights of the fields searched: a word of the name counts more than a
+// word of the tags, which counts more than a word of the description.
+const (
+	nameWeight        = 3
+	tagWeight         = 2
+	descriptionWeight = 1
+
+	// stemmedScore scores the documents the text index of the database
+	// matched, on the stem of a word, that the words searched didn't.
+	stemmedScore = 0.5
+)
+
+// searchField is a text searched and its weight.
+type searchField struct {
+	text   string
+	weight float64
+}
+
+// rankFoods returns the best limit hits of query among foods, stemmed
+// holding the IDs of the foods the text index of the database matched.
+func rankFoods(foods []models.Food, query string, stemmed map[string]bool, limit int) []SearchHit {
+	terms := searchTerms(query)
+	var hits []SearchHit
+	for _, food := range foods {
+		hit := SearchHit{Kind: FoodHit, ID: food.Food_id}
+		if food.Name != nil {
+			hit.Name = *food.Name
+		}
+		if food.Menu_id != nil {
+			hit.Menu_id = *food.Menu_id
+		}
+		fields := []searchField{{hit.Name, nameWeight}}
+		for _, tag := range food.Tags {
+			fields = append(fields, searchField{tag, tagWeight})
+		}
+		if food.Description != nil {
+			fields = append(fields, searchField{*food.Description, descriptionWeight})
+		}
+		hit.Score = rank(terms, fields)
+		if hit.Score == 0 && stemmed[hit.ID] {
+			hit.Score = stemmedScore
+		}
+		if hit.Score > 0 {
+			hits = append(hits, hit)
+		}
+	}
+	return bestHits(hits, limit)
+}
+
+// rankMenus returns the best limit hits of query among menus, stemmed
+// holding the IDs of the menus the text index of the database matched.
+func rankMenus(menus []models.Menu, query string, stemmed map[string]bool, limit int) []SearchHit {
+	terms := searchTerms(query)
+	var hits []SearchHit
+	for _, menu := range menus {
+		hit := SearchHit{Kind: MenuHit, ID: menu.Menu_id, Name: menu.Name}
+		hit.Score = rank(terms, []searchField{{menu.Name, nameWeight}, {menu.Category, descriptionWeight}})
+		if hit.Score == 0 && stemmed[hit.ID] {
+			hit.Score = stemmedScore
+		}
+		if hit.Score > 0 {
+			hits = append(hits, hit)
+		}
+	}
+	return bestHits(hits, limit)
+}
+
+// bestHits sorts hits by score, then by name, the shortest first, and keeps
+// limit of them.
+func bestHits(hits []SearchHit, limit int) []SearchHit {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if len(hits[i].Name) != len(hits[j].Name) {
+			return len(hits[i].Name) < len(hits[j].Name)
+		}
+		return hits[i].Name < hits[j].Name
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// rank scores fields against terms: every term has to match a word of a
+// field, the best match of each one being added up. A name starting with the
+// terms is ranked first.
+func rank(terms []string, fields []searchField) float64 {
+	if len(terms) == 0 || len(fields) == 0 {
+		return 0
+	}
+
+	var score float64
+	for _, term := range terms {
+		var best float64
+		for _, field := range fields {
+			for _, word := range searchTerms(field.text) {
+				if match := matchTerm(term, word) * field.weight; match > best {
+					best = match
+				}
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	// the first field is the name
+	if strings.HasPrefix(strings.Join(searchTerms(fields[0].text), " "), strings.Join(terms, " ")) {
+		score += nameWeight
+	}
+	return score
+}
+
+// matchTerm scores how well word matches term, searched: 1 for the same
+// word, less for a word term starts, for a typo or both, 0 otherwise. Short
+// terms tolerate no typo, long ones two, one less while they are typed.
+func matchTerm(term, word string) float64 {
+	switch {
+	case term == word:
+		return 1
+	case strings.HasPrefix(word, term):
+		return 0.8
+	}
+
+	typos := maxTypos(term)
+	if typos == 0 {
+		return 0
+	}
+	if distance := editDistance(term, word); distance <= typos {
+		return 0.7 - 0.2*float64(distance-1)
+	}
+	// a term being typed with a typo, tolerating one less
+	letters := []rune(word)
+	if n := len([]rune(term)); typos > 1 && len(letters) > n && editDistance(term, string(letters[:n])) < typos {
+		return 0.4
+	}
+	return 0
+}
+
+func maxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// editDistance returns the number of letters to insert, delete, substitute
+// or swap with the next one to turn a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between s[:i] and t[:j]
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// searchTerms splits text into its lower case words.
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
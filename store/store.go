@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"restaurant_management/models"
+	"time"
+)
+
+// ErrNotFound is returned when the requested document does not exist.
+var ErrNotFound = errors.New("document not found")
+
+// Store gives access to every collection of the restaurant database.
+type Store interface {
+	UnitOfWork
+
+	Users() UserStore
+	Foods() FoodStore
+	Menus() MenuStore
+	Tables() TableStore
+	Orders() OrderStore
+	OrderItems() OrderItemStore
+	Invoices() InvoiceStore
+	Webhooks() WebhookStore
+	WebhookDeliveries() WebhookDeliveryStore
+	Outbox() OutboxStore
+}
+
+// UnitOfWork runs fn so that every write fn performs through the store, using
+// the context it receives, is committed or rolled back together. Calling
+// WithTransaction again with that context joins the running unit of work.
+//
+// A Mongo store allowed to run on a standalone server can't roll back: fn
+// has to run every check that may fail before its first write, and write
+// first what may still be refused, like the portions of a food.
+type UnitOfWork interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type UserStore interface {
+	Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error)
+	Get(ctx context.Context, userId string) (models.User, error)
+	GetByEmail(ctx context.Context, email string) (models.User, error)
+	CountByEmail(ctx context.Context, email string) (int64, error)
+	CountByPhone(ctx context.Context, phone string) (int64, error)
+	Create(ctx context.Context, user models.User) (*mongo.InsertOneResult, error)
+	UpdateTokens(ctx context.Context, userId, token, refreshToken string) error
+	// UpdateAvatar sets the URLs of the avatar of the user and of its
+	// thumbnail.
+	UpdateAvatar(ctx context.Context, userId, avatar, thumbnail string) error
+}
+
+type FoodStore interface {
+	// Page returns a page of the foods matching filter and their number.
+	Page(ctx context.Context, filter FoodFilter, startIndex, recordPerPage int) ([]bson.M, int64, error)
+	Get(ctx context.Context, foodId string) (models.Food, error)
+	// GetMany returns the foods having one of foodIds, missing ones are left
+	// out.
+	GetMany(ctx context.Context, foodIds []string) ([]models.Food, error)
+	// ByMenus returns the foods of every menu of menuIds.
+	ByMenus(ctx context.Context, menuIds []string) ([]models.Food, error)
+	Create(ctx context.Context, food models.Food) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, foodId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, foodId string) (*mongo.DeleteResult, error)
+	// Limited returns the foods that are sold out or have a daily limit.
+	Limited(ctx context.Context) ([]models.Food, error)
+	// TakePortions counts n more portions of the food sold on day, a
+	// "2006-01-02" date, unless more than its daily limit would be sold that
+	// day. It returns the portions left that day, ok being false when there
+	// weren't n left or the food is missing. Foods without a daily limit
+	// always have n left, none being counted.
+	TakePortions(ctx context.Context, foodId, day string, n int) (left int, ok bool, err error)
+	// Search returns the limit foods whose name, tags or description best
+	// match query, tolerating typos and words being typed.
+	Search(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
+
+// FoodFilter selects foods, its zero value selects them all. The foods that
+// didn't declare their allergens or spice level are left out when they are
+// filtered on.
+type FoodFilter struct {
+	Category string
+	// Tags are tags the foods all have.
+	Tags []string
+	// AllergenFree are allergens the foods don't contain.
+	AllergenFree  []string
+	MaxSpiceLevel *int
+}
+
+type MenuStore interface {
+	All(ctx context.Context) ([]bson.M, error)
+	Get(ctx context.Context, menuId string) (models.Menu, error)
+	GetMany(ctx context.Context, menuIds []string) ([]models.Menu, error)
+	Create(ctx context.Context, menu models.Menu) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, menuId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, menuId string) (*mongo.DeleteResult, error)
+	// Search returns the limit menus whose name or category best match
+	// query, like FoodStore.Search.
+	Search(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
+
+type TableStore interface {
+	All(ctx context.Context) ([]bson.M, error)
+	Get(ctx context.Context, tableId string) (models.Table, error)
+	GetMany(ctx context.Context, tableIds []string) ([]models.Table, error)
+	Create(ctx context.Context, table models.Table) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, tableId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, tableId string) (*mongo.DeleteResult, error)
+}
+
+type OrderStore interface {
+	Page(ctx context.Context, startIndex, recordPerPage int) ([]bson.M, int64, error)
+	Get(ctx context.Context, orderId string) (models.Order, error)
+	GetMany(ctx context.Context, orderIds []string) ([]models.Order, error)
+	Create(ctx context.Context, order models.Order) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, orderId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, orderId string) (*mongo.DeleteResult, error)
+}
+
+type OrderItemStore interface {
+	All(ctx context.Context) ([]bson.M, error)
+	Get(ctx context.Context, orderItemId string) (models.OrderItem, error)
+	// ByOrders returns the items of every order of orderIds.
+	ByOrders(ctx context.Context, orderIds []string) ([]models.OrderItem, error)
+	CreateMany(ctx context.Context, orderItems []models.OrderItem) (*mongo.InsertManyResult, error)
+	Update(ctx context.Context, orderItemId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, orderItemId string) (*mongo.DeleteResult, error)
+	// ItemsByOrder joins the items of an order with their food, order and
+	// table and groups them into a single summary with the amount due.
+	ItemsByOrder(ctx context.Context, orderId string) ([]bson.M, error)
+}
+
+type InvoiceStore interface {
+	All(ctx context.Context) ([]bson.M, error)
+	Get(ctx context.Context, invoiceId string) (models.Invoice, error)
+	Create(ctx context.Context, invoice models.Invoice) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, invoiceId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, invoiceId string) (*mongo.DeleteResult, error)
+}
+
+// Decode converts the documents returned by the list operations into T.
+func Decode[T any](docs []bson.M) ([]T, error) {
+	values := make([]T, len(docs))
+	for i, doc := range docs {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		if err := bson.Unmarshal(data, &values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+type WebhookStore interface {
+	All(ctx context.Context) ([]bson.M, error)
+	Get(ctx context.Context, webhookId string) (models.Webhook, error)
+	// Subscribed returns the active webhooks subscribed to event.
+	Subscribed(ctx context.Context, event string) ([]models.Webhook, error)
+	Create(ctx context.Context, webhook models.Webhook) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, webhookId string, set bson.D) (*mongo.UpdateResult, error)
+	Delete(ctx context.Context, webhookId string) (*mongo.DeleteResult, error)
+}
+
+type WebhookDeliveryStore interface {
+	// ByWebhook returns the last limit deliveries of a webhook, the latest
+	// first.
+	ByWebhook(ctx context.Context, webhookId string, limit int) ([]models.WebhookDelivery, error)
+	Get(ctx context.Context, deliveryId string) (models.WebhookDelivery, error)
+	Create(ctx context.Context, delivery models.WebhookDelivery) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, deliveryId string, set bson.D) (*mongo.UpdateResult, error)
+	// Due returns up to limit PENDING deliveries whose next attempt is at or
+	// before now, the oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+}
+
+// OutboxStore holds the domain events until they are published. Events are
+// written in the unit of work of the change they record.
+type OutboxStore interface {
+	Create(ctx context.Context, event models.OutboxEvent) (*mongo.InsertOneResult, error)
+	Update(ctx context.Context, eventId string, set bson.D) (*mongo.UpdateResult, error)
+	// Due returns up to limit PENDING events whose next attempt is at or
+	// before now, in the order they were written.
+	Due(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error)
+}
+
+type txKey struct{}
+
+// withinTransaction marks ctx as belonging to a running unit of work.
+func withinTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, true)
+}
+
+// inTransaction reports whether ctx already belongs to a unit of work.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(bool)
+	return ok
+}
+
+// now returns the current time truncated to the second, the way every
+// timestamp of the database is stored.
+func now() time.Time {
+	t, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	return t
+}
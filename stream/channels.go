@@ -0,0 +1,120 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/service"
+	"strings"
+)
+
+const (
+	Orders  = "orders"
+	Tables  = "tables"
+	Kitchen = "kitchen"
+	SoldOut = "sold-out"
+
+	tablePrefix   = "table:"
+	kitchenPrefix = "kitchen:"
+)
+
+// TableChannel returns the channel of everything happening on a table.
+func TableChannel(tableId string) string {
+	return tablePrefix + tableId
+}
+
+// StationChannel returns the channel of the items a kitchen station prepares.
+func StationChannel(station string) string {
+	return kitchenPrefix + station
+}
+
+// validChannel reports whether clients may subscribe to channel.
+func validChannel(channel string) error {
+	switch {
+	case channel == Orders || channel == Tables || channel == Kitchen || channel == SoldOut:
+		return nil
+	case strings.HasPrefix(channel, tablePrefix) && len(channel) > len(tablePrefix):
+		return nil
+	case strings.HasPrefix(channel, kitchenPrefix) && len(channel) > len(kitchenPrefix):
+		return nil
+	}
+	return fmt.Errorf("unknown channel %q", channel)
+}
+
+// refs are the fields of the event documents channels are derived from.
+type refs struct {
+	Table_id *string
+	Order_id string
+	Food_id  *string
+}
+
+// channelsOf returns the channels event is sent to. Documents the event
+// refers to that can't be read only leave out the channels they lead to.
+func channelsOf(ctx context.Context, event events.Event) []string {
+	var doc refs
+	if err := json.Unmarshal(event.Data, &doc); err != nil {
+		logger.FromContext(ctx).Error("stream event has an unexpected payload", zap.String("type", event.Type), zap.Error(err))
+		return nil
+	}
+
+	var channels []string
+	switch event.Type {
+	case events.TableOccupied, events.TableFreed:
+		channels = append(channels, Tables)
+		if doc.Table_id != nil {
+			channels = append(channels, TableChannel(*doc.Table_id))
+		}
+
+	case events.OrderPlaced, events.OrderUpdated, events.OrderDeleted:
+		channels = append(channels, Orders)
+		if doc.Table_id != nil {
+			channels = append(channels, TableChannel(*doc.Table_id))
+		}
+
+	case events.InvoiceCreated, events.InvoiceUpdated, events.InvoicePaid:
+		channels = append(channels, Orders)
+		if tableId := tableOfOrder(ctx, doc.Order_id); tableId != "" {
+			channels = append(channels, TableChannel(tableId))
+		}
+
+	case events.ItemAdded, events.ItemUpdated, events.ItemDeleted:
+		channels = append(channels, Orders, Kitchen)
+		if tableId := tableOfOrder(ctx, doc.Order_id); tableId != "" {
+			channels = append(channels, TableChannel(tableId))
+		}
+		if doc.Food_id != nil {
+			if station := stationOfFood(ctx, *doc.Food_id); station != "" {
+				channels = append(channels, StationChannel(station))
+			}
+		}
+
+	case events.FoodSoldOut, events.FoodRestored:
+		channels = append(channels, SoldOut, Kitchen)
+	}
+	return channels
+}
+
+func tableOfOrder(ctx context.Context, orderId string) string {
+	order, err := service.GetOrder(ctx, orderId)
+	if err != nil || order.Table_id == nil {
+		return ""
+	}
+	return *order.Table_id
+}
+
+// stationOfFood returns the kitchen station preparing a food: the category
+// of its menu.
+func stationOfFood(ctx context.Context, foodId string) string {
+	food, err := service.GetFood(ctx, foodId)
+	if err != nil || food.Menu_id == nil {
+		return ""
+	}
+	menu, err := service.GetMenu(ctx, *food.Menu_id)
+	if err != nil {
+		return ""
+	}
+	return menu.Category
+}
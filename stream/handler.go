@@ -0,0 +1,190 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Handler serves /stream: over WebSocket when the request asks for an
+// upgrade, as Server-Sent Events when it accepts them. The channel query parameters are
+// the channels subscribed to. Connections are kept alive with a comment or a
+// ping every heartbeat.
+func Handler(hub *Hub, heartbeat time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		channels := c.QueryArray("channel")
+		for _, channel := range channels {
+			if err := validChannel(channel); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		if websocket.IsWebSocketUpgrade(c.Request) {
+			serveWebSocket(c, hub, channels, heartbeat)
+			return
+		}
+
+		if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.JSON(http.StatusNotAcceptable, gin.H{"error": "accept text/event-stream or upgrade to a WebSocket"})
+			return
+		}
+		if len(channels) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "subscribe to at least one channel"})
+			return
+		}
+		serveEvents(c, hub, channels, heartbeat)
+	}
+}
+
+// serveEvents sends the messages as Server-Sent Events, named after the type
+// of their event.
+func serveEvents(c *gin.Context, hub *Hub, channels []string, heartbeat time.Duration) {
+	client := hub.subscribe("sse", channels)
+	defer hub.unsubscribe(client)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// proxies must not buffer the stream
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// a comment tells the client it is subscribed before the first event
+	fmt.Fprint(c.Writer, ": subscribed\n\n")
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-client.dropped:
+			return
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		case msg := <-client.send:
+			data, err := json.Marshal(msg)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, data)
+		}
+		c.Writer.Flush()
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	// clients authenticate with a token rather than cookies, pages of any
+	// origin may connect
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// command changes the channels of a WebSocket client.
+type command struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+}
+
+// reply acknowledges a command, or tells why it was refused.
+type reply struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+	Error   string `json:"error,omitempty"`
+}
+
+// serveWebSocket sends the messages as JSON text messages. The client may
+// subscribe to and unsubscribe from channels by sending commands:
+//
+//	{"action": "subscribe", "channel": "table:..."}
+func serveWebSocket(c *gin.Context, hub *Hub, channels []string, heartbeat time.Duration) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader already answered
+		return
+	}
+	defer conn.Close()
+
+	client := hub.subscribe("websocket", channels)
+	defer hub.unsubscribe(client)
+
+	done := make(chan struct{})
+	defer close(done)
+	replies := make(chan reply)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		readCommands(conn, client, heartbeat, replies, done)
+	}()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-closed:
+			return
+		case <-client.dropped:
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(client.closeCode, client.closeText), time.Now().Add(time.Second))
+			return
+		case <-ticker.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(heartbeat))
+		case r := <-replies:
+			conn.SetWriteDeadline(time.Now().Add(heartbeat))
+			err = conn.WriteJSON(r)
+		case msg := <-client.send:
+			conn.SetWriteDeadline(time.Now().Add(heartbeat))
+			err = conn.WriteJSON(msg)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readCommands applies the commands of a WebSocket client until the
+// connection is closed or stays silent, pongs included, for two heartbeats.
+func readCommands(conn *websocket.Conn, client *client, heartbeat time.Duration, replies chan<- reply, done <-chan struct{}) {
+	conn.SetReadLimit(4 << 10)
+	conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+
+		var cmd command
+		err = json.Unmarshal(data, &cmd)
+		r := reply{Action: cmd.Action, Channel: cmd.Channel}
+		if err != nil {
+			r.Error = "invalid command: " + err.Error()
+		} else if err := validChannel(cmd.Channel); err != nil {
+			r.Error = err.Error()
+		} else if cmd.Action == "subscribe" {
+			client.subscribe(cmd.Channel)
+		} else if cmd.Action == "unsubscribe" {
+			client.unsubscribe(cmd.Channel)
+		} else {
+			r.Error = fmt.Sprintf("unknown action %q", cmd.Action)
+		}
+
+		select {
+		case replies <- r:
+		case <-done:
+			return
+		}
+	}
+}
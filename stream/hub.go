@@ -0,0 +1,166 @@
+// Package stream pushes the domain events to the clients of /stream as they
+// happen, over Server-Sent Events or WebSocket, so that waiters and the
+// kitchen don't have to poll.
+//
+// Clients subscribe to channels:
+//
+//	orders             orders placed, items added and invoices paid
+//	tables             tables occupied and freed
+//	table:<table_id>   everything happening on a table: the table, its orders,
+//	                   their items and invoices
+//	kitchen            items added, foods sold out and restored
+//	kitchen:<station>  items added whose food belongs to a menu of category
+//	                   <station>
+//	sold-out           foods sold out and restored, the changes of the 86
+//	                   list
+//
+// The hub is a transport of the event bus: a client gets the events relayed
+// by the instance it is connected to.
+package stream
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"sync"
+)
+
+// Message is an event as clients get it, with the channels it was sent to
+// among those they subscribed to.
+type Message struct {
+	Channels []string `json:"channels"`
+	events.Event
+}
+
+// bufferSize is the number of messages a client may lag behind before it is
+// disconnected, a slow client never holds the others back.
+const bufferSize = 64
+
+// Hub sends the events to the clients subscribed to their channels.
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{clients: map[*client]struct{}{}}
+}
+
+func (h *Hub) Name() string {
+	return "stream"
+}
+
+// Send hands event to the clients subscribed to one of its channels.
+func (h *Hub) Send(ctx context.Context, event events.Event) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.clients) == 0 {
+		return nil
+	}
+
+	channels := channelsOf(ctx, event)
+	for c := range h.clients {
+		matched := c.matching(channels)
+		if len(matched) == 0 {
+			continue
+		}
+
+		select {
+		case c.send <- Message{Channels: matched, Event: event}:
+		default:
+			logger.FromContext(ctx).Warn("stream client too slow, disconnecting", zap.String("transport", c.transport))
+			c.drop(websocket.ClosePolicyViolation, "too slow")
+		}
+	}
+	return nil
+}
+
+// Close disconnects every client, the server is going away.
+func (h *Hub) Close() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients {
+		c.drop(websocket.CloseGoingAway, "server shutting down")
+	}
+}
+
+// subscribe connects a client to channels.
+func (h *Hub) subscribe(transport string, channels []string) *client {
+	c := &client{
+		transport: transport,
+		channels:  map[string]bool{},
+		send:      make(chan Message, bufferSize),
+		dropped:   make(chan struct{}),
+	}
+	c.subscribe(channels...)
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	metrics.StreamConnected(transport)
+	return c
+}
+
+// unsubscribe disconnects a client.
+func (h *Hub) unsubscribe(c *client) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+	metrics.StreamDisconnected(c.transport)
+}
+
+// client is a connection to /stream.
+type client struct {
+	transport string
+
+	mu       sync.Mutex
+	channels map[string]bool
+
+	send     chan Message
+	dropOnce sync.Once
+	// dropped is closed when the client is disconnected by the server, for
+	// the reason told by closeCode and closeText.
+	dropped   chan struct{}
+	closeCode int
+	closeText string
+}
+
+func (c *client) subscribe(channels ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, channel := range channels {
+		c.channels[channel] = true
+	}
+}
+
+func (c *client) unsubscribe(channels ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, channel := range channels {
+		delete(c.channels, channel)
+	}
+}
+
+// matching returns the channels the client subscribed to among channels.
+func (c *client) matching(channels []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matched []string
+	for _, channel := range channels {
+		if c.channels[channel] {
+			matched = append(matched, channel)
+		}
+	}
+	return matched
+}
+
+func (c *client) drop(code int, text string) {
+	c.dropOnce.Do(func() {
+		c.closeCode, c.closeText = code, text
+		close(c.dropped)
+	})
+}
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// ErrPrivateAddress rejects the receivers that aren't on the internet: a
+// webhook would let its owner reach the hosts next to the API, e.g. the
+// metadata service of the cloud at 169.254.169.254.
+var ErrPrivateAddress = errors.New("webhook receivers can't be on loopback, private or link-local addresses")
+
+// reservedNetworks aren't public without being covered by the methods of
+// net.IP.
+var reservedNetworks = parseNetworks(
+	"0.0.0.0/8",     // this network
+	"100.64.0.0/10", // shared address space
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved
+	"64:ff9b::/96",  // NAT64, maps the IPv4 addresses
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// publicIP reports whether ip can be reached by the deliveries.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialControl rejects the connections to the addresses that aren't public.
+// It runs once the host is resolved, for every connection, so a host
+// resolving to a public address when the webhook is registered and to a
+// private one when it is delivered to is rejected too.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// CheckURL rejects the URL of a webhook whose host doesn't resolve to public
+// addresses, unless the private networks are allowed.
+func (d *Dispatcher) CheckURL(ctx context.Context, rawURL string) error {
+	if d.opts.AllowPrivateNetworks {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("%s can't be resolved", u.Hostname())
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return ErrPrivateAddress
+		}
+	}
+	return nil
+}
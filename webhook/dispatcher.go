@@ -0,0 +1,275 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"net/http"
+	"restaurant_management/events"
+	"restaurant_management/logger"
+	"restaurant_management/metrics"
+	"restaurant_management/models"
+	"restaurant_management/store"
+	"strconv"
+	"time"
+)
+
+// The status of a delivery.
+const (
+	Pending   = "PENDING"
+	Succeeded = "SUCCEEDED"
+	Failed    = "FAILED"
+)
+
+type Options struct {
+	// MaxAttempts is the number of attempts after which a delivery FAILED.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every
+	// failed attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds every attempt.
+	Timeout time.Duration
+	// PollInterval is how often the pending deliveries are looked for.
+	PollInterval time.Duration
+	// AllowPrivateNetworks lets the receivers be on loopback, private or
+	// link-local addresses, for development.
+	AllowPrivateNetworks bool
+}
+
+// Dispatcher is the transport of the events to the webhooks: it records the
+// deliveries of the events and sends them. The deliveries are stored before
+// being sent, so that the pending ones survive a restart.
+type Dispatcher struct {
+	store  store.Store
+	opts   Options
+	client *http.Client
+	wake   chan struct{}
+}
+
+func NewDispatcher(s store.Store, opts Options) *Dispatcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !opts.AllowPrivateNetworks {
+		// a proxy would be the address checked
+		transport.Proxy = nil
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &Dispatcher{
+		store:  s,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout, Transport: transport},
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhooks"
+}
+
+// Send records a delivery of event to every webhook subscribed to it, sent
+// in the background.
+func (d *Dispatcher) Send(ctx context.Context, event events.Event) error {
+	webhooks, err := d.store.Webhooks().Subscribed(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	err = d.store.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, webhook := range webhooks {
+			delivery := newDelivery(webhook.Webhook_id, event.ID, event.Type, string(payload), createdAt)
+			if _, err := d.store.WebhookDeliveries().Create(ctx, delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.wakeUp()
+	return nil
+}
+
+// Redeliver sends the event of a delivery again, as a new delivery.
+func (d *Dispatcher) Redeliver(ctx context.Context, deliveryId string) (models.WebhookDelivery, error) {
+	original, err := d.store.WebhookDeliveries().Get(ctx, deliveryId)
+	if err != nil {
+		return original, err
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+	delivery := newDelivery(original.Webhook_id, original.Event_id, original.Event, original.Payload, createdAt)
+	delivery.Redelivery_of = original.Delivery_id
+	if _, err := d.store.WebhookDeliveries().Create(ctx, delivery); err != nil {
+		return delivery, err
+	}
+
+	d.wakeUp()
+	return delivery, nil
+}
+
+func newDelivery(webhookId, eventId, event, payload string, createdAt time.Time) models.WebhookDelivery {
+	delivery := models.WebhookDelivery{
+		ID:              primitive.NewObjectID(),
+		Webhook_id:      webhookId,
+		Event_id:        eventId,
+		Event:           event,
+		Payload:         payload,
+		Status:          Pending,
+		Next_attempt_at: createdAt,
+		Created_at:      createdAt,
+		Updated_at:      createdAt,
+	}
+	delivery.Delivery_id = delivery.ID.Hex()
+	return delivery
+}
+
+func (d *Dispatcher) wakeUp() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends the pending deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// deliverDue sends the due deliveries by batches, until a batch isn't full.
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	const batchSize = 20
+
+	for ctx.Err() == nil {
+		due, err := d.store.WebhookDeliveries().Due(ctx, time.Now(), batchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("listing the due webhook deliveries failed", zap.Error(err))
+			return
+		}
+
+		for _, delivery := range due {
+			d.attempt(ctx, delivery)
+		}
+		if len(due) < batchSize {
+			return
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome, scheduling the next
+// attempt when it failed and attempts are left.
+func (d *Dispatcher) attempt(ctx context.Context, delivery models.WebhookDelivery) {
+	log := logger.FromContext(ctx).With(zap.String("delivery_id", delivery.Delivery_id), zap.String("event", delivery.Event))
+
+	responseStatus, err := d.send(ctx, delivery)
+	attempts := delivery.Attempts + 1
+	now, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
+
+	set := bson.D{
+		{"attempts", attempts},
+		{"response_status", responseStatus},
+		{"updated_at", now},
+	}
+	var outcome string
+	switch {
+	case err == nil:
+		outcome = "succeeded"
+		set = append(set, bson.E{"status", Succeeded}, bson.E{"last_error", ""})
+	case attempts >= d.opts.MaxAttempts || errors.Is(err, errGone):
+		outcome = "failed"
+		set = append(set, bson.E{"status", Failed}, bson.E{"last_error", err.Error()})
+		log.Warn("webhook delivery failed", zap.Int("attempts", attempts), zap.Error(err))
+	default:
+		outcome = "retried"
+		set = append(set, bson.E{"last_error", err.Error()}, bson.E{"next_attempt_at", now.Add(d.backoff(attempts))})
+	}
+	metrics.WebhookDelivery(delivery.Event, outcome)
+
+	if _, err := d.store.WebhookDeliveries().Update(ctx, delivery.Delivery_id, set); err != nil {
+		log.Error("recording the webhook delivery failed", zap.Error(err))
+	}
+}
+
+// errGone fails a delivery right away, its webhook was deleted or disabled.
+var errGone = errors.New("webhook was deleted or disabled")
+
+func (d *Dispatcher) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	webhook, err := d.store.Webhooks().Get(ctx, delivery.Webhook_id)
+	if errors.Is(err, store.ErrNotFound) || (err == nil && webhook.Active != nil && !*webhook.Active) {
+		return 0, errGone
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "restaurant-management-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.Delivery_id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(*webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.opts.Backoff
+	for i := 1; i < attempts && delay < d.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.opts.MaxBackoff {
+		delay = d.opts.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,52 @@
+// Package webhook delivers the domain events, see package events, to the
+// URLs subscribed to them.
+//
+// Every delivery is a POST of a JSON event:
+//
+//	{"id": "...", "type": "invoice.paid", "created_at": "...", "data": {...}}
+//
+// signed with the secret of the webhook. The X-Webhook-Signature header holds
+// "sha256=" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header,
+// a dot and the body. Deliveries are retried with an exponential backoff
+// until the receiver answers 2xx, so a receiver may get an event more than
+// once: X-Webhook-Delivery identifies a delivery, the id of the event is the
+// same across deliveries and redeliveries.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns the signature of body sent at timestamp, in unix seconds.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a delivery, rejecting
+// deliveries signed more than tolerance ago to prevent replays.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) bool {
+	ts, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}